@@ -11,6 +11,7 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim/compare/versions"
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/baseline"
 	"github.com/spf13/cobra"
 )
 
@@ -71,17 +72,29 @@ CNIs: Only in CLAIM 2
  - claim.nodes.csiDriver
  - claim.nodes.nodesHwInfo
  - claim.nodes.nodeSummary
+
+With --baseline, the claim file 2 is compared with the baseline claim file instead, to adopt the Cert Suite
+incrementally without fixing every existing failure first. Each test case whose result changed is classified as:
+ - new-failure: failed (or errored) in claim 2 but not in the baseline.
+ - fixed: failed (or errored) in the baseline and passed in claim 2.
+ - still-failing: failed (or errored) in both. Its non-compliant objects are compared too.
+ - newly-skipped: skipped in claim 2 but not in the baseline.
+The non-compliant objects found in claim 2 only, and those found in the baseline only, are listed for each test case.
+New failures, and still failing test cases with new non-compliant objects, are regressions. With --gate, the
+command exits with code 1 if there are regressions.
 `
 
 var (
-	Claim1FilePathFlag string
-	Claim2FilePathFlag string
+	Claim1FilePathFlag   string
+	Claim2FilePathFlag   string
+	BaselineFilePathFlag string
+	GateFlag             bool
 
 	claimCompareFiles = &cobra.Command{
 		Use:     "compare",
 		Short:   "Compare two claim files.",
 		Long:    longHelp,
-		Example: "claim compare -1 claim1.json -2 claim2.json",
+		Example: "claim compare -1 claim1.json -2 claim2.json\nclaim compare --baseline baseline.json -2 claim.json --gate",
 		RunE:    claimCompare,
 	}
 )
@@ -95,12 +108,17 @@ func NewCommand() *cobra.Command {
 		&Claim2FilePathFlag, "claim2", "2", "",
 		"existing claim2 file. (Required) second file to compare",
 	)
-	err := claimCompareFiles.MarkFlagRequired("claim1")
-	if err != nil {
-		log.Error("Failed to mark flag claim1 as required: %v", err)
-		return nil
-	}
-	err = claimCompareFiles.MarkFlagRequired("claim2")
+	claimCompareFiles.Flags().StringVarP(
+		&BaselineFilePathFlag, "baseline", "b", "",
+		"existing baseline claim file, whose results are compared with the claim2 ones to find regressions",
+	)
+	claimCompareFiles.Flags().BoolVar(
+		&GateFlag, "gate", false,
+		"with --baseline, exit with code 1 if claim2 has regressions",
+	)
+	claimCompareFiles.MarkFlagsOneRequired("claim1", "baseline")
+	claimCompareFiles.MarkFlagsMutuallyExclusive("claim1", "baseline")
+	err := claimCompareFiles.MarkFlagRequired("claim2")
 	if err != nil {
 		log.Error("Failed to mark flag claim2 as required: %v", err)
 		return nil
@@ -110,6 +128,21 @@ func NewCommand() *cobra.Command {
 }
 
 func claimCompare(_ *cobra.Command, _ []string) error {
+	if BaselineFilePathFlag != "" {
+		report, err := claimCompareBaselinefunc(BaselineFilePathFlag, Claim2FilePathFlag)
+		if err != nil {
+			log.Fatal("Error comparing claim file with the baseline: %v", err)
+		}
+		if GateFlag && report.HasRegressions() {
+			os.Exit(1)
+		}
+		return nil
+	}
+
+	if GateFlag {
+		log.Fatal("Flag --gate requires --baseline")
+	}
+
 	err := claimCompareFilesfunc(Claim1FilePathFlag, Claim2FilePathFlag)
 	if err != nil {
 		log.Fatal("Error comparing claim files: %v", err)
@@ -161,6 +194,35 @@ func claimCompareFilesfunc(claim1, claim2 string) error {
 	return nil
 }
 
+// claimCompareBaselinefunc prints the test cases of the claim file whose result changed since the
+// baseline claim file, or still failing, and returns the report.
+func claimCompareBaselinefunc(baselineClaim, claim2 string) (*baseline.Report, error) {
+	baselineData, err := os.ReadFile(baselineClaim)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading baseline claim file: %w", err)
+	}
+
+	claimdata2, err := os.ReadFile(claim2)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading claim2 file: %w", err)
+	}
+
+	baselineFileData, err := unmarshalClaimFile(baselineData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal baseline claim file: %w", err)
+	}
+
+	claimFile2Data, err := unmarshalClaimFile(claimdata2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal claim2 file: %w", err)
+	}
+
+	report := baseline.GetReport(baselineFileData.ToOfficialClaim().Results, claimFile2Data.ToOfficialClaim().Results)
+	fmt.Print(report)
+
+	return report, nil
+}
+
 func unmarshalClaimFile(claimdata []byte) (claim.Schema, error) {
 	var claimDataResult claim.Schema
 	err := json.Unmarshal(claimdata, &claimDataResult)
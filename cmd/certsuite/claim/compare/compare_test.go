@@ -100,3 +100,15 @@ func Test_claimCompareFilesfunc(t *testing.T) {
 		})
 	}
 }
+
+func Test_claimCompareBaselinefunc(t *testing.T) {
+	_, err := claimCompareBaselinefunc("not_found.json", "testdata/claim_access_control.json")
+	assert.EqualError(t, err, "failed reading baseline claim file: open not_found.json: no such file or directory")
+
+	_, err = claimCompareBaselinefunc("testdata/claim_observability.json", "testdata/invalid.json")
+	assert.EqualError(t, err, "failed to unmarshal claim2 file: failed to unmarshal claim file data: invalid character 'T' looking for beginning of value")
+
+	report, err := claimCompareBaselinefunc("testdata/claim_observability.json", "testdata/claim_observability.json")
+	assert.Nil(t, err)
+	assert.False(t, report.HasRegressions())
+}
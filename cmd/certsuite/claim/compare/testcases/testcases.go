@@ -72,7 +72,7 @@ func getTestCasesResultsSummary(results map[string]string) TcResultsSummary {
 
 	for _, result := range results {
 		switch result {
-		case claim.TestCaseResultPassed:
+		case claim.TestCaseResultPassed, claim.TestCaseResultPassedAfterRetry, claim.TestCaseResultPassedWithWaivers:
 			summary.Passed++
 		case claim.TestCaseResultSkipped:
 			summary.Skipped++
@@ -0,0 +1,99 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package merge
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/spf13/cobra"
+)
+
+const minClaimFiles = 2
+
+const longHelp = `Merges the results of several claim files, e.g. from runs per namespace or per label filter, into a single
+claim file. The claim format version of every claim file must be supported.
+
+When a test case has results in several claim files, the --strategy flag decides which one is kept:
+ - worst-state-wins: the worst result, from skipped to passed, passed-after-retry, passed-with-waivers, error and
+   failed, with the objects reported by all the results of the test case that did not skip.
+ - latest-wins: the result from the claim file that ended last.
+Skipped results only win when the test case was skipped in every claim file.
+
+The configurations and nodes of the claim files are combined, and the versions are the ones of the claim file that
+ended last. The merged claim file records the claim files it comes from, and the ones with a result of each test
+case, in the mergeProvenance field of its configurations.
+`
+
+var (
+	outputFilePathFlag string
+	strategyFlag       string
+
+	claimMergeCommand = &cobra.Command{
+		Use:     "merge <claim file>...",
+		Short:   "Merge several claim files into one.",
+		Long:    longHelp,
+		Example: "claim merge results-ns1/claim.json results-ns2/claim.json -o merged.json --strategy worst-state-wins",
+		Args:    cobra.MinimumNArgs(minClaimFiles),
+		RunE:    claimMerge,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	claimMergeCommand.Flags().StringVarP(&outputFilePathFlag, "output", "o", "",
+		"Required: merged claim file path.",
+	)
+	claimMergeCommand.Flags().StringVar(&strategyFlag, "strategy", claimhelper.MergeStrategyWorstState,
+		"Result kept when a test case has results in several claim files: worst-state-wins or latest-wins.",
+	)
+
+	err := claimMergeCommand.MarkFlagRequired("output")
+	if err != nil {
+		log.Fatalf("Failed to mark output file path as required parameter: %v", err)
+		return nil
+	}
+
+	return claimMergeCommand
+}
+
+func claimMerge(_ *cobra.Command, args []string) error {
+	return mergeClaimFiles(args, outputFilePathFlag, strategyFlag)
+}
+
+// mergeClaimFiles merges the claim files into the output claim file with the strategy.
+func mergeClaimFiles(claimFilePaths []string, outputFilePath, strategy string) error {
+	strategy, err := claimhelper.GetMergeStrategy(strategy)
+	if err != nil {
+		return err
+	}
+
+	claimFiles := []claimhelper.ClaimFile{}
+	for _, claimFilePath := range claimFilePaths {
+		claimScheme, err := claim.Parse(claimFilePath)
+		if err != nil {
+			return fmt.Errorf("failed to parse claim file %s: %w", claimFilePath, err)
+		}
+
+		err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
+		if err != nil {
+			return fmt.Errorf("claim file %s: %w", claimFilePath, err)
+		}
+
+		root, err := claimhelper.LoadClaimFile(claimFilePath)
+		if err != nil {
+			return err
+		}
+		claimFiles = append(claimFiles, claimhelper.ClaimFile{Name: claimFilePath, Root: root})
+	}
+
+	merged, err := claimhelper.MergeClaims(claimFiles, strategy)
+	if err != nil {
+		return fmt.Errorf("failed to merge the claim files: %w", err)
+	}
+
+	claimhelper.WriteClaimOutput(outputFilePath, claimhelper.MarshalClaimOutput(merged))
+	fmt.Printf("Merged %d claim files into %s\n", len(claimFiles), outputFilePath)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package merge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeClaimFiles(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "merged.json")
+	err := mergeClaimFiles([]string{"testdata/claim_ns2.json", "testdata/claim_ns1.json"}, outputFile, "worst-state-wins")
+	require.NoError(t, err)
+
+	merged, err := claim.Parse(outputFile)
+	require.NoError(t, err)
+	assert.NoError(t, claim.CheckVersion(merged.Claim.Versions.ClaimFormat))
+	assert.Equal(t, "2026-10-17 00:00:00 +0000 UTC", merged.Claim.Metadata.StartTime)
+	assert.Equal(t, "2026-10-17 00:20:00 +0000 UTC", merged.Claim.Metadata.EndTime)
+	assert.Equal(t, claim.TestCaseResultFailed, merged.Claim.Results["lifecycle-pod-owner-type"].State)
+	assert.Contains(t, merged.Claim.Results["lifecycle-pod-owner-type"].CheckDetails, "pod-b")
+	assert.Equal(t, claim.TestCaseResultPassed, merged.Claim.Results["lifecycle-pod-scheduling"].State)
+	assert.Equal(t, claim.TestCaseResultPassed, merged.Claim.Results["observability-crd-status"].State)
+
+	payload, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), `"mergeProvenance"`)
+}
+
+func TestMergeClaimFilesErrors(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "merged.json")
+
+	err := mergeClaimFiles([]string{"testdata/claim_ns1.json", "testdata/claim_ns2.json"}, outputFile, "first-wins")
+	assert.EqualError(t, err, `invalid merge strategy "first-wins", valid strategies are worst-state-wins, latest-wins`)
+
+	err = mergeClaimFiles([]string{"testdata/claim_ns1.json", "not_found.json"}, outputFile, "latest-wins")
+	assert.ErrorContains(t, err, "failed to parse claim file not_found.json")
+
+	payload, err := os.ReadFile("testdata/claim_ns2.json")
+	require.NoError(t, err)
+	oldClaimFile := filepath.Join(t.TempDir(), "old.json")
+	require.NoError(t, os.WriteFile(oldClaimFile, []byte(strings.Replace(string(payload), `"v0.5.0"`, `"v0.1.0"`, 1)), 0o600))
+	err = mergeClaimFiles([]string{"testdata/claim_ns1.json", oldClaimFile}, outputFile, "latest-wins")
+	assert.EqualError(t, err, "claim file "+oldClaimFile+": claim format version v0.1.0 is not supported. Supported version is v0.5.0")
+
+	assert.NoFileExists(t, outputFile)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package redact
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/spf13/cobra"
+)
+
+const longHelp = `Redacts a claim file before sharing it, e.g. with vendors, with a redaction profile: a YAML file with rules
+applied in order, each of them redacting the values at some paths of the claim, or the parts of them matching a regex.
+
+The built-in "default" profile hashes the node names, hostnames, IP addresses and image registries, and masks the
+environment variable values and the annotations.
+
+Hashed values are replaced with a pseudonym, the rule name and the start of their salted hash, e.g. node-3f9a1c07b2e4.
+The values found by the rules that propagate, like the node names, are replaced everywhere in the claim, including
+the test cases results. Claim files redacted with the same profile and salt have the same pseudonyms, so they can
+still be compared with "certsuite claim compare". Without a salt, neither in the profile nor set with --salt, a random
+one is used, as values like IP addresses can be guessed from their unsalted hash.
+`
+
+var (
+	outputFilePathFlag string
+	profileFlag        string
+	saltFlag           string
+
+	claimRedactCommand = &cobra.Command{
+		Use:     "redact <claim file>",
+		Short:   "Redact the sensitive data of a claim file.",
+		Long:    longHelp,
+		Example: "claim redact results/claim.json -o claim-redacted.json --profile default --salt \"$SALT\"",
+		Args:    cobra.ExactArgs(1),
+		RunE:    claimRedact,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	claimRedactCommand.Flags().StringVarP(&outputFilePathFlag, "output", "o", "",
+		"Required: redacted claim file path.",
+	)
+	claimRedactCommand.Flags().StringVarP(&profileFlag, "profile", "p", claimhelper.DefaultRedactionProfile,
+		"Name of a built-in redaction profile, or path of a redaction profile file.",
+	)
+	claimRedactCommand.Flags().StringVar(&saltFlag, "salt", "",
+		"Salt of the pseudonyms hashes, instead of the one of the profile.",
+	)
+
+	err := claimRedactCommand.MarkFlagRequired("output")
+	if err != nil {
+		log.Fatalf("Failed to mark output file path as required parameter: %v", err)
+		return nil
+	}
+
+	return claimRedactCommand
+}
+
+func claimRedact(_ *cobra.Command, args []string) error {
+	return redactClaimFile(args[0], outputFilePathFlag, profileFlag, saltFlag)
+}
+
+// redactClaimFile saves the claim file redacted with the profile, and the salt if not empty, in the
+// output file. Without a salt, neither given nor in the profile, a random one is used.
+func redactClaimFile(claimFilePath, outputFilePath, profileNameOrFile, salt string) error {
+	profile, err := claimhelper.LoadRedactionProfile(profileNameOrFile)
+	if err != nil {
+		return err
+	}
+	if err := profile.SetSalt(salt); err != nil {
+		return err
+	}
+
+	claimScheme, err := claim.Parse(claimFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse claim file %s: %w", claimFilePath, err)
+	}
+
+	err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
+	if err != nil {
+		return fmt.Errorf("claim file %s: %w", claimFilePath, err)
+	}
+
+	return claimhelper.RedactClaimFile(claimFilePath, outputFilePath, profile)
+}
@@ -0,0 +1,46 @@
+package redact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactClaimFile(t *testing.T) {
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "redacted.json")
+	require.NoError(t, redactClaimFile("testdata/claim.json", outputFile, "default", "salt"))
+
+	redacted, err := claim.Parse(outputFile)
+	require.NoError(t, err)
+	assert.NoError(t, claim.CheckVersion(redacted.Claim.Versions.ClaimFormat))
+	assert.Equal(t, claim.TestCaseResultFailed, redacted.Claim.Results["lifecycle-pod-owner-type"].State)
+
+	payload, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), "worker-0")
+	assert.Regexp(t, `"node-[0-9a-f]{12}": \{`, string(payload))
+
+	// Same profile and salt, same redacted claim, so they can be compared.
+	otherOutputFile := filepath.Join(dir, "redacted-again.json")
+	require.NoError(t, redactClaimFile("testdata/claim.json", otherOutputFile, "default", "salt"))
+	otherPayload, err := os.ReadFile(otherOutputFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(payload), string(otherPayload))
+}
+
+func TestRedactClaimFileErrors(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "redacted.json")
+
+	err := redactClaimFile("testdata/claim.json", outputFile, "not-found", "")
+	assert.ErrorContains(t, err, `no built-in redaction profile "not-found"`)
+
+	err = redactClaimFile("not_found.json", outputFile, "default", "")
+	assert.ErrorContains(t, err, "failed to parse claim file not_found.json")
+
+	assert.NoFileExists(t, outputFile)
+}
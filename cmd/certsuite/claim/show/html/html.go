@@ -0,0 +1,61 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package html
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/results"
+	"github.com/spf13/cobra"
+)
+
+var (
+	claimFilePathFlag string
+
+	showHTMLCommand = &cobra.Command{
+		Use:   "html",
+		Short: "Shows the results from a claim file as a standalone HTML report.",
+		Long: `Parses a claim.json file and prints a single-file HTML report of its results, with per suite summaries,
+filters by status, suite, category and tag, and the compliant and non compliant objects of each test case. The
+report embeds the claim, the catalog info of its test cases, and its styles and scripts, so it can be attached to
+tickets and viewed offline.
+`,
+		Example: `./certsuite claim show html --claim path/to/claim.json > report.html`,
+		RunE:    showHTML,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	showHTMLCommand.Flags().StringVarP(&claimFilePathFlag, "claim", "c", "",
+		"Required: Existing claim file path.",
+	)
+
+	err := showHTMLCommand.MarkFlagRequired("claim")
+	if err != nil {
+		log.Fatalf("Failed to mark claim file path as required parameter: %v", err)
+		return nil
+	}
+
+	return showHTMLCommand
+}
+
+func showHTML(_ *cobra.Command, _ []string) error {
+	claimScheme, err := claim.Parse(claimFilePathFlag)
+	if err != nil {
+		return fmt.Errorf("failed to parse claim file %s: %w", claimFilePathFlag, err)
+	}
+
+	err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
+	if err != nil {
+		return err
+	}
+
+	claimContent, err := os.ReadFile(claimFilePathFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read claim file %s: %w", claimFilePathFlag, err)
+	}
+
+	return results.WriteHTMLReport(os.Stdout, claimScheme.ToOfficialClaim(), claimContent)
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package sarif
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/spf13/cobra"
+)
+
+var (
+	claimFilePathFlag string
+
+	showSarifCommand = &cobra.Command{
+		Use:   "sarif",
+		Short: "Shows the failed test cases from a claim file in SARIF format.",
+		Long: `Parses a claim.json file and prints a SARIF 2.1.0 log with its failed test cases, so they can be
+consumed by code-scanning and security tools. Each failed test case is a rule, with its description, remediation,
+best practice reference and impact statement, and each of its non compliant objects is a result whose logical
+location is the object's namespace, pod and container.
+`,
+		Example: `./certsuite claim show sarif --claim path/to/claim.json > certsuite.sarif`,
+		RunE:    showSarif,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	showSarifCommand.Flags().StringVarP(&claimFilePathFlag, "claim", "c", "",
+		"Required: Existing claim file path.",
+	)
+
+	err := showSarifCommand.MarkFlagRequired("claim")
+	if err != nil {
+		log.Fatalf("Failed to mark claim file path as required parameter: %v", err)
+		return nil
+	}
+
+	return showSarifCommand
+}
+
+func showSarif(_ *cobra.Command, _ []string) error {
+	claimScheme, err := claim.Parse(claimFilePathFlag)
+	if err != nil {
+		return fmt.Errorf("failed to parse claim file %s: %w", claimFilePathFlag, err)
+	}
+
+	err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
+	if err != nil {
+		return err
+	}
+
+	sarifPayload, err := claimhelper.MarshalSarifLog(claimScheme.ToOfficialClaim())
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(sarifPayload))
+	return nil
+}
@@ -0,0 +1,31 @@
+package sarif
+
+import (
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSarifFromClaimFile(t *testing.T) {
+	claimScheme, err := claim.Parse("testdata/claim.json")
+	require.NoError(t, err)
+
+	officialClaim := claimScheme.ToOfficialClaim()
+	assert.Equal(t, "v5.6.0", officialClaim.Versions.CertSuite)
+	require.Len(t, officialClaim.Results, 3)
+
+	netAdmin := officialClaim.Results["access-control-net-admin-capability-check"]
+	assert.Equal(t, "access-control-net-admin-capability-check", netAdmin.TestID.Id)
+	assert.Equal(t, "access-control", netAdmin.TestID.Suite)
+	assert.Equal(t, claimhelper.TestStateFailed, netAdmin.State)
+	assert.NotEmpty(t, netAdmin.CheckDetails)
+	assert.NotEmpty(t, netAdmin.CatalogInfo.Remediation)
+
+	sarifLog := claimhelper.NewSarifLog(officialClaim)
+	require.Len(t, sarifLog.Runs, 1)
+	assert.Len(t, sarifLog.Runs[0].Tool.Driver.Rules, 2)
+	assert.Len(t, sarifLog.Runs[0].Results, 3)
+}
@@ -3,6 +3,9 @@ package show
 import (
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim/show/csv"
 	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim/show/failures"
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim/show/html"
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim/show/sarif"
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/claim/show/summary"
 	"github.com/spf13/cobra"
 )
 
@@ -16,5 +19,8 @@ var (
 func NewCommand() *cobra.Command {
 	showCommand.AddCommand(failures.NewCommand())
 	showCommand.AddCommand(csv.NewCommand())
+	showCommand.AddCommand(sarif.NewCommand())
+	showCommand.AddCommand(html.NewCommand())
+	showCommand.AddCommand(summary.NewCommand())
 	return showCommand
 }
@@ -0,0 +1,86 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package summary
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFormatMarkdown = "markdown"
+)
+
+var (
+	claimFilePathFlag string
+	outputFormatFlag  string
+	categoryFlag      string
+	maxSizeFlag       int
+
+	showSummaryCommand = &cobra.Command{
+		Use:   "summary",
+		Short: "Shows a summary of the results from a claim file, to be pasted in merge requests and chat.",
+		Long: `Parses a claim.json file and prints a summary of its results: the number of passed, failed, skipped and
+errored test cases per suite, the failed mandatory test cases of a category with their remediation, and the
+non-compliant objects of the failed test cases with most of them. The summary is truncated to --max-size bytes
+so it fits in a comment.
+`,
+		Example: `./certsuite claim show summary --claim path/to/claim.json --format markdown --category FarEdge`,
+		RunE:    showSummary,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	showSummaryCommand.Flags().StringVarP(&claimFilePathFlag, "claim", "c", "",
+		"Required: Existing claim file path.",
+	)
+
+	err := showSummaryCommand.MarkFlagRequired("claim")
+	if err != nil {
+		log.Fatalf("Failed to mark claim file path as required parameter: %v", err)
+		return nil
+	}
+
+	showSummaryCommand.Flags().StringVarP(&outputFormatFlag, "format", "f", outputFormatMarkdown,
+		"Output format of the summary. Only markdown is supported.",
+	)
+	showSummaryCommand.Flags().StringVar(&categoryFlag, "category", claimhelper.DefaultSummaryCategory,
+		"Category whose failed mandatory test cases are listed: Telco, NonTelco, FarEdge or Extended.",
+	)
+	showSummaryCommand.Flags().IntVar(&maxSizeFlag, "max-size", claimhelper.DefaultSummaryMaxSize,
+		"Maximum size of the summary in bytes. Zero means no limit.",
+	)
+
+	return showSummaryCommand
+}
+
+func showSummary(_ *cobra.Command, _ []string) error {
+	if outputFormatFlag != outputFormatMarkdown {
+		return fmt.Errorf("invalid output format %q, only %s is supported", outputFormatFlag, outputFormatMarkdown)
+	}
+
+	category, err := claimhelper.GetCategory(categoryFlag)
+	if err != nil {
+		return err
+	}
+
+	if maxSizeFlag < 0 {
+		return fmt.Errorf("invalid maximum size %d, it can't be negative", maxSizeFlag)
+	}
+
+	claimScheme, err := claim.Parse(claimFilePathFlag)
+	if err != nil {
+		return fmt.Errorf("failed to parse claim file %s: %w", claimFilePathFlag, err)
+	}
+
+	err = claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(claimhelper.NewMarkdownSummary(claimScheme.ToOfficialClaim(), category, maxSizeFlag))
+	return nil
+}
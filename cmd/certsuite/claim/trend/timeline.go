@@ -0,0 +1,187 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package trend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+)
+
+// Run is a claim file of the series, from a run of the test suite.
+type Run struct {
+	File      string `json:"file"`
+	StartTime string `json:"startTime"`
+	CertSuite string `json:"certSuite"`
+}
+
+// Point is the result of a test case in a run.
+type Point struct {
+	State string `json:"state"`
+	// Duration in seconds.
+	Duration            int `json:"duration"`
+	NonCompliantObjects int `json:"nonCompliantObjects"`
+}
+
+// TestTimeline holds the results of a test case in every run, nil if it's not in the run's claim file.
+type TestTimeline struct {
+	ID     string   `json:"id"`
+	Suite  string   `json:"suite"`
+	Points []*Point `json:"points"`
+	// Number of times the test case went from passing to failing, or the other way around, from a
+	// run where it ran to the next one where it ran.
+	Flips int  `json:"flips"`
+	Flaky bool `json:"flaky"`
+}
+
+// Trend holds the timelines of the test cases across the runs, from the oldest to the latest.
+type Trend struct {
+	Runs  []Run          `json:"runs"`
+	Tests []TestTimeline `json:"tests"`
+	// Minimum number of flips of the flaky test cases.
+	FlakyFlips int `json:"flakyFlips"`
+}
+
+type runClaim struct {
+	run       Run
+	startTime time.Time
+	results   claim.TestSuiteResults
+}
+
+// loadClaimFiles returns the claim files in the directory and its subdirectories, sorted by start
+// time. The json files that are not claim files are ignored, but claim files with an unsupported
+// claim format version are an error.
+func loadClaimFiles(dir string) ([]runClaim, error) {
+	runClaims := []runClaim{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		claimScheme, err := claim.Parse(path)
+		if err != nil || claimScheme.Claim.Versions.ClaimFormat == "" {
+			return nil
+		}
+		if err := claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat); err != nil {
+			return fmt.Errorf("claim file %s: %w", path, err)
+		}
+
+		startTime, err := time.Parse(claimhelper.DateTimeFormatDirective, strings.Split(claimScheme.Claim.Metadata.StartTime, " m=")[0])
+		if err != nil {
+			return fmt.Errorf("claim file %s has an invalid start time %q: %w", path, claimScheme.Claim.Metadata.StartTime, err)
+		}
+
+		runClaims = append(runClaims, runClaim{
+			run: Run{
+				File:      path,
+				StartTime: claimScheme.Claim.Metadata.StartTime,
+				CertSuite: claimScheme.Claim.Versions.CertSuite,
+			},
+			startTime: startTime,
+			results:   claimScheme.Claim.Results,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(runClaims, func(i, j int) bool { return runClaims[i].startTime.Before(runClaims[j].startTime) })
+	return runClaims, nil
+}
+
+// pointState returns the state of the point: passed after retry and passed with waivers are passed.
+func pointState(state string) string {
+	if strings.HasPrefix(state, claim.TestCaseResultPassed) {
+		return claim.TestCaseResultPassed
+	}
+
+	return state
+}
+
+// countNonCompliantObjects returns the number of non-compliant objects in the check details.
+func countNonCompliantObjects(checkDetails string) int {
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(checkDetails), &details); err != nil {
+		return 0
+	}
+
+	return len(details.NonCompliantObjectsOut)
+}
+
+// countFlips returns the number of times the points went from passed to failed (or errored), or the
+// other way around, not counting the runs where the test case did not run or was skipped.
+func countFlips(points []*Point) int {
+	flips := 0
+	previous := ""
+	for _, point := range points {
+		if point == nil || point.State == claim.TestCaseResultSkipped {
+			continue
+		}
+
+		state := pointState(point.State)
+		if state != claim.TestCaseResultPassed {
+			state = claim.TestCaseResultFailed
+		}
+		if previous != "" && state != previous {
+			flips++
+		}
+		previous = state
+	}
+
+	return flips
+}
+
+// newTrend returns the timeline of every test case in the claim files, flagging as flaky those
+// that flipped state at least flakyFlips times.
+func newTrend(runClaims []runClaim, flakyFlips int) *Trend {
+	trend := Trend{Runs: []Run{}, Tests: []TestTimeline{}, FlakyFlips: flakyFlips}
+
+	timelines := map[string]*TestTimeline{}
+	for i, runClaim := range runClaims {
+		trend.Runs = append(trend.Runs, runClaim.run)
+		for _, result := range runClaim.results {
+			timeline, found := timelines[result.TestID.ID]
+			if !found {
+				timeline = &TestTimeline{ID: result.TestID.ID, Suite: result.TestID.Suite, Points: make([]*Point, len(runClaims))}
+				timelines[result.TestID.ID] = timeline
+			}
+			timeline.Points[i] = &Point{
+				State:               result.State,
+				Duration:            result.Duration,
+				NonCompliantObjects: countNonCompliantObjects(result.CheckDetails),
+			}
+		}
+	}
+
+	for _, timeline := range timelines {
+		timeline.Flips = countFlips(timeline.Points)
+		timeline.Flaky = flakyFlips > 0 && timeline.Flips >= flakyFlips
+		trend.Tests = append(trend.Tests, *timeline)
+	}
+	sort.Slice(trend.Tests, func(i, j int) bool { return trend.Tests[i].ID < trend.Tests[j].ID })
+
+	return &trend
+}
+
+// FlakyTests returns the IDs of the flaky test cases.
+func (t *Trend) FlakyTests() []string {
+	ids := []string{}
+	for i := range t.Tests {
+		if t.Tests[i].Flaky {
+			ids = append(ids, t.Tests[i].ID)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,71 @@
+package trend
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadClaimFiles(t *testing.T) {
+	runClaims, err := loadClaimFiles("testdata/nightly")
+	require.NoError(t, err)
+
+	// Sorted by start time, summary.json is not a claim file.
+	require.Len(t, runClaims, 3)
+	assert.Equal(t, filepath.Join("testdata", "nightly", "2026-10-01", "claim.json"), runClaims[0].run.File)
+	assert.Equal(t, "2026-10-03 02:00:00 +0000 UTC", runClaims[2].run.StartTime)
+	assert.Equal(t, "v5.6.0", runClaims[2].run.CertSuite)
+
+	dir := t.TempDir()
+	payload, err := os.ReadFile("testdata/nightly/2026-10-01/claim.json")
+	require.NoError(t, err)
+	oldClaimFile := filepath.Join(dir, "claim.json")
+	require.NoError(t, os.WriteFile(oldClaimFile, []byte(strings.Replace(string(payload), `"v0.5.0"`, `"v0.1.0"`, 1)), 0o600))
+	_, err = loadClaimFiles(dir)
+	assert.EqualError(t, err, "claim file "+oldClaimFile+": claim format version v0.1.0 is not supported. Supported version is v0.5.0")
+}
+
+func TestCountFlips(t *testing.T) {
+	assert.Equal(t, 0, countFlips(nil))
+	assert.Equal(t, 0, countFlips([]*Point{{State: "failed"}, {State: "error"}, nil, {State: "failed"}}))
+	// Skipped results and runs without the test case don't count.
+	assert.Equal(t, 1, countFlips([]*Point{{State: "passed"}, {State: "skipped"}, nil, {State: "failed"}}))
+	assert.Equal(t, 3, countFlips([]*Point{{State: "passed"}, {State: "error"}, {State: "passed-with-waivers"}, {State: "failed"}}))
+}
+
+func TestNewTrend(t *testing.T) {
+	runClaims, err := loadClaimFiles("testdata/nightly")
+	require.NoError(t, err)
+
+	trend := newTrend(runClaims, 2)
+	require.Len(t, trend.Runs, 3)
+	require.Len(t, trend.Tests, 3)
+
+	sshDaemons := trend.Tests[0]
+	assert.Equal(t, "access-control-ssh-daemons", sshDaemons.ID)
+	assert.Equal(t, "access-control", sshDaemons.Suite)
+	assert.Equal(t, []*Point{
+		{State: "passed", Duration: 10},
+		{State: "failed", Duration: 12, NonCompliantObjects: 1},
+		{State: "passed", Duration: 11},
+	}, sshDaemons.Points)
+	assert.Equal(t, 2, sshDaemons.Flips)
+	assert.True(t, sshDaemons.Flaky)
+
+	podOwnerType := trend.Tests[1]
+	assert.Equal(t, "lifecycle-pod-owner-type", podOwnerType.ID)
+	assert.Equal(t, 1, podOwnerType.Flips)
+	assert.False(t, podOwnerType.Flaky)
+	assert.Equal(t, 3, podOwnerType.Points[0].NonCompliantObjects)
+
+	// Not in the last run.
+	crdStatus := trend.Tests[2]
+	assert.Nil(t, crdStatus.Points[2])
+
+	assert.Equal(t, []string{"access-control-ssh-daemons"}, trend.FlakyTests())
+	assert.Empty(t, newTrend(runClaims, 0).FlakyTests())
+}
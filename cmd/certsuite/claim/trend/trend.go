@@ -0,0 +1,291 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package trend
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFormatCSV  = "csv"
+	outputFormatJSON = "json"
+	outputFormatHTML = "html"
+
+	defaultFlakyFlips = 3
+
+	// Size of the charts of the HTML report, in pixels.
+	chartWidth  = 240
+	chartHeight = 32
+)
+
+// The trend template has the styles inline, and the charts are inline SVG, so the generated file is
+// standalone.
+//
+//go:embed html/trend.html
+var htmlTrendTemplateContent string
+
+var htmlTrendTemplate = template.Must(template.New("trend").Parse(htmlTrendTemplateContent))
+
+var (
+	outputFormatFlag   string
+	outputFilePathFlag string
+	flakyFlipsFlag     int
+
+	claimTrendCommand = &cobra.Command{
+		Use:   "trend <dir>",
+		Short: "Shows the trend of the test cases results across the claim files of a directory.",
+		Long: `Loads the claim files in a directory and its subdirectories, e.g. from nightly runs, and prints the timeline
+of every test case from the oldest run to the latest one: its state, duration and number of non-compliant objects
+in each run. The json files that are not claim files are ignored.
+
+Test cases that flipped between passing and failing (or erroring) at least --flaky-flips times, not counting the
+runs where they were skipped, are flagged as flaky.
+
+The timeline is printed in CSV, with a row per test case and run, in JSON, or as a standalone HTML page with a
+chart per test case.
+`,
+		Example: "claim trend nightly-results/ --format html -o trend.html",
+		Args:    cobra.ExactArgs(1),
+		RunE:    claimTrend,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	claimTrendCommand.Flags().StringVarP(&outputFormatFlag, "format", "f", outputFormatCSV,
+		"Output format of the trend: csv, json or html.",
+	)
+	claimTrendCommand.Flags().StringVarP(&outputFilePathFlag, "output", "o", "",
+		"Output file path. The trend is printed to stdout if not set.",
+	)
+	claimTrendCommand.Flags().IntVar(&flakyFlipsFlag, "flaky-flips", defaultFlakyFlips,
+		"Minimum number of flips between passing and failing of the test cases flagged as flaky. Zero disables it.",
+	)
+
+	return claimTrendCommand
+}
+
+func claimTrend(_ *cobra.Command, args []string) error {
+	if flakyFlipsFlag < 0 {
+		return fmt.Errorf("invalid number of flips %d, it can't be negative", flakyFlipsFlag)
+	}
+
+	writeTrend, err := getTrendWriter(outputFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	runClaims, err := loadClaimFiles(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load the claim files: %w", err)
+	}
+	if len(runClaims) == 0 {
+		return fmt.Errorf("no claim files found in %s", args[0])
+	}
+
+	trend := newTrend(runClaims, flakyFlipsFlag)
+
+	output := io.Writer(os.Stdout)
+	if outputFilePathFlag != "" {
+		outputFile, err := os.Create(outputFilePathFlag)
+		if err != nil {
+			return fmt.Errorf("failed to create output file %s: %w", outputFilePathFlag, err)
+		}
+		defer outputFile.Close()
+		output = outputFile
+	}
+
+	if err := writeTrend(output, trend); err != nil {
+		return err
+	}
+
+	if flakyTests := trend.FlakyTests(); len(flakyTests) > 0 {
+		log.Printf("Flaky test cases: %s", strings.Join(flakyTests, ", "))
+	}
+
+	return nil
+}
+
+func getTrendWriter(format string) (func(io.Writer, *Trend) error, error) {
+	switch format {
+	case outputFormatCSV:
+		return writeCSV, nil
+	case outputFormatJSON:
+		return writeJSON, nil
+	case outputFormatHTML:
+		return writeHTML, nil
+	}
+
+	return nil, fmt.Errorf("invalid output format %q, valid formats are %s, %s and %s", format, outputFormatCSV, outputFormatJSON, outputFormatHTML)
+}
+
+// writeCSV writes a row per test case and run it's in.
+func writeCSV(w io.Writer, trend *Trend) error {
+	writer := csv.NewWriter(w)
+	records := [][]string{{"test_id", "suite", "start_time", "claim_file", "state", "duration", "non_compliant_objects", "flips", "flaky"}}
+	for i := range trend.Tests {
+		test := &trend.Tests[i]
+		for j, point := range test.Points {
+			if point == nil {
+				continue
+			}
+			records = append(records, []string{
+				test.ID,
+				test.Suite,
+				trend.Runs[j].StartTime,
+				trend.Runs[j].File,
+				point.State,
+				strconv.Itoa(point.Duration),
+				strconv.Itoa(point.NonCompliantObjects),
+				strconv.Itoa(test.Flips),
+				strconv.FormatBool(test.Flaky),
+			})
+		}
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write the csv: %w", err)
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, trend *Trend) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(trend); err != nil {
+		return fmt.Errorf("failed to write the json: %w", err)
+	}
+
+	return nil
+}
+
+type htmlCell struct {
+	Class string
+	Title string
+}
+
+type htmlChart struct {
+	// Points of the SVG polyline.
+	Points string
+	Max    int
+}
+
+type htmlTest struct {
+	ID       string
+	Suite    string
+	Flips    int
+	Flaky    bool
+	Cells    []htmlCell
+	Objects  htmlChart
+	Duration htmlChart
+}
+
+type htmlTrend struct {
+	Runs        []Run
+	FirstRun    Run
+	LastRun     Run
+	Tests       []htmlTest
+	FlakyFlips  int
+	FlakyTests  int
+	ChartWidth  int
+	ChartHeight int
+}
+
+// newHTMLChart returns the chart of the values of the runs the test case is in.
+func newHTMLChart(points []*Point, value func(*Point) int) htmlChart {
+	chart := htmlChart{}
+	for _, point := range points {
+		if point != nil && value(point) > chart.Max {
+			chart.Max = value(point)
+		}
+	}
+
+	coordinates := []string{}
+	for i, point := range points {
+		if point == nil {
+			continue
+		}
+
+		x := chartWidth / 2
+		if len(points) > 1 {
+			x = i * chartWidth / (len(points) - 1)
+		}
+		y := chartHeight
+		if chart.Max > 0 {
+			y = chartHeight - value(point)*chartHeight/chart.Max
+		}
+		coordinates = append(coordinates, fmt.Sprintf("%d,%d", x, y))
+	}
+	chart.Points = strings.Join(coordinates, " ")
+
+	return chart
+}
+
+// htmlCellClass returns the class of the cell of the point: passed, failed, skipped, error or absent.
+func htmlCellClass(point *Point) string {
+	if point == nil {
+		return "absent"
+	}
+
+	switch state := pointState(point.State); state {
+	case claim.TestCaseResultPassed, claim.TestCaseResultFailed, claim.TestCaseResultSkipped:
+		return state
+	}
+
+	return claim.TestCaseResultError
+}
+
+func writeHTML(w io.Writer, trend *Trend) error {
+	page := htmlTrend{
+		Runs:        trend.Runs,
+		FirstRun:    trend.Runs[0],
+		LastRun:     trend.Runs[len(trend.Runs)-1],
+		Tests:       []htmlTest{},
+		FlakyFlips:  trend.FlakyFlips,
+		ChartWidth:  chartWidth,
+		ChartHeight: chartHeight,
+	}
+
+	for i := range trend.Tests {
+		test := &trend.Tests[i]
+		if test.Flaky {
+			page.FlakyTests++
+		}
+
+		cells := []htmlCell{}
+		for j, point := range test.Points {
+			title := trend.Runs[j].StartTime + ": not run"
+			if point != nil {
+				title = fmt.Sprintf("%s: %s, %ds, %d non-compliant object(s)", trend.Runs[j].StartTime, point.State, point.Duration, point.NonCompliantObjects)
+			}
+			cells = append(cells, htmlCell{Class: htmlCellClass(point), Title: title})
+		}
+
+		page.Tests = append(page.Tests, htmlTest{
+			ID:       test.ID,
+			Suite:    test.Suite,
+			Flips:    test.Flips,
+			Flaky:    test.Flaky,
+			Cells:    cells,
+			Objects:  newHTMLChart(test.Points, func(point *Point) int { return point.NonCompliantObjects }),
+			Duration: newHTMLChart(test.Points, func(point *Point) int { return point.Duration }),
+		})
+	}
+
+	if err := htmlTrendTemplate.Execute(w, page); err != nil {
+		return fmt.Errorf("failed to write the html: %w", err)
+	}
+
+	return nil
+}
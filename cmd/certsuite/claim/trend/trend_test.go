@@ -0,0 +1,71 @@
+package trend
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTrend(t *testing.T) *Trend {
+	t.Helper()
+
+	runClaims, err := loadClaimFiles("testdata/nightly")
+	require.NoError(t, err)
+
+	return newTrend(runClaims, 2)
+}
+
+func TestWriteCSV(t *testing.T) {
+	output := bytes.Buffer{}
+	require.NoError(t, writeCSV(&output, testTrend(t)))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	// A row per test case and run it's in.
+	require.Len(t, lines, 9)
+	assert.Equal(t, "test_id,suite,start_time,claim_file,state,duration,non_compliant_objects,flips,flaky", lines[0])
+	assert.Equal(t, "access-control-ssh-daemons,access-control,2026-10-02 02:00:00 +0000 UTC,testdata/nightly/2026-10-02/claim.json,failed,12,1,2,true", lines[2])
+	assert.Equal(t, "observability-crd-status,observability,2026-10-02 02:00:00 +0000 UTC,testdata/nightly/2026-10-02/claim.json,passed,1,0,0,false", lines[8])
+}
+
+func TestWriteJSON(t *testing.T) {
+	output := bytes.Buffer{}
+	require.NoError(t, writeJSON(&output, testTrend(t)))
+
+	trend := Trend{}
+	require.NoError(t, json.Unmarshal(output.Bytes(), &trend))
+	assert.Equal(t, testTrend(t), &trend)
+}
+
+func TestWriteHTML(t *testing.T) {
+	output := bytes.Buffer{}
+	require.NoError(t, writeHTML(&output, testTrend(t)))
+
+	html := output.String()
+	assert.Contains(t, html, "3 run(s), from 2026-10-01 02:00:00 &#43;0000 UTC to 2026-10-03 02:00:00 &#43;0000 UTC.")
+	assert.Contains(t, html, `access-control-ssh-daemons <span class="badge">flaky</span>`)
+	assert.Contains(t, html, `<span class="cell failed" title="2026-10-02 02:00:00 &#43;0000 UTC: failed, 12s, 1 non-compliant object(s)"></span>`)
+	assert.Contains(t, html, `<span class="cell absent" title="2026-10-03 02:00:00 &#43;0000 UTC: not run"></span>`)
+	// Non-compliant objects of lifecycle-pod-owner-type: 3, 1 and 0.
+	assert.Contains(t, html, `<polyline points="0,0 120,22 240,32"/>`)
+}
+
+func TestNewHTMLChart(t *testing.T) {
+	duration := func(point *Point) int { return point.Duration }
+
+	assert.Equal(t, htmlChart{Points: "0,32 240,0", Max: 10}, newHTMLChart([]*Point{{Duration: 0}, nil, {Duration: 10}}, duration))
+	assert.Equal(t, htmlChart{Points: "120,32"}, newHTMLChart([]*Point{{Duration: 0}}, duration))
+}
+
+func TestGetTrendWriter(t *testing.T) {
+	for _, format := range []string{"csv", "json", "html"} {
+		_, err := getTrendWriter(format)
+		assert.NoError(t, err)
+	}
+
+	_, err := getTrendWriter("xml")
+	assert.EqualError(t, err, `invalid output format "xml", valid formats are csv, json and html`)
+}
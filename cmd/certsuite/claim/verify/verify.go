@@ -0,0 +1,87 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package verify
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/attestation"
+	"github.com/spf13/cobra"
+)
+
+const longHelp = `Verifies an attestation created by "certsuite run --sign-key": checks that it was signed with the private
+key of the public key, and that the files it lists were not modified since, using their sha256 digests.
+
+The certsuite run creates two attestations in the output directory:
+ - certsuite-attestation.intoto.json, with the claim file and the reports. It's added to the results tar.gz file,
+   so the extracted files can be verified.
+ - <results tar.gz file>.intoto.json, with the results tar.gz file.
+
+The files are looked up in the --dir directory, which defaults to the attestation file's one. The verification
+needs no network access.
+
+The attestations are DSSE envelopes with an in-toto statement, which also has the certsuite version and the
+sha256 digest of the certsuite binary that signed them.
+`
+
+var (
+	publicKeyFilePathFlag string
+	filesDirFlag          string
+
+	claimVerifyCommand = &cobra.Command{
+		Use:     "verify <attestation file>",
+		Short:   "Verify the signature of the claim and results artifacts.",
+		Long:    longHelp,
+		Example: "claim verify results/certsuite-attestation.intoto.json --key certsuite.pub",
+		Args:    cobra.ExactArgs(1),
+		RunE:    claimVerify,
+	}
+)
+
+func NewCommand() *cobra.Command {
+	claimVerifyCommand.Flags().StringVarP(&publicKeyFilePathFlag, "key", "k", "",
+		"Required: PEM public key file of the private key the attestation was signed with.",
+	)
+	claimVerifyCommand.Flags().StringVar(&filesDirFlag, "dir", "",
+		"Directory of the signed files. Defaults to the attestation file's directory.",
+	)
+
+	err := claimVerifyCommand.MarkFlagRequired("key")
+	if err != nil {
+		log.Fatalf("Failed to mark public key file path as required parameter: %v", err)
+		return nil
+	}
+
+	return claimVerifyCommand
+}
+
+func claimVerify(_ *cobra.Command, args []string) error {
+	return verifyAttestation(os.Stdout, args[0], publicKeyFilePathFlag, filesDirFlag)
+}
+
+// verifyAttestation verifies the attestation file and prints its signed files and certsuite build.
+func verifyAttestation(w io.Writer, attestationFilePath, publicKeyFilePath, filesDir string) error {
+	if filesDir == "" {
+		filesDir = filepath.Dir(attestationFilePath)
+	}
+
+	statement, err := attestation.VerifyFile(attestationFilePath, publicKeyFilePath, filesDir)
+	if err != nil {
+		return fmt.Errorf("verification of %s failed: %w", attestationFilePath, err)
+	}
+
+	fmt.Fprintf(w, "Attestation %s verified.\n", attestationFilePath)
+	fmt.Fprintf(w, "Signed by certsuite %s (commit %s), binary sha256 digest %s, at %s.\n",
+		statement.Predicate.CertSuiteVersion, statement.Predicate.CertSuiteGitCommit,
+		statement.Predicate.BinaryDigest["sha256"], statement.Predicate.Timestamp)
+	fmt.Fprintf(w, "Claim format version: %s\n", statement.Predicate.ClaimFormat)
+	fmt.Fprintf(w, "Files:\n")
+	for _, subject := range statement.Subject {
+		fmt.Fprintf(w, "  %s  sha256:%s\n", subject.Name, subject.Digest["sha256"])
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package verify
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/attestation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAttestation(t *testing.T) {
+	publicKey, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+
+	keyDir := t.TempDir()
+	publicKeyFile := filepath.Join(keyDir, "key.pub")
+	require.NoError(t, os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	resultsDir := t.TempDir()
+	claimFile := filepath.Join(resultsDir, "claim.json")
+	require.NoError(t, os.WriteFile(claimFile, []byte(`{"claim":{}}`), 0o600))
+	attestationFile := filepath.Join(resultsDir, "certsuite-attestation"+attestation.FileSuffix)
+	predicate := attestation.Predicate{CertSuiteVersion: "v5.6.0", CertSuiteGitCommit: "abc123", ClaimFormat: "v0.5.0"}
+	require.NoError(t, attestation.SignFilesToFile(key, attestationFile, []string{claimFile}, &predicate))
+
+	output := bytes.Buffer{}
+	require.NoError(t, verifyAttestation(&output, attestationFile, publicKeyFile, ""))
+	assert.Contains(t, output.String(), "Attestation "+attestationFile+" verified.")
+	assert.Contains(t, output.String(), "Signed by certsuite v5.6.0 (commit abc123)")
+	assert.Contains(t, output.String(), "  claim.json  sha256:")
+
+	// The signed files are looked up in --dir, e.g. where the tar.gz file was extracted.
+	extractedDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(extractedDir, "claim.json"), []byte(`{"claim":{"results":{}}}`), 0o600))
+	err = verifyAttestation(&output, attestationFile, publicKeyFile, extractedDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file claim.json was modified")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/Masterminds/semver/v3"
 	officialClaimScheme "github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
@@ -14,9 +15,12 @@ const (
 )
 
 const (
-	TestCaseResultPassed  = "passed"
-	TestCaseResultSkipped = "skipped"
-	TestCaseResultFailed  = "failed"
+	TestCaseResultPassed            = "passed"
+	TestCaseResultPassedAfterRetry  = "passed-after-retry"
+	TestCaseResultPassedWithWaivers = "passed-with-waivers"
+	TestCaseResultSkipped           = "skipped"
+	TestCaseResultFailed            = "failed"
+	TestCaseResultError             = "error"
 )
 
 type TestCaseRawResult struct {
@@ -80,6 +84,8 @@ type Schema struct {
 	Claim struct {
 		Configurations `json:"configurations"`
 
+		Metadata officialClaimScheme.Metadata `json:"metadata"`
+
 		Nodes Nodes `json:"nodes"`
 
 		Results  TestSuiteResults             `json:"results"`
@@ -120,3 +126,62 @@ func Parse(filePath string) (*Schema, error) {
 
 	return &claimFile, nil
 }
+
+// FailedTestCaseIDs returns the sorted IDs of the test cases that failed or errored.
+func (s *Schema) FailedTestCaseIDs() []string {
+	ids := []string{}
+	for id, result := range s.Claim.Results {
+		if result.State == TestCaseResultFailed || result.State == TestCaseResultError {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+// ToOfficialClaim returns the claim with the metadata, versions and results of the parsed claim file, in the
+// format of the official claim schema.
+func (s *Schema) ToOfficialClaim() *officialClaimScheme.Claim {
+	versions := s.Claim.Versions
+	metadata := s.Claim.Metadata
+	officialClaim := &officialClaimScheme.Claim{
+		Metadata: &metadata,
+		Versions: &versions,
+		Results:  map[string]officialClaimScheme.Result{},
+	}
+
+	for testID := range s.Claim.Results {
+		result := s.Claim.Results[testID]
+		officialClaim.Results[testID] = officialClaimScheme.Result{
+			TestID: &officialClaimScheme.Identifier{
+				Id:    result.TestID.ID,
+				Suite: result.TestID.Suite,
+				Tags:  result.TestID.Tags,
+			},
+			State:              result.State,
+			SkipReason:         result.SkipReason,
+			CheckDetails:       result.CheckDetails,
+			CapturedTestOutput: result.CapturedTestOutput,
+			Duration:           result.Duration,
+			StartTime:          result.StartTime,
+			EndTime:            result.EndTime,
+			FailureLineContent: result.FailureLineContent,
+			FailureLocation:    result.FailureLocation,
+			CatalogInfo: &officialClaimScheme.CatalogInfo{
+				BestPracticeReference: result.CatalogInfo.BestPracticeReference,
+				Description:           result.CatalogInfo.Description,
+				ExceptionProcess:      result.CatalogInfo.ExceptionProcess,
+				Remediation:           result.CatalogInfo.Remediation,
+			},
+			CategoryClassification: &officialClaimScheme.CategoryClassification{
+				Extended: result.CategoryClassification["Extended"],
+				FarEdge:  result.CategoryClassification["FarEdge"],
+				NonTelco: result.CategoryClassification["NonTelco"],
+				Telco:    result.CategoryClassification["Telco"],
+			},
+		}
+	}
+
+	return officialClaim
+}
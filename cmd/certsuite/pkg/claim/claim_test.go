@@ -120,3 +120,46 @@ func TestIsClaimFormatVersionSupported(t *testing.T) {
 		}
 	}
 }
+
+func TestFailedTestCaseIDs(t *testing.T) {
+	schema := Schema{}
+	schema.Claim.Results = TestSuiteResults{
+		"lifecycle-pod-scheduling":       {State: TestCaseResultFailed},
+		"access-control-ssh-daemons":     {State: TestCaseResultPassed},
+		"networking-icmpv4-connectivity": {State: TestCaseResultError},
+		"observability-crd-status":       {State: TestCaseResultSkipped},
+	}
+
+	assert.Equal(t, []string{"lifecycle-pod-scheduling", "networking-icmpv4-connectivity"}, schema.FailedTestCaseIDs())
+
+	schema.Claim.Results = TestSuiteResults{}
+	assert.Empty(t, schema.FailedTestCaseIDs())
+}
+
+func TestToOfficialClaim(t *testing.T) {
+	schema := Schema{}
+	schema.Claim.Versions.CertSuite = "v5.6.0"
+	result := TestCaseResult{State: TestCaseResultFailed, CheckDetails: "{}", Duration: 3}
+	result.TestID.ID = "access-control-net-admin-capability-check"
+	result.TestID.Suite = "access-control"
+	result.TestID.Tags = "telco"
+	result.CatalogInfo.Remediation = "Remove NET_ADMIN"
+	result.CategoryClassification = map[string]string{"Telco": "Mandatory", "NonTelco": "Optional"}
+	schema.Claim.Results = TestSuiteResults{result.TestID.ID: result}
+
+	officialClaim := schema.ToOfficialClaim()
+	assert.Equal(t, "v5.6.0", officialClaim.Versions.CertSuite)
+	require.Len(t, officialClaim.Results, 1)
+
+	officialResult := officialClaim.Results["access-control-net-admin-capability-check"]
+	assert.Equal(t, "access-control-net-admin-capability-check", officialResult.TestID.Id)
+	assert.Equal(t, "access-control", officialResult.TestID.Suite)
+	assert.Equal(t, "telco", officialResult.TestID.Tags)
+	assert.Equal(t, TestCaseResultFailed, officialResult.State)
+	assert.Equal(t, "{}", officialResult.CheckDetails)
+	assert.Equal(t, 3, officialResult.Duration)
+	assert.Equal(t, "Remove NET_ADMIN", officialResult.CatalogInfo.Remediation)
+	assert.Equal(t, "Mandatory", officialResult.CategoryClassification.Telco)
+	assert.Equal(t, "Optional", officialResult.CategoryClassification.NonTelco)
+	assert.Empty(t, officialResult.CategoryClassification.FarEdge)
+}
@@ -2,23 +2,32 @@
 package run
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
 	"text/template"
 	"time"
 
 	flag "github.com/spf13/pflag"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/cmd/certsuite/pkg/claim"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/attestation"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/certsuite"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
 	"github.com/redhat-best-practices-for-k8s/certsuite/webserver"
 	"github.com/spf13/cobra"
 )
 
-const timeoutFlagDefaultvalue = 24 * time.Hour
+const (
+	timeoutFlagDefaultvalue = 24 * time.Hour
+	// Exit code when mandatory test cases of the --fail-on category failed.
+	mandatoryTestCasesFailedExitCode = 2
+)
 
 type flagGroup struct {
 	Name    string
@@ -48,12 +57,41 @@ func NewCommand() *cobra.Command {
 	behaviorFlags := flag.NewFlagSet("behavior", flag.ContinueOnError)
 	behaviorFlags.Bool("allow-non-running", false, "Include non-Running pods during autodiscovery phase")
 	behaviorFlags.Bool("server-mode", false, "Run the certsuite in web server mode")
+	behaviorFlags.String("manifests", "", "Run the static checks against the rendered manifests in this file or directory instead of a live cluster")
+	behaviorFlags.String("from-snapshot", "", "Replay the discovery snapshot archive in this file instead of querying a live cluster")
+	behaviorFlags.Int("groups-concurrency", 1, "Maximum number of test suites running at the same time. Intrusive checks always run alone")
+	behaviorFlags.Duration("check-timeout", 0, "Time allowed for each test case to complete, unless it sets its own, e.g. --check-timeout 10m. Zero means no limit")
+	behaviorFlags.String("order", "", "Comma separated list of test suites in the order they must run, \"*\" stands for the rest of them, e.g. \"*,lifecycle\"")
+	behaviorFlags.String("resume", "", "Resume the interrupted run whose output directory is this one, running only the checks that did not complete")
+	behaviorFlags.String("rerun-failed", "", "Run only the test cases that failed or errored in this claim file")
+
+	serverFlags := flag.NewFlagSet("server", flag.ContinueOnError)
+	serverFlags.String("server-tls-cert", "", "With --server-mode, serve over TLS with the PEM certificate in this file")
+	serverFlags.String("server-tls-key", "", "With --server-mode, serve over TLS with the PEM private key in this file")
+	serverFlags.String("server-tokens-file", "", "With --server-mode, authenticate the users with the bearer tokens in this file, a token and its user per line")
+	serverFlags.String("server-htpasswd-file", "", "With --server-mode, authenticate the users with the basic auth credentials in this htpasswd file (bcrypt or SHA-1 hashes)")
+	serverFlags.String("server-oidc-issuer-url", "", "With --server-mode, authenticate the users with the ID tokens of this OIDC issuer, sent as bearer tokens")
+	serverFlags.String("server-oidc-client-id", "", "Client ID the OIDC ID tokens must be issued for, required with --server-oidc-issuer-url")
+	serverFlags.String("server-oidc-username-claim", webserver.DefaultOIDCUsernameClaim, "OIDC ID token claim with the user name")
+	serverFlags.Int("server-history-max-runs", 0, "With --server-mode, number of latest runs kept in the run history. Zero keeps all of them")
+	serverFlags.Duration("server-history-max-age", 0, "With --server-mode, time the runs are kept in the run history, e.g. --server-history-max-age 720h. Zero keeps them forever")
 
 	outputFlags := flag.NewFlagSet("output", flag.ContinueOnError)
 	outputFlags.Bool("omit-artifacts-zip-file", false, "Prevents the creation of a zip file with the result artifacts")
 	outputFlags.Bool("include-web-files", false, "Save web files in the configured output folder")
 	outputFlags.Bool("create-xml-junit-file", false, "Create a JUnit file with the test results")
+	outputFlags.Bool("junit-per-object", false, "With --create-xml-junit-file, create a test suite per certsuite suite and a test case per reported object of each test case")
+	outputFlags.Bool("create-sarif-file", false, "Create a SARIF file with the non-compliant objects of the failed test cases")
+	outputFlags.Bool("create-markdown-summary", false, "Create a markdown summary of the results, to be pasted in merge requests and chat")
+	outputFlags.String("summary-category", claimhelper.DefaultSummaryCategory, "Category whose failed mandatory test cases are listed in the markdown summary: Telco, NonTelco, FarEdge or Extended")
+	outputFlags.Int("summary-max-size", claimhelper.DefaultSummaryMaxSize, "Maximum size of the markdown summary in bytes. Zero means no limit")
+	outputFlags.String("fail-on", "", "Exit with code 2 if mandatory test cases of this category failed: Telco, NonTelco, FarEdge or Extended")
 	outputFlags.Bool("sanitize-claim", false, "Sanitize the claim.json file before sending it to the collector")
+	outputFlags.Bool("create-snapshot", false, "Save a discovery snapshot archive, with the exec'ed commands outputs, that can be replayed with --from-snapshot")
+	outputFlags.String("redact-profile", "", "Redact the claim.json file with this built-in redaction profile, e.g. \"default\", or redaction profile file, see \"certsuite claim redact\"")
+	outputFlags.String("redact-salt", "", "Salt of the pseudonyms hashes of --redact-profile, instead of the one of the profile. A random one is used if neither is set")
+	outputFlags.String("sign-key", "", "Sign the claim file and the results artifacts with the PEM private key in this file, see \"certsuite claim verify\"")
+	outputFlags.Bool("merge-results", false, "With --rerun-failed, save a copy of the previous claim file updated with the new results")
 
 	probeFlags := flag.NewFlagSet("probe", flag.ContinueOnError)
 	probeFlags.String("certsuite-probe-image", "quay.io/redhat-best-practices-for-k8s/certsuite-probe:v0.0.42", "Certsuite probe image")
@@ -80,6 +118,7 @@ func NewCommand() *cobra.Command {
 	groups = []flagGroup{
 		{Name: "Common", FlagSet: commonFlags},
 		{Name: "Test Behavior", FlagSet: behaviorFlags},
+		{Name: "Web Server", FlagSet: serverFlags},
 		{Name: "Output & Artifact", FlagSet: outputFlags},
 		{Name: "Probe DaemonSet", FlagSet: probeFlags},
 		{Name: "Preflight", FlagSet: preflightFlags},
@@ -144,6 +183,26 @@ func (f *flagReader) getBool(dest *bool, name string) {
 	}
 }
 
+func (f *flagReader) getInt(dest *int, name string) {
+	if f.err != nil {
+		return
+	}
+	*dest, f.err = f.cmd.Flags().GetInt(name)
+	if f.err != nil {
+		f.err = fmt.Errorf("flag %q: %w", name, f.err)
+	}
+}
+
+func (f *flagReader) getDuration(dest *time.Duration, name string) {
+	if f.err != nil {
+		return
+	}
+	*dest, f.err = f.cmd.Flags().GetDuration(name)
+	if f.err != nil {
+		f.err = fmt.Errorf("flag %q: %w", name, f.err)
+	}
+}
+
 func initTestParamsFromFlags(cmd *cobra.Command) error {
 	testParams := configuration.GetTestParameters()
 	f := &flagReader{cmd: cmd}
@@ -162,6 +221,24 @@ func initTestParamsFromFlags(cmd *cobra.Command) error {
 	f.getBool(&testParams.IncludeWebFilesInOutputFolder, "include-web-files")
 	f.getBool(&testParams.EnableDataCollection, "enable-data-collection")
 	f.getBool(&testParams.EnableXMLCreation, "create-xml-junit-file")
+	f.getBool(&testParams.JUnitPerObject, "junit-per-object")
+	f.getBool(&testParams.EnableSARIFCreation, "create-sarif-file")
+	f.getBool(&testParams.EnableMarkdownSummary, "create-markdown-summary")
+	f.getString(&testParams.SummaryCategory, "summary-category")
+	f.getInt(&testParams.SummaryMaxSize, "summary-max-size")
+	f.getString(&testParams.FailOn, "fail-on")
+	f.getString(&testParams.RedactProfile, "redact-profile")
+	f.getString(&testParams.RedactSalt, "redact-salt")
+	f.getString(&testParams.SignKeyFile, "sign-key")
+	f.getString(&testParams.ServerTLSCertFile, "server-tls-cert")
+	f.getString(&testParams.ServerTLSKeyFile, "server-tls-key")
+	f.getString(&testParams.ServerTokensFile, "server-tokens-file")
+	f.getString(&testParams.ServerHtpasswdFile, "server-htpasswd-file")
+	f.getString(&testParams.ServerOIDCIssuerURL, "server-oidc-issuer-url")
+	f.getString(&testParams.ServerOIDCClientID, "server-oidc-client-id")
+	f.getString(&testParams.ServerOIDCUsernameClaim, "server-oidc-username-claim")
+	f.getInt(&testParams.ServerHistoryMaxRuns, "server-history-max-runs")
+	f.getDuration(&testParams.ServerHistoryMaxAge, "server-history-max-age")
 	f.getString(&testParams.CertSuiteProbeImage, "certsuite-probe-image")
 	f.getString(&testParams.DaemonsetCPUReq, "daemonset-cpu-req")
 	f.getString(&testParams.DaemonsetCPULim, "daemonset-cpu-lim")
@@ -176,6 +253,12 @@ func initTestParamsFromFlags(cmd *cobra.Command) error {
 	f.getString(&testParams.ConnectAPIProxyPort, "connect-api-proxy-port")
 	f.getBool(&testParams.CleanupProbe, "cleanup-probe")
 	f.getBool(&testParams.RequireProbe, "require-probe")
+	f.getString(&testParams.ManifestsDir, "manifests")
+	f.getString(&testParams.FromSnapshot, "from-snapshot")
+	f.getBool(&testParams.CreateSnapshot, "create-snapshot")
+	f.getInt(&testParams.GroupsConcurrency, "groups-concurrency")
+	f.getString(&testParams.GroupsOrder, "order")
+	f.getDuration(&testParams.CheckTimeout, "check-timeout")
 
 	var timeoutStr string
 	f.getString(&timeoutStr, "timeout")
@@ -183,6 +266,89 @@ func initTestParamsFromFlags(cmd *cobra.Command) error {
 		return f.err
 	}
 
+	var resumeDir string
+	f.getString(&resumeDir, "resume")
+	if f.err != nil {
+		return f.err
+	}
+
+	f.getString(&testParams.RerunFailedClaim, "rerun-failed")
+	f.getBool(&testParams.MergeResults, "merge-results")
+	if f.err != nil {
+		return f.err
+	}
+
+	if testParams.MergeResults && testParams.RerunFailedClaim == "" {
+		return errors.New("flag --merge-results requires --rerun-failed")
+	}
+
+	if testParams.JUnitPerObject && !testParams.EnableXMLCreation {
+		return errors.New("flag --junit-per-object requires --create-xml-junit-file")
+	}
+
+	summaryCategory, err := claimhelper.GetCategory(testParams.SummaryCategory)
+	if err != nil {
+		return fmt.Errorf("invalid --summary-category: %w", err)
+	}
+	testParams.SummaryCategory = summaryCategory
+
+	if testParams.SummaryMaxSize < 0 {
+		return errors.New("flag --summary-max-size can't be negative")
+	}
+
+	if testParams.FailOn != "" {
+		failOn, err := claimhelper.GetCategory(testParams.FailOn)
+		if err != nil {
+			return fmt.Errorf("invalid --fail-on: %w", err)
+		}
+		testParams.FailOn = failOn
+	}
+
+	if testParams.RedactProfile != "" {
+		if _, err := claimhelper.LoadRedactionProfile(testParams.RedactProfile); err != nil {
+			return fmt.Errorf("invalid --redact-profile: %w", err)
+		}
+	}
+
+	if testParams.SignKeyFile != "" {
+		if _, err := attestation.LoadPrivateKey(testParams.SignKeyFile); err != nil {
+			return fmt.Errorf("invalid --sign-key: %w", err)
+		}
+	}
+
+	if err := checkServerFlags(cmd, testParams); err != nil {
+		return err
+	}
+
+	if testParams.RerunFailedClaim != "" {
+		if cmd.Flags().Changed("label-filter") {
+			return errors.New("flags --label-filter and --rerun-failed can't be used together")
+		}
+
+		labelsFilter, err := rerunFailedLabelsFilter(testParams.RerunFailedClaim)
+		if err != nil {
+			return err
+		}
+		testParams.LabelsFilter = labelsFilter
+	}
+
+	if resumeDir != "" {
+		if cmd.Flags().Changed("output-dir") && filepath.Clean(testParams.OutputDir) != filepath.Clean(resumeDir) {
+			return errors.New("flags --output-dir and --resume must point to the same directory")
+		}
+		testParams.OutputDir = resumeDir
+		testParams.Resume = true
+	}
+
+	if testParams.ManifestsDir != "" && testParams.FromSnapshot != "" {
+		return errors.New("flags --manifests and --from-snapshot can't be used together")
+	}
+
+	// Intrusive checks modify the workloads under test, which need a live cluster.
+	if testParams.ManifestsDir != "" || testParams.FromSnapshot != "" {
+		testParams.Intrusive = false
+	}
+
 	// Check if the output directory exists and, if not, create it
 	if _, err := os.Stat(testParams.OutputDir); os.IsNotExist(err) {
 		var dirPerm fs.FileMode = 0o755 // default permissions for a directory
@@ -205,6 +371,54 @@ func initTestParamsFromFlags(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// checkServerFlags checks the web server flags are only set in server mode, and complete.
+func checkServerFlags(cmd *cobra.Command, testParams *configuration.TestParameters) error {
+	if !testParams.ServerMode {
+		for _, name := range []string{"server-tls-cert", "server-tls-key", "server-tokens-file", "server-htpasswd-file", "server-oidc-issuer-url", "server-oidc-client-id", "server-oidc-username-claim",
+			"server-history-max-runs", "server-history-max-age"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("flag --%s requires --server-mode", name)
+			}
+		}
+		return nil
+	}
+
+	if (testParams.ServerTLSCertFile == "") != (testParams.ServerTLSKeyFile == "") {
+		return errors.New("flags --server-tls-cert and --server-tls-key must be used together")
+	}
+
+	if (testParams.ServerOIDCIssuerURL == "") != (testParams.ServerOIDCClientID == "") {
+		return errors.New("flags --server-oidc-issuer-url and --server-oidc-client-id must be used together")
+	}
+
+	if testParams.ServerHistoryMaxRuns < 0 || testParams.ServerHistoryMaxAge < 0 {
+		return errors.New("flags --server-history-max-runs and --server-history-max-age can't be negative")
+	}
+
+	return nil
+}
+
+// rerunFailedLabelsFilter returns the label filter matching the test cases that failed or errored
+// in the claim file.
+func rerunFailedLabelsFilter(claimFileName string) (string, error) {
+	claimScheme, err := claim.Parse(claimFileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse claim file %s: %w", claimFileName, err)
+	}
+
+	if err := claim.CheckVersion(claimScheme.Claim.Versions.ClaimFormat); err != nil {
+		return "", err
+	}
+
+	failedIDs := claimScheme.FailedTestCaseIDs()
+	if len(failedIDs) == 0 {
+		return "", fmt.Errorf("no failed test cases found in claim file %s", claimFileName)
+	}
+
+	return strings.Join(failedIDs, ","), nil
+}
+
 func runTestSuite(cmd *cobra.Command, _ []string) error {
 	err := initTestParamsFromFlags(cmd)
 	if err != nil {
@@ -222,6 +436,11 @@ func runTestSuite(cmd *cobra.Command, _ []string) error {
 		defer certsuite.Shutdown()
 		log.Info("Running Certification Suite in stand-alone mode")
 		err := certsuite.Run(testParams.LabelsFilter, testParams.OutputDir)
+		if errors.Is(err, certsuite.ErrMandatoryTestCasesFailed) {
+			log.Error("Certification Suite verdict: %v", err)
+			certsuite.Shutdown()
+			os.Exit(mandatoryTestCasesFailedExitCode) //nolint:gocritic // exitAfterDefer
+		}
 		if err != nil {
 			log.Fatal("Failed to run Certification Suite: %v", err) //nolint:gocritic // exitAfterDefer
 		}
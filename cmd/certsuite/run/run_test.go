@@ -2,8 +2,11 @@
 package run
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -119,3 +122,63 @@ func TestFlagReaderGetStringOnBoolFlag(t *testing.T) {
 	require.Error(t, f.err)
 	assert.Contains(t, f.err.Error(), `"verbose"`)
 }
+
+func TestRerunFailedLabelsFilter(t *testing.T) {
+	t.Parallel()
+
+	writeClaim := func(results string) string {
+		claimFile := filepath.Join(t.TempDir(), "claim.json")
+		content := `{"claim": {"results": ` + results + `, "versions": {"claimFormat": "v0.5.0", "certSuite": ""}}}`
+		require.NoError(t, os.WriteFile(claimFile, []byte(content), 0o600))
+		return claimFile
+	}
+
+	claimFile := writeClaim(`{
+		"observability-crd-status": {"state": "passed"},
+		"lifecycle-pod-scheduling": {"state": "failed"},
+		"access-control-ssh-daemons": {"state": "error"}
+	}`)
+	labelsFilter, err := rerunFailedLabelsFilter(claimFile)
+	require.NoError(t, err)
+	assert.Equal(t, "access-control-ssh-daemons,lifecycle-pod-scheduling", labelsFilter)
+
+	_, err = rerunFailedLabelsFilter(writeClaim(`{"observability-crd-status": {"state": "passed"}}`))
+	assert.ErrorContains(t, err, "no failed test cases found")
+
+	_, err = rerunFailedLabelsFilter(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to parse claim file")
+}
+
+func TestCheckServerFlags(t *testing.T) {
+	t.Parallel()
+
+	newServerCommand := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		for _, name := range []string{"server-tls-cert", "server-tls-key", "server-tokens-file", "server-htpasswd-file", "server-oidc-issuer-url", "server-oidc-client-id", "server-oidc-username-claim"} {
+			cmd.Flags().String(name, "", "")
+		}
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	err := checkServerFlags(newServerCommand("--server-tokens-file", "tokens"), &configuration.TestParameters{ServerTokensFile: "tokens"})
+	assert.EqualError(t, err, "flag --server-tokens-file requires --server-mode")
+
+	err = checkServerFlags(newServerCommand(), &configuration.TestParameters{ServerMode: true, ServerTLSCertFile: "tls.crt"})
+	assert.EqualError(t, err, "flags --server-tls-cert and --server-tls-key must be used together")
+
+	err = checkServerFlags(newServerCommand(), &configuration.TestParameters{ServerMode: true, ServerOIDCIssuerURL: "https://issuer.example.com"})
+	assert.EqualError(t, err, "flags --server-oidc-issuer-url and --server-oidc-client-id must be used together")
+
+	err = checkServerFlags(newServerCommand(), &configuration.TestParameters{ServerMode: true, ServerHistoryMaxRuns: -1})
+	assert.EqualError(t, err, "flags --server-history-max-runs and --server-history-max-age can't be negative")
+
+	err = checkServerFlags(newServerCommand(), &configuration.TestParameters{
+		ServerMode:          true,
+		ServerTLSCertFile:   "tls.crt",
+		ServerTLSKeyFile:    "tls.key",
+		ServerOIDCIssuerURL: "https://issuer.example.com",
+		ServerOIDCClientID:  "certsuite",
+	})
+	assert.NoError(t, err)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/term"
@@ -49,10 +51,27 @@ const (
 var CliCheckLogSniffer = &cliCheckLogSniffer{}
 
 var (
-	checkLoggerChan chan string
-	stopChan        chan bool
+	// Guards the channels of the running check line, as the logs of the checks may be written from
+	// other goroutines.
+	runningCheckLineLock sync.Mutex
+	checkLoggerChan      chan string
+	stopChan             chan bool
+
+	// When disabled, the running checks are printed once, without the live updated line.
+	runningCheckLineDisabled atomic.Bool
 )
 
+// DisableRunningCheckLine stops updating the running check line with the elapsed time and
+// last log line, which only works when a single check runs at a time.
+func DisableRunningCheckLine() {
+	runningCheckLineDisabled.Store(true)
+}
+
+// EnableRunningCheckLine restores the live updated running check line.
+func EnableRunningCheckLine() {
+	runningCheckLineDisabled.Store(false)
+}
+
 func PrintBanner() {
 	fmt.Print(banner)
 }
@@ -63,7 +82,7 @@ func isTTY() bool {
 	return term.IsTerminal(int(os.Stdin.Fd()))
 }
 
-func updateRunningCheckLine(checkName string, stopChan <-chan bool) {
+func updateRunningCheckLine(checkName string, checkLoggerChan <-chan string, stopChan <-chan bool) {
 	startTime := time.Now()
 
 	// Local string var to save the last received log line from the running check.
@@ -131,6 +150,9 @@ func (c *cliCheckLogSniffer) Write(p []byte) (n int, err error) {
 	if !isTTY() {
 		return len(p), nil
 	}
+	runningCheckLineLock.Lock()
+	defer runningCheckLineLock.Unlock()
+
 	// Send to channel, or ignore it in case the channel is not ready or is closed.
 	// This way we avoid blocking the whole program.
 	select {
@@ -141,22 +163,31 @@ func (c *cliCheckLogSniffer) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
 
-func PrintResultsTable(results map[string][]int) {
+// GroupResults holds the number of passed, failed and skipped checks of a group, in that order.
+type GroupResults struct {
+	Name    string
+	Results []int
+}
+
+func PrintResultsTable(results []GroupResults) {
 	fmt.Printf("\n")
 	fmt.Println("-----------------------------------------------------------")
 	fmt.Printf("| %-27s %-9s %-9s %s |\n", "SUITE", "PASSED", "FAILED", "SKIPPED")
 	fmt.Println("-----------------------------------------------------------")
-	for groupName, groupResults := range results {
-		fmt.Printf("| %-25s %8d %9d %10d |\n", groupName,
-			groupResults[0],
-			groupResults[1],
-			groupResults[2])
+	for _, group := range results {
+		fmt.Printf("| %-25s %8d %9d %10d |\n", group.Name,
+			group.Results[0],
+			group.Results[1],
+			group.Results[2])
 		fmt.Println("-----------------------------------------------------------")
 	}
 	fmt.Printf("\n")
 }
 
 func stopCheckLineGoroutine() {
+	runningCheckLineLock.Lock()
+	defer runningCheckLineLock.Unlock()
+
 	if stopChan == nil {
 		// This may happen for checks that were skipped if no compliant nor non-compliant objects found.
 		return
@@ -176,6 +207,17 @@ func PrintCheckSkipped(checkName, reason string) {
 }
 
 func PrintCheckRunning(checkName string) {
+	if runningCheckLineDisabled.Load() {
+		fmt.Print("[ " + CheckResultTagRunning + " ] " + checkName + "\n")
+		return
+	}
+
+	// The line of a previous attempt of the check may still be running.
+	stopCheckLineGoroutine()
+
+	runningCheckLineLock.Lock()
+	defer runningCheckLineLock.Unlock()
+
 	stopChan = make(chan bool)
 	checkLoggerChan = make(chan string)
 
@@ -186,7 +228,7 @@ func PrintCheckRunning(checkName string) {
 
 	fmt.Print(line)
 
-	go updateRunningCheckLine(checkName, stopChan)
+	go updateRunningCheckLine(checkName, checkLoggerChan, stopChan)
 }
 
 func PrintCheckPassed(checkName string) {
@@ -25,6 +25,7 @@ import (
 	clientconfigv1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	olmClient "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
 	olmFakeClient "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
+	olmpkgFakeClient "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/client/clientset/versioned/fake"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 
 	apiextv1c "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -32,15 +33,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	dynamicFakeClient "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
+	kubernetesscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/scale"
+	scaleFakeClient "k8s.io/client-go/scale/fake"
 
 	cncfV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	cncfNetworkAttachmentv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
 	cncfNetworkAttachmentFake "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/fake"
 	apiserverscheme "github.com/openshift/client-go/apiserver/clientset/versioned"
+	apiserverFakeClient "github.com/openshift/client-go/apiserver/clientset/versioned/fake"
+	ocpConfigFakeClient "github.com/openshift/client-go/config/clientset/versioned/fake"
 	ocpMachine "github.com/openshift/client-go/machineconfiguration/clientset/versioned"
+	ocpMachineFakeClient "github.com/openshift/client-go/machineconfiguration/clientset/versioned/fake"
+	olmv1Alpha "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	olmpkgclient "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/client/clientset/versioned/typed/operators/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	scalingv1 "k8s.io/api/autoscaling/v1"
@@ -93,6 +101,9 @@ type ClientsHolder struct {
 	MachineCfg           ocpMachine.Interface
 	KubeConfig           []byte
 	ready                bool
+	offline              bool
+	execRecorder         *execRecorder
+	execReplayer         *execRecorder
 	GroupResources       []*metav1.APIResourceList
 	ApiserverClient      apiserverscheme.Interface
 }
@@ -195,6 +206,61 @@ func SetTestClientGroupResources(groupResources []*metav1.APIResourceList) {
 func ClearTestClientsHolder() {
 	clientsHolder.K8sClient = nil
 	clientsHolder.ready = false
+	clientsHolder.offline = false
+	clientsHolder.execRecorder = nil
+	clientsHolder.execReplayer = nil
+}
+
+// SetOfflineClientsHolder sets up the singleton with in-memory clients preloaded with the given objects,
+// so code that queries the k8s API can work without a cluster (e.g. when running against rendered
+// manifests). Objects of unsupported types are ignored. Exec'ing commands in containers is not possible.
+func SetOfflineClientsHolder(objects []runtime.Object) *ClientsHolder {
+	var k8sClientObjects []runtime.Object
+	var k8sExtClientObjects []runtime.Object
+	var k8sPlumbingObjects []runtime.Object
+	var olmClientObjects []runtime.Object
+
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *apiextv1c.CustomResourceDefinition:
+			k8sExtClientObjects = append(k8sExtClientObjects, obj)
+		case *cncfV1.NetworkAttachmentDefinition:
+			k8sPlumbingObjects = append(k8sPlumbingObjects, obj)
+		case *olmv1Alpha.ClusterServiceVersion, *olmv1Alpha.Subscription, *olmv1Alpha.InstallPlan, *olmv1Alpha.CatalogSource:
+			olmClientObjects = append(olmClientObjects, obj)
+		default:
+			if gvks, _, err := kubernetesscheme.Scheme.ObjectKinds(obj); err == nil && len(gvks) > 0 {
+				k8sClientObjects = append(k8sClientObjects, obj)
+			}
+		}
+	}
+
+	k8sClient := k8sFakeClient.NewClientset(k8sClientObjects...)
+	clientsHolder.RestConfig = &rest.Config{}
+	clientsHolder.K8sClient = k8sClient
+	clientsHolder.K8sNetworkingClient = k8sClient.NetworkingV1()
+	clientsHolder.DiscoveryClient = k8sClient.Discovery()
+	clientsHolder.DynamicClient = dynamicFakeClient.NewSimpleDynamicClient(runtime.NewScheme())
+	clientsHolder.ScalingClient = &scaleFakeClient.FakeScaleClient{}
+	clientsHolder.APIExtClient = apiextv1fake.NewClientset(k8sExtClientObjects...)
+	clientsHolder.CNCFNetworkingClient = cncfNetworkAttachmentFake.NewSimpleClientset(k8sPlumbingObjects...)
+	clientsHolder.OlmClient = olmFakeClient.NewSimpleClientset(olmClientObjects...)
+	clientsHolder.OlmPkgClient = olmpkgFakeClient.NewSimpleClientset().PackagesV1()
+	clientsHolder.OcpClient = ocpConfigFakeClient.NewSimpleClientset().ConfigV1()
+	clientsHolder.MachineCfg = ocpMachineFakeClient.NewSimpleClientset()
+	clientsHolder.ApiserverClient = apiserverFakeClient.NewSimpleClientset()
+	clientsHolder.KubeConfig = nil
+	clientsHolder.GroupResources = nil
+	clientsHolder.offline = true
+	clientsHolder.execReplayer = nil
+	clientsHolder.ready = true
+
+	return &clientsHolder
+}
+
+// IsOffline returns true when the clients are not backed by a real cluster.
+func (clientsholder *ClientsHolder) IsOffline() bool {
+	return clientsholder.offline
 }
 
 // GetClientsHolder returns the singleton ClientsHolder object.
@@ -210,12 +276,22 @@ func GetClientsHolder(filenames ...string) *ClientsHolder {
 }
 
 func GetNewClientsHolder(kubeconfigFile string) *ClientsHolder {
-	_, err := newClientsHolder(kubeconfigFile)
+	holder, err := LoadClientsHolder(kubeconfigFile)
 	if err != nil {
 		log.Fatal("Failed to create k8s clients holder, err: %v", err)
 	}
 
-	return &clientsHolder
+	return holder
+}
+
+// LoadClientsHolder creates the clients of the singleton ClientsHolder object again with the
+// kubeconfig file, returning the error instead of exiting, e.g. for the web server jobs.
+func LoadClientsHolder(kubeconfigFile string) (*ClientsHolder, error) {
+	if _, err := newClientsHolder(kubeconfigFile); err != nil {
+		return nil, err
+	}
+
+	return &clientsHolder, nil
 }
 
 func createByteArrayKubeConfig(kubeConfig *clientcmdapi.Config) ([]byte, error) {
@@ -29,7 +29,7 @@ import (
 )
 
 type Command interface {
-	ExecCommandContainer(Context, string) (string, string, error)
+	ExecCommandContainer(context.Context, Context, string) (string, string, error)
 }
 
 const (
@@ -37,9 +37,27 @@ const (
 	ExecCommandTimeout = 30 * time.Second
 )
 
-// ExecCommand runs command in the pod and returns buffer output.
+// ExecCommand runs command in the pod and returns buffer output. The command is stopped when
+// goCtx is cancelled or after ExecCommandTimeout, whatever happens first.
 func (clientsholder *ClientsHolder) ExecCommandContainer(
-	ctx Context, command string) (stdout, stderr string, err error) {
+	goCtx context.Context, ctx Context, command string) (stdout, stderr string, err error) {
+	if clientsholder.execReplayer != nil {
+		return clientsholder.execReplayer.replay(ctx, command)
+	}
+
+	if clientsholder.offline {
+		return "", "", newExecError(command, ctx.GetNamespace(), ctx.GetPodName(), ErrOffline)
+	}
+
+	stdout, stderr, err = clientsholder.execCommandContainer(goCtx, ctx, command)
+	if clientsholder.execRecorder != nil {
+		clientsholder.execRecorder.record(ctx, command, stdout, stderr, err)
+	}
+	return stdout, stderr, err
+}
+
+func (clientsholder *ClientsHolder) execCommandContainer(
+	parentCtx context.Context, ctx Context, command string) (stdout, stderr string, err error) {
 	commandStr := []string{"sh", "-c", command}
 	var buffOut bytes.Buffer
 	var buffErr bytes.Buffer
@@ -65,7 +83,7 @@ func (clientsholder *ClientsHolder) ExecCommandContainer(
 		return stdout, stderr, newExecError(command, ctx.GetNamespace(), ctx.GetPodName(), err)
 	}
 	// enforce an execution timeout for the remote command
-	goCtx, cancel := context.WithTimeout(context.TODO(), ExecCommandTimeout)
+	goCtx, cancel := context.WithTimeout(parentCtx, ExecCommandTimeout)
 	defer cancel()
 
 	err = exec.StreamWithContext(goCtx, remotecommand.StreamOptions{
@@ -16,7 +16,10 @@
 
 package clientsholder
 
-import "sync"
+import (
+	"context"
+	"sync"
+)
 
 // Ensure MockCommand implements Command interface
 var _ Command = (*MockCommand)(nil)
@@ -47,7 +50,7 @@ type execCall struct {
 }
 
 // ExecCommandContainer implements the Command interface.
-func (m *MockCommand) ExecCommandContainer(ctx Context, command string) (stdout, stderr string, err error) {
+func (m *MockCommand) ExecCommandContainer(_ context.Context, ctx Context, command string) (stdout, stderr string, err error) {
 	m.mu.Lock()
 	m.calls = append(m.calls, execCall{Context: ctx, Command: command})
 	m.mu.Unlock()
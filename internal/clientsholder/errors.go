@@ -23,6 +23,9 @@ import (
 	k8sexec "k8s.io/client-go/util/exec"
 )
 
+// ErrOffline is returned by operations that need a live cluster when the clients are offline.
+var ErrOffline = errors.New("no cluster available (offline mode)")
+
 type ExecError struct {
 	Command   string
 	Namespace string
@@ -0,0 +1,145 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package clientsholder
+
+import (
+	"errors"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sexec "k8s.io/client-go/util/exec"
+)
+
+// ErrExecNotRecorded is returned when replaying a command whose output was not recorded.
+var ErrExecNotRecorded = errors.New("command output not found in the recorded execs")
+
+// ExecRecord holds the output of a command exec'ed in a container, so it can be replayed later.
+type ExecRecord struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Command   string `json:"command"`
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr"`
+	// Error is the original error message, or empty if the command succeeded.
+	Error string `json:"error,omitempty"`
+	// ExitCode is the command's exit code when it was available in the error, -1 otherwise.
+	ExitCode int `json:"exitCode"`
+}
+
+type execRecordKey struct {
+	namespace, pod, container, command string
+}
+
+// execRecorder keeps the records of the exec'ed commands. When replaying, the records of the
+// same command in the same container are returned in the same order they were recorded. The
+// last one is returned again once all of them have been replayed.
+type execRecorder struct {
+	mutex    sync.Mutex
+	records  []ExecRecord
+	replays  map[execRecordKey][]ExecRecord
+	replayed map[execRecordKey]int
+}
+
+func newExecRecordKey(ctx Context, command string) execRecordKey {
+	return execRecordKey{
+		namespace: ctx.GetNamespace(),
+		pod:       ctx.GetPodName(),
+		container: ctx.GetContainerName(),
+		command:   command,
+	}
+}
+
+func (r *execRecorder) record(ctx Context, command, stdout, stderr string, err error) {
+	record := ExecRecord{
+		Namespace: ctx.GetNamespace(),
+		Pod:       ctx.GetPodName(),
+		Container: ctx.GetContainerName(),
+		Command:   command,
+		Stdout:    stdout,
+		Stderr:    stderr,
+		ExitCode:  -1,
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+		var execErr *ExecError
+		if errors.As(err, &execErr) {
+			record.ExitCode = execErr.ExitCode
+			record.Error = execErr.Err.Error()
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records = append(r.records, record)
+}
+
+func (r *execRecorder) replay(ctx Context, command string) (stdout, stderr string, err error) {
+	key := newExecRecordKey(ctx, command)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	records := r.replays[key]
+	if len(records) == 0 {
+		return "", "", newExecError(command, ctx.GetNamespace(), ctx.GetPodName(), ErrExecNotRecorded)
+	}
+
+	idx := r.replayed[key]
+	if idx < len(records)-1 {
+		r.replayed[key]++
+	}
+
+	record := records[idx]
+	if record.Error == "" {
+		return record.Stdout, record.Stderr, nil
+	}
+
+	var recordedErr error = errors.New(record.Error)
+	if record.ExitCode >= 0 {
+		recordedErr = k8sexec.CodeExitError{Err: recordedErr, Code: record.ExitCode}
+	}
+	return record.Stdout, record.Stderr, newExecError(command, ctx.GetNamespace(), ctx.GetPodName(), recordedErr)
+}
+
+// EnableExecRecording starts recording the output of every command exec'ed in the containers.
+func (clientsholder *ClientsHolder) EnableExecRecording() {
+	clientsholder.execRecorder = &execRecorder{}
+}
+
+// GetExecRecords returns the commands recorded since EnableExecRecording was called.
+func (clientsholder *ClientsHolder) GetExecRecords() []ExecRecord {
+	if clientsholder.execRecorder == nil {
+		return nil
+	}
+
+	clientsholder.execRecorder.mutex.Lock()
+	defer clientsholder.execRecorder.mutex.Unlock()
+
+	records := make([]ExecRecord, len(clientsholder.execRecorder.records))
+	copy(records, clientsholder.execRecorder.records)
+	return records
+}
+
+// SetReplayClientsHolder sets up the offline clientsholder (see SetOfflineClientsHolder) with the
+// given objects, but exec'ed commands are answered from the recorded outputs instead of failing.
+func SetReplayClientsHolder(objects []runtime.Object, records []ExecRecord) *ClientsHolder {
+	holder := SetOfflineClientsHolder(objects)
+
+	recorder := &execRecorder{
+		replays:  map[execRecordKey][]ExecRecord{},
+		replayed: map[execRecordKey]int{},
+	}
+	for i := range records {
+		key := execRecordKey{
+			namespace: records[i].Namespace,
+			pod:       records[i].Pod,
+			container: records[i].Container,
+			command:   records[i].Command,
+		}
+		recorder.replays[key] = append(recorder.replays[key], records[i])
+	}
+	holder.execReplayer = recorder
+
+	return holder
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package clientsholder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sexec "k8s.io/client-go/util/exec"
+)
+
+func TestExecRecordAndReplay(t *testing.T) {
+	defer ClearTestClientsHolder()
+
+	ctx := NewContext("ns1", "pod1", "container1")
+	recorder := &execRecorder{}
+	recorder.record(ctx, "echo hello", "hello\n", "", nil)
+	recorder.record(ctx, "echo hello", "hello again\n", "", nil)
+	recorder.record(ctx, "false", "", "oops", newExecError("false", "ns1", "pod1", k8sexec.CodeExitError{Err: errors.New("command terminated"), Code: 1}))
+
+	records := recorder.records
+	require.Len(t, records, 3)
+	assert.Equal(t, -1, records[0].ExitCode)
+	assert.Empty(t, records[0].Error)
+	assert.Equal(t, 1, records[2].ExitCode)
+	assert.Equal(t, "command terminated", records[2].Error)
+
+	oc := SetReplayClientsHolder(nil, records)
+
+	// Same command outputs are replayed in order, the last one is repeated.
+	for _, expected := range []string{"hello\n", "hello again\n", "hello again\n"} {
+		stdout, stderr, err := oc.ExecCommandContainer(context.Background(), ctx, "echo hello")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, stdout)
+		assert.Empty(t, stderr)
+	}
+
+	stdout, stderr, err := oc.ExecCommandContainer(context.Background(), ctx, "false")
+	assert.Empty(t, stdout)
+	assert.Equal(t, "oops", stderr)
+	var execErr *ExecError
+	require.ErrorAs(t, err, &execErr)
+	assert.True(t, execErr.HasExitCode(1))
+
+	// Same command in another container was not recorded.
+	_, _, err = oc.ExecCommandContainer(context.Background(), NewContext("ns1", "pod1", "container2"), "echo hello")
+	assert.ErrorIs(t, err, ErrExecNotRecorded)
+}
+
+func TestGetExecRecords(t *testing.T) {
+	defer ClearTestClientsHolder()
+
+	oc := SetOfflineClientsHolder(nil)
+	assert.Nil(t, oc.GetExecRecords())
+
+	oc.EnableExecRecording()
+	oc.execRecorder.record(NewContext("ns1", "pod1", "container1"), "ls", "file\n", "", nil)
+
+	records := oc.GetExecRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, ExecRecord{Namespace: "ns1", Pod: "pod1", Container: "container1", Command: "ls", Stdout: "file\n", ExitCode: -1}, records[0])
+}
@@ -17,6 +17,7 @@
 package crclient
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -58,7 +59,7 @@ func GetNodeProbePodContext(node string, env *provider.TestEnvironment) (clients
 	return clientsholder.NewContext(probePod.Namespace, probePod.Name, probePod.Spec.Containers[0].Name), nil
 }
 
-func GetPidFromContainer(cut *provider.Container, ctx clientsholder.Context) (int, error) {
+func GetPidFromContainer(goCtx context.Context, cut *provider.Container, ctx clientsholder.Context) (int, error) {
 	var pidCmd string
 
 	switch cut.Runtime {
@@ -74,7 +75,7 @@ func GetPidFromContainer(cut *provider.Container, ctx clientsholder.Context) (in
 	}
 
 	ch := clientsholder.GetClientsHolder()
-	outStr, errStr, err := ch.ExecCommandContainer(ctx, pidCmd)
+	outStr, errStr, err := ch.ExecCommandContainer(goCtx, ctx, pidCmd)
 	if err != nil {
 		return 0, fmt.Errorf("cannot execute command: \" %s \"  on %s err:%w", pidCmd, cut, err)
 	}
@@ -86,21 +87,21 @@ func GetPidFromContainer(cut *provider.Container, ctx clientsholder.Context) (in
 }
 
 // To get the pid namespace of the container
-func GetContainerPidNamespace(testContainer *provider.Container, env *provider.TestEnvironment) (string, error) {
+func GetContainerPidNamespace(goCtx context.Context, testContainer *provider.Container, env *provider.TestEnvironment) (string, error) {
 	// Get the container pid
 	ocpContext, err := GetNodeProbePodContext(testContainer.NodeName, env)
 	if err != nil {
 		return "", fmt.Errorf("failed to get probe pod's context for container %s: %w", testContainer, err)
 	}
 
-	pid, err := GetPidFromContainer(testContainer, ocpContext)
+	pid, err := GetPidFromContainer(goCtx, testContainer, ocpContext)
 	if err != nil {
 		return "", fmt.Errorf("unable to get container process id due to: %w", err)
 	}
 	log.Debug("Obtained process id for %s is %d", testContainer, pid)
 
 	command := fmt.Sprintf("lsns -p %d -t pid -n", pid)
-	stdout, stderr, err := clientsholder.GetClientsHolder().ExecCommandContainer(ocpContext, command)
+	stdout, stderr, err := clientsholder.GetClientsHolder().ExecCommandContainer(goCtx, ocpContext, command)
 	if err != nil || stderr != "" {
 		return "", fmt.Errorf("unable to run nsenter due to: %w", err)
 	}
@@ -108,17 +109,18 @@ func GetContainerPidNamespace(testContainer *provider.Container, env *provider.T
 	return strings.Fields(stdout)[0], nil
 }
 
-func GetContainerProcesses(container *provider.Container, env *provider.TestEnvironment) ([]*Process, error) {
-	pidNs, err := GetContainerPidNamespace(container, env)
+func GetContainerProcesses(goCtx context.Context, container *provider.Container, env *provider.TestEnvironment) ([]*Process, error) {
+	pidNs, err := GetContainerPidNamespace(goCtx, container, env)
 	if err != nil {
 		return nil, fmt.Errorf("could not get the containers' pid namespace, err: %w", err)
 	}
 
-	return GetPidsFromPidNamespace(pidNs, container)
+	return GetPidsFromPidNamespace(goCtx, pidNs, container)
 }
 
-// ExecCommandContainerNSEnter executes a command in the specified container namespace using nsenter
-func ExecCommandContainerNSEnter(command string,
+// ExecCommandContainerNSEnter executes a command in the specified container namespace using nsenter.
+// It gives up as soon as goCtx is cancelled.
+func ExecCommandContainerNSEnter(goCtx context.Context, command string,
 	aContainer *provider.Container) (outStr, errStr string, err error) {
 	env := provider.GetTestEnvironment()
 	ctx, err := GetNodeProbePodContext(aContainer.NodeName, &env)
@@ -129,7 +131,7 @@ func ExecCommandContainerNSEnter(command string,
 	ch := clientsholder.GetClientsHolder()
 
 	// Get the container PID to build the nsenter command
-	containerPid, err := GetPidFromContainer(aContainer, ctx)
+	containerPid, err := GetPidFromContainer(goCtx, aContainer, ctx)
 	if err != nil {
 		return "", "", fmt.Errorf("cannot get PID from: %s, err: %w", aContainer, err)
 	}
@@ -139,8 +141,8 @@ func ExecCommandContainerNSEnter(command string,
 
 	// Run the nsenter command on the probe pod with retry logic
 	for attempt := 1; attempt <= RetryAttempts; attempt++ {
-		outStr, errStr, err = ch.ExecCommandContainer(ctx, nsenterCommand)
-		if err == nil {
+		outStr, errStr, err = ch.ExecCommandContainer(goCtx, ctx, nsenterCommand)
+		if err == nil || goCtx.Err() != nil {
 			break
 		}
 		if attempt < RetryAttempts {
@@ -154,7 +156,7 @@ func ExecCommandContainerNSEnter(command string,
 	return outStr, errStr, err
 }
 
-func GetPidsFromPidNamespace(pidNamespace string, container *provider.Container) (p []*Process, err error) {
+func GetPidsFromPidNamespace(goCtx context.Context, pidNamespace string, container *provider.Container) (p []*Process, err error) {
 	const command = "trap \"\" SIGURG ; ps -e -o pidns,pid,ppid,args"
 	env := provider.GetTestEnvironment()
 	ctx, err := GetNodeProbePodContext(container.NodeName, &env)
@@ -162,7 +164,7 @@ func GetPidsFromPidNamespace(pidNamespace string, container *provider.Container)
 		return nil, fmt.Errorf("failed to get probe pod's context for container %s: %w", container, err)
 	}
 
-	stdout, stderr, err := clientsholder.GetClientsHolder().ExecCommandContainer(ctx, command)
+	stdout, stderr, err := clientsholder.GetClientsHolder().ExecCommandContainer(goCtx, ctx, command)
 	if err != nil || stderr != "" {
 		return nil, fmt.Errorf("command %q failed to run in probe pod=%s (node=%s): %w", command, ctx.GetPodName(), container.NodeName, err)
 	}
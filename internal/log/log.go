@@ -43,7 +43,17 @@ func CreateGlobalLogFile(outputDir, logLevel string) error {
 		return fmt.Errorf("could not delete old log file, err: %w", err)
 	}
 
-	logFile, err := os.OpenFile(logFilePath, os.O_RDWR|os.O_CREATE, LogFilePermissions)
+	return openGlobalLogFile(logFilePath, logLevel, os.O_RDWR|os.O_CREATE)
+}
+
+// AppendGlobalLogFile is like CreateGlobalLogFile, but keeps the logs already in the log file, as
+// when resuming a run.
+func AppendGlobalLogFile(outputDir, logLevel string) error {
+	return openGlobalLogFile(outputDir+"/"+LogFileName, logLevel, os.O_WRONLY|os.O_CREATE|os.O_APPEND)
+}
+
+func openGlobalLogFile(logFilePath, logLevel string, flags int) error {
+	logFile, err := os.OpenFile(logFilePath, flags, LogFilePermissions)
 	if err != nil {
 		return fmt.Errorf("could not open a new log file, err: %w", err)
 	}
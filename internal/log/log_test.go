@@ -2,6 +2,9 @@ package log
 
 import (
 	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -82,3 +85,27 @@ func TestParseLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendGlobalLogFile(t *testing.T) {
+	outputDir := t.TempDir()
+	logFilePath := filepath.Join(outputDir, LogFileName)
+	require.NoError(t, os.WriteFile(logFilePath, []byte("previous run\n"), LogFilePermissions))
+
+	// The logs of the previous run are kept.
+	require.NoError(t, AppendGlobalLogFile(outputDir, LevelInfo))
+	Info("resumed run")
+	require.NoError(t, CloseGlobalLogFile())
+
+	content, err := os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "previous run\n"))
+	assert.Contains(t, string(content), "resumed run")
+
+	// Unlike when creating it.
+	require.NoError(t, CreateGlobalLogFile(outputDir, LevelInfo))
+	require.NoError(t, CloseGlobalLogFile())
+
+	content, err = os.ReadFile(logFilePath)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
@@ -23,6 +24,23 @@ func generateZipFileName() string {
 	return time.Now().Format(tarGzFileNamePrefixLayout) + "-" + tarGzFileNameSuffix
 }
 
+// GetResultsArtifactsFile returns the path of the latest results tar.gz file in the output dir, or an
+// empty string if there's none.
+func GetResultsArtifactsFile(outputDir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(outputDir, "*-"+tarGzFileNameSuffix))
+	if err != nil {
+		return "", fmt.Errorf("failed to look for the results tar.gz file in %s: %w", outputDir, err)
+	}
+
+	if len(files) == 0 {
+		return "", nil
+	}
+
+	// The files names start with their creation time.
+	sort.Strings(files)
+	return files[len(files)-1], nil
+}
+
 // Helper function to get the tar file header from a file.
 func getFileTarHeader(file string) (*tar.Header, error) {
 	info, err := os.Stat(file)
@@ -1 +1,24 @@
 package results
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetResultsArtifactsFile(t *testing.T) {
+	dir := t.TempDir()
+	file, err := GetResultsArtifactsFile(dir)
+	require.NoError(t, err)
+	assert.Empty(t, file)
+
+	for _, name := range []string{"20261016-020000-" + tarGzFileNameSuffix, "20261017-020000-" + tarGzFileNameSuffix, "discovery-snapshot.tar.gz"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+	file, err = GetResultsArtifactsFile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "20261017-020000-"+tarGzFileNameSuffix), file)
+}
@@ -0,0 +1,305 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package results
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+)
+
+const (
+	HTMLReportFileName = "report.html"
+
+	reportStatePassed  = "passed"
+	reportStateFailed  = "failed"
+	reportStateSkipped = "skipped"
+	reportStateError   = "error"
+)
+
+// The report template has the styles and scripts inline, so the generated file is standalone.
+//
+//go:embed html/report.html
+var htmlReportTemplateContent string
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(htmlReportTemplateContent))
+
+type reportSummary struct {
+	Total   int
+	Passed  int
+	Failed  int
+	Skipped int
+	Error   int
+}
+
+// add counts a test case result. Passed after retry and passed with waivers count as passed.
+func (s *reportSummary) add(state string) {
+	s.Total++
+	switch reportState(state) {
+	case reportStatePassed:
+		s.Passed++
+	case reportStateFailed:
+		s.Failed++
+	case reportStateSkipped:
+		s.Skipped++
+	default:
+		s.Error++
+	}
+}
+
+type reportSuite struct {
+	Name    string
+	Summary reportSummary
+}
+
+type reportObject struct {
+	Type   string
+	Fields []reportField
+}
+
+type reportField struct {
+	Key   string
+	Value string
+}
+
+type reportTestCase struct {
+	ID    string
+	Suite string
+	// Result of the test case as in the claim, e.g. passed-after-retry.
+	Result string
+	// Result of the test case used for the filters and summaries: passed, failed, skipped or error.
+	State                 string
+	Description           string
+	Remediation           string
+	BestPracticeReference string
+	ExceptionProcess      string
+	Impact                string
+	Tags                  []string
+	// Category classifications as Category:Classification pairs, e.g. Telco:Mandatory.
+	Categories          []string
+	SkipReason          string
+	Duration            int
+	CompliantObjects    []reportObject
+	NonCompliantObjects []reportObject
+	WaivedObjects       []reportObject
+}
+
+type htmlReport struct {
+	Versions   claim.Versions
+	StartTime  string
+	EndTime    string
+	Summary    reportSummary
+	Suites     []reportSuite
+	TestCases  []reportTestCase
+	Tags       []string
+	Categories []string
+	// Claim file contents, so they can be downloaded from the report.
+	ClaimJSON template.JS
+}
+
+// reportState returns the state used to filter and summarize a test case result.
+func reportState(result string) string {
+	switch {
+	case strings.HasPrefix(result, reportStatePassed):
+		return reportStatePassed
+	case result == reportStateFailed, result == reportStateSkipped:
+		return result
+	default:
+		return reportStateError
+	}
+}
+
+func newReportObjects(objects []*testhelper.ReportObject) []reportObject {
+	reportObjects := []reportObject{}
+	for _, obj := range objects {
+		if obj == nil {
+			continue
+		}
+
+		reportObj := reportObject{Type: obj.ObjectType}
+		for i := range obj.ObjectFieldsKeys {
+			if i < len(obj.ObjectFieldsValues) {
+				reportObj.Fields = append(reportObj.Fields, reportField{Key: obj.ObjectFieldsKeys[i], Value: obj.ObjectFieldsValues[i]})
+			}
+		}
+		reportObjects = append(reportObjects, reportObj)
+	}
+
+	return reportObjects
+}
+
+func newReportTestCase(testID string, result *claim.Result, catalog map[string]claim.TestCaseDescription) reportTestCase {
+	testCase := reportTestCase{
+		ID:         testID,
+		Result:     result.State,
+		State:      reportState(result.State),
+		Impact:     identifiers.ImpactMap[testID],
+		SkipReason: result.SkipReason,
+		Duration:   result.Duration,
+	}
+
+	if result.TestID != nil {
+		testCase.Suite = result.TestID.Suite
+		for _, tag := range strings.Split(result.TestID.Tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				testCase.Tags = append(testCase.Tags, tag)
+			}
+		}
+	}
+
+	catalogInfo := claimhelper.GetCatalogInfo(testID, result, catalog)
+	testCase.Description = catalogInfo.Description
+	testCase.Remediation = catalogInfo.Remediation
+	testCase.BestPracticeReference = catalogInfo.BestPracticeReference
+	testCase.ExceptionProcess = catalogInfo.ExceptionProcess
+
+	if categories := result.CategoryClassification; categories != nil {
+		for _, category := range []struct{ name, classification string }{
+			{identifiers.FarEdge, categories.FarEdge},
+			{identifiers.Telco, categories.Telco},
+			{identifiers.NonTelco, categories.NonTelco},
+			{identifiers.Extended, categories.Extended},
+		} {
+			if category.classification != "" {
+				testCase.Categories = append(testCase.Categories, category.name+":"+category.classification)
+			}
+		}
+	}
+
+	// Check details of test cases that could not run might not be set.
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(result.CheckDetails), &details); err == nil {
+		testCase.CompliantObjects = newReportObjects(details.CompliantObjectsOut)
+		testCase.NonCompliantObjects = newReportObjects(details.NonCompliantObjectsOut)
+		testCase.WaivedObjects = newReportObjects(details.WaivedObjectsOut)
+	}
+
+	return testCase
+}
+
+func newHTMLReport(c *claim.Claim, claimContent []byte) (*htmlReport, error) {
+	// The claim contents are escaped so they can't close the script element they are in.
+	compactClaimContent := bytes.Buffer{}
+	err := json.Compact(&compactClaimContent, claimContent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid claim contents: %w", err)
+	}
+	escapedClaimContent := bytes.Buffer{}
+	json.HTMLEscape(&escapedClaimContent, compactClaimContent.Bytes())
+
+	report := &htmlReport{
+		Tags:       []string{},
+		Categories: []string{},
+		ClaimJSON:  template.JS(escapedClaimContent.String()),
+	}
+	if c.Versions != nil {
+		report.Versions = *c.Versions
+	}
+	if c.Metadata != nil {
+		report.StartTime = c.Metadata.StartTime
+		report.EndTime = c.Metadata.EndTime
+	}
+
+	catalog := claimhelper.CatalogByID()
+
+	suites := map[string]*reportSuite{}
+	tags := map[string]bool{}
+	categories := map[string]bool{}
+	for testID := range c.Results {
+		result := c.Results[testID]
+		testCase := newReportTestCase(testID, &result, catalog)
+		report.TestCases = append(report.TestCases, testCase)
+
+		report.Summary.add(testCase.State)
+		if suites[testCase.Suite] == nil {
+			suites[testCase.Suite] = &reportSuite{Name: testCase.Suite}
+		}
+		suites[testCase.Suite].Summary.add(testCase.State)
+
+		for _, tag := range testCase.Tags {
+			tags[tag] = true
+		}
+		for _, category := range testCase.Categories {
+			categories[category] = true
+		}
+	}
+
+	sort.Slice(report.TestCases, func(i, j int) bool {
+		if report.TestCases[i].Suite != report.TestCases[j].Suite {
+			return report.TestCases[i].Suite < report.TestCases[j].Suite
+		}
+		return report.TestCases[i].ID < report.TestCases[j].ID
+	})
+	for _, suite := range suites {
+		report.Suites = append(report.Suites, *suite)
+	}
+	sort.Slice(report.Suites, func(i, j int) bool { return report.Suites[i].Name < report.Suites[j].Name })
+	for tag := range tags {
+		report.Tags = append(report.Tags, tag)
+	}
+	sort.Strings(report.Tags)
+	for category := range categories {
+		report.Categories = append(report.Categories, category)
+	}
+	sort.Strings(report.Categories)
+
+	return report, nil
+}
+
+// WriteHTMLReport writes a standalone HTML report of the claim, with its results, the catalog
+// info of its test cases, and the styles and scripts to filter them, so it can be viewed offline.
+// The claim file contents are embedded in the report too.
+func WriteHTMLReport(w io.Writer, c *claim.Claim, claimContent []byte) error {
+	report, err := newHTMLReport(c, claimContent)
+	if err != nil {
+		return err
+	}
+
+	err = htmlReportTemplate.Execute(w, report)
+	if err != nil {
+		return fmt.Errorf("failed to render the HTML report: %w", err)
+	}
+
+	return nil
+}
+
+// CreateHTMLReport creates the standalone HTML report of the claim file in outputDir. Returns the
+// path of the report file.
+func CreateHTMLReport(outputDir, claimFileName string) (filePath string, err error) {
+	claimFilePath := filepath.Join(outputDir, claimFileName)
+	claimContent, err := os.ReadFile(claimFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read claim file %s: %w", claimFilePath, err)
+	}
+
+	claimRoot := claim.Root{}
+	err = json.Unmarshal(claimContent, &claimRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal claim file %s: %w", claimFilePath, err)
+	}
+
+	report := bytes.Buffer{}
+	err = WriteHTMLReport(&report, claimRoot.Claim, claimContent)
+	if err != nil {
+		return "", err
+	}
+
+	filePath = filepath.Join(outputDir, HTMLReportFileName)
+	err = os.WriteFile(filePath, report.Bytes(), writeFilePerms)
+	if err != nil {
+		return "", fmt.Errorf("failed to write file %s: %w", filePath, err)
+	}
+
+	return filePath, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package results
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportTestResult(t *testing.T, testID, suite, tags, state string, nonCompliant []*testhelper.ReportObject) claim.Result {
+	t.Helper()
+
+	details, err := testhelper.ResultObjectsToString(nil, nonCompliant, nil)
+	require.NoError(t, err)
+
+	return claim.Result{
+		TestID:                 &claim.Identifier{Id: testID, Suite: suite, Tags: tags},
+		State:                  state,
+		CheckDetails:           details,
+		CatalogInfo:            &claim.CatalogInfo{Description: "Description of " + testID},
+		CategoryClassification: &claim.CategoryClassification{Telco: identifiers.Mandatory, FarEdge: identifiers.Optional},
+	}
+}
+
+func reportTestClaim(t *testing.T) *claim.Root {
+	t.Helper()
+
+	return &claim.Root{Claim: &claim.Claim{
+		Configurations: map[string]interface{}{},
+		Nodes:          map[string]interface{}{},
+		Metadata:       &claim.Metadata{StartTime: "2026-10-17 10:00:00 +0000 UTC", EndTime: "2026-10-17 10:30:00 +0000 UTC"},
+		Versions:       &claim.Versions{CertSuite: "v5.6.0", ClaimFormat: "v0.5.0"},
+		Results: map[string]claim.Result{
+			"access-control-net-admin-capability-check": reportTestResult(t, "access-control-net-admin-capability-check", "access-control", "telco",
+				"failed", []*testhelper.ReportObject{testhelper.NewContainerReportObject("tnf", "test-0", "test", "</script><script>alert(1)</script>", false)}),
+			"access-control-ssh-daemons": reportTestResult(t, "access-control-ssh-daemons", "access-control", "telco,extended", "passed-after-retry", nil),
+			"lifecycle-pod-scheduling":   reportTestResult(t, "lifecycle-pod-scheduling", "lifecycle", "common", "skipped", nil),
+			"removed-test-case":          reportTestResult(t, "removed-test-case", "lifecycle", "", "error", nil),
+		},
+	}}
+}
+
+func TestNewHTMLReport(t *testing.T) {
+	claimRoot := reportTestClaim(t)
+	claimContent, err := json.Marshal(claimRoot)
+	require.NoError(t, err)
+
+	report, err := newHTMLReport(claimRoot.Claim, claimContent)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v5.6.0", report.Versions.CertSuite)
+	assert.Equal(t, "2026-10-17 10:00:00 +0000 UTC", report.StartTime)
+	assert.Equal(t, reportSummary{Total: 4, Passed: 1, Failed: 1, Skipped: 1, Error: 1}, report.Summary)
+	assert.Equal(t, []reportSuite{
+		{Name: "access-control", Summary: reportSummary{Total: 2, Passed: 1, Failed: 1}},
+		{Name: "lifecycle", Summary: reportSummary{Total: 2, Skipped: 1, Error: 1}},
+	}, report.Suites)
+	assert.Equal(t, []string{"common", "extended", "telco"}, report.Tags)
+	assert.Equal(t, []string{"FarEdge:Optional", "Telco:Mandatory"}, report.Categories)
+
+	require.Len(t, report.TestCases, 4)
+	netAdmin := report.TestCases[0]
+	assert.Equal(t, "access-control-net-admin-capability-check", netAdmin.ID)
+	assert.Equal(t, "failed", netAdmin.State)
+	// The catalog is preferred over the catalog info saved in the claim.
+	assert.Contains(t, netAdmin.Description, "Ensures that containers do not use NET_ADMIN capability.")
+	assert.Equal(t, identifiers.ImpactMap[netAdmin.ID], netAdmin.Impact)
+	require.Len(t, netAdmin.NonCompliantObjects, 1)
+	assert.Equal(t, testhelper.ContainerType, netAdmin.NonCompliantObjects[0].Type)
+	assert.Contains(t, netAdmin.NonCompliantObjects[0].Fields, reportField{Key: testhelper.PodName, Value: "test-0"})
+
+	sshDaemons := report.TestCases[1]
+	assert.Equal(t, "passed-after-retry", sshDaemons.Result)
+	assert.Equal(t, "passed", sshDaemons.State)
+	assert.Equal(t, []string{"telco", "extended"}, sshDaemons.Tags)
+
+	removed := report.TestCases[3]
+	assert.Equal(t, "removed-test-case", removed.ID)
+	assert.Equal(t, "Description of removed-test-case", removed.Description)
+	assert.Empty(t, removed.Tags)
+
+	_, err = newHTMLReport(claimRoot.Claim, []byte("{invalid"))
+	assert.Error(t, err)
+}
+
+func TestWriteHTMLReport(t *testing.T) {
+	claimRoot := reportTestClaim(t)
+	claimContent, err := json.Marshal(claimRoot)
+	require.NoError(t, err)
+
+	report := bytes.Buffer{}
+	require.NoError(t, WriteHTMLReport(&report, claimRoot.Claim, claimContent))
+
+	html := report.String()
+	assert.Contains(t, html, `data-id="access-control-net-admin-capability-check"`)
+	assert.Contains(t, html, `<option value="Telco:Mandatory">`)
+	assert.Contains(t, html, "const claimJSON = {")
+	// The claim and objects contents never close the script element.
+	assert.Equal(t, 1, strings.Count(html, "</script>"))
+	assert.NotContains(t, html, "<script>alert(1)")
+}
+
+func TestCreateHTMLReport(t *testing.T) {
+	outputDir := t.TempDir()
+	claimContent, err := json.Marshal(reportTestClaim(t))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "claim.json"), claimContent, writeFilePerms))
+
+	filePath, err := CreateHTMLReport(outputDir, "claim.json")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(outputDir, HTMLReportFileName), filePath)
+
+	report, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(report), "lifecycle-pod-scheduling")
+
+	_, err = CreateHTMLReport(outputDir, "missing.json")
+	assert.Error(t, err)
+}
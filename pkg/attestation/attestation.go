@@ -0,0 +1,386 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/versions"
+)
+
+// The attestations are DSSE envelopes (https://github.com/secure-systems-lab/dsse) whose payload is
+// an in-toto statement (https://github.com/in-toto/attestation) with the digests of the signed files.
+const (
+	PayloadType   = "application/vnd.in-toto+json"
+	StatementType = "https://in-toto.io/Statement/v1"
+	PredicateType = "https://github.com/redhat-best-practices-for-k8s/certsuite/attestation/v1"
+
+	// FileSuffix is the suffix of the attestation files.
+	FileSuffix = ".intoto.json"
+
+	digestAlgorithm       = "sha256"
+	attestationFilePerms  = 0o644
+	dssePAEPrefix         = "DSSEv1"
+	pemTypePrivateKey     = "PRIVATE KEY"
+	pemTypeECPrivateKey   = "EC PRIVATE KEY"
+	pemTypeRSAPrivateKey  = "RSA PRIVATE KEY"
+	pemTypePublicKey      = "PUBLIC KEY"
+	timestampFormatLayout = time.RFC3339
+)
+
+// ErrSignatureMismatch is returned when no signature of the attestation was made with the key.
+var ErrSignatureMismatch = errors.New("no signature matches the public key")
+
+// Signature is a signature of a DSSE envelope.
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Envelope is a DSSE envelope, whose payload is base64 encoded.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Subject is a signed file, by name relative to the attestation file directory.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Predicate has the certsuite build that signed the files.
+type Predicate struct {
+	CertSuiteVersion   string            `json:"certSuiteVersion"`
+	CertSuiteGitCommit string            `json:"certSuiteGitCommit"`
+	ClaimFormat        string            `json:"claimFormat"`
+	BinaryDigest       map[string]string `json:"binaryDigest"`
+	Timestamp          string            `json:"timestamp"`
+}
+
+// Statement is the in-toto statement signed in the attestations.
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// pae returns the DSSE pre-authentication encoding of the payload, which is what is signed.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("%s %d %s %d %s", dssePAEPrefix, len(payloadType), payloadType, len(payload), payload))
+}
+
+func fileDigest(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// LoadPrivateKey returns the private key of a PEM file, in PKCS #8, or in PKCS #1 or SEC 1 for RSA
+// and ECDSA keys. Ed25519, ECDSA and RSA keys are supported.
+func LoadPrivateKey(keyFile string) (crypto.Signer, error) {
+	block, err := readPEMFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var key any
+	switch block.Type {
+	case pemTypePrivateKey:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case pemTypeECPrivateKey:
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case pemTypeRSAPrivateKey:
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key file %s has no private key, but a %q PEM block", keyFile, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key of %s: %w", keyFile, err)
+	}
+
+	switch typedKey := key.(type) {
+	case ed25519.PrivateKey:
+		return typedKey, nil
+	case *ecdsa.PrivateKey:
+		return typedKey, nil
+	case *rsa.PrivateKey:
+		return typedKey, nil
+	}
+
+	return nil, fmt.Errorf("private key type %T of %s is not supported", key, keyFile)
+}
+
+// LoadPublicKey returns the PKIX public key of a PEM file.
+func LoadPublicKey(keyFile string) (crypto.PublicKey, error) {
+	block, err := readPEMFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != pemTypePublicKey {
+		return nil, fmt.Errorf("key file %s has no public key, but a %q PEM block", keyFile, block.Type)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the public key of %s: %w", keyFile, err)
+	}
+
+	return key, nil
+}
+
+func readPEMFile(keyFile string) (*pem.Block, error) {
+	content, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %w", keyFile, err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("key file %s is not PEM encoded", keyFile)
+	}
+
+	return block, nil
+}
+
+// KeyID returns the ID of the public key: the hex encoded sha256 digest of its PKIX encoding.
+func KeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the public key: %w", err)
+	}
+
+	digest := sha256.Sum256(der)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+func sign(key crypto.Signer, message []byte) ([]byte, error) {
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return key.Sign(rand.Reader, message, crypto.Hash(0))
+	}
+
+	digest := sha256.Sum256(message)
+	return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+func verifySignature(publicKey crypto.PublicKey, message, signature []byte) bool {
+	switch typedKey := publicKey.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(typedKey, message, signature)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		return ecdsa.VerifyASN1(typedKey, digest[:], signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		return rsa.VerifyPKCS1v15(typedKey, crypto.SHA256, digest[:], signature) == nil
+	}
+
+	return false
+}
+
+// NewPredicate returns the predicate of the running certsuite build, with the digest of its binary.
+func NewPredicate() (Predicate, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return Predicate{}, fmt.Errorf("failed to get the certsuite binary path: %w", err)
+	}
+
+	digest, err := fileDigest(executable)
+	if err != nil {
+		return Predicate{}, fmt.Errorf("failed to get the digest of the certsuite binary %s: %w", executable, err)
+	}
+
+	return Predicate{
+		CertSuiteVersion:   versions.GitDisplayRelease,
+		CertSuiteGitCommit: versions.GitCommit,
+		ClaimFormat:        versions.ClaimFormatVersion,
+		BinaryDigest:       map[string]string{digestAlgorithm: digest},
+		Timestamp:          time.Now().UTC().Format(timestampFormatLayout),
+	}, nil
+}
+
+// SignFiles returns the attestation of the files, signed with the key. The files are named after
+// their path relative to baseDir.
+func SignFiles(key crypto.Signer, baseDir string, filePaths []string, predicate *Predicate) (*Envelope, error) {
+	statement := Statement{
+		Type:          StatementType,
+		Subject:       []Subject{},
+		PredicateType: PredicateType,
+		Predicate:     *predicate,
+	}
+
+	for _, filePath := range filePaths {
+		name, err := filepath.Rel(baseDir, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("file %s is not in %s: %w", filePath, baseDir, err)
+		}
+
+		digest, err := fileDigest(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the digest of %s: %w", filePath, err)
+		}
+		statement.Subject = append(statement.Subject, Subject{Name: filepath.ToSlash(name), Digest: map[string]string{digestAlgorithm: digest}})
+	}
+
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the statement: %w", err)
+	}
+
+	signature, err := sign(key, pae(PayloadType, payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the statement: %w", err)
+	}
+
+	keyID, err := KeyID(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []Signature{{KeyID: keyID, Sig: base64.StdEncoding.EncodeToString(signature)}},
+	}, nil
+}
+
+// WriteEnvelope writes the attestation file.
+func WriteEnvelope(envelope *Envelope, attestationFile string) error {
+	content, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the attestation: %w", err)
+	}
+
+	if err := os.WriteFile(attestationFile, content, attestationFilePerms); err != nil {
+		return fmt.Errorf("failed to write the attestation file %s: %w", attestationFile, err)
+	}
+
+	return nil
+}
+
+// SignFilesToFile signs the files, named after their path relative to the attestation file
+// directory, and writes the attestation file.
+func SignFilesToFile(key crypto.Signer, attestationFile string, filePaths []string, predicate *Predicate) error {
+	envelope, err := SignFiles(key, filepath.Dir(attestationFile), filePaths, predicate)
+	if err != nil {
+		return err
+	}
+
+	return WriteEnvelope(envelope, attestationFile)
+}
+
+// VerifyEnvelope checks that the attestation was signed with the key, and returns its statement.
+func VerifyEnvelope(envelope *Envelope, publicKey crypto.PublicKey) (*Statement, error) {
+	if envelope.PayloadType != PayloadType {
+		return nil, fmt.Errorf("unexpected payload type %q", envelope.PayloadType)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the payload: %w", err)
+	}
+
+	verified := false
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err == nil && verifySignature(publicKey, pae(envelope.PayloadType, payload), sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrSignatureMismatch
+	}
+
+	statement := Statement{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	if err := decoder.Decode(&statement); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the statement: %w", err)
+	}
+	if statement.Type != StatementType || statement.PredicateType != PredicateType {
+		return nil, fmt.Errorf("unexpected statement type %q with predicate type %q", statement.Type, statement.PredicateType)
+	}
+
+	return &statement, nil
+}
+
+// VerifySubjects checks the digests of the signed files, relative to baseDir. Returns an error
+// with every file that is missing or was modified.
+func VerifySubjects(statement *Statement, baseDir string) error {
+	errs := []error{}
+	for _, subject := range statement.Subject {
+		expectedDigest, found := subject.Digest[digestAlgorithm]
+		if !found {
+			errs = append(errs, fmt.Errorf("file %s has no %s digest", subject.Name, digestAlgorithm))
+			continue
+		}
+
+		digest, err := fileDigest(filepath.Join(baseDir, filepath.FromSlash(subject.Name)))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get the digest of file %s: %w", subject.Name, err))
+			continue
+		}
+		if digest != expectedDigest {
+			errs = append(errs, fmt.Errorf("file %s was modified: its %s digest is %s instead of %s", subject.Name, digestAlgorithm, digest, expectedDigest))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// VerifyFile checks the signature of the attestation file with the public key, and the digests of
+// its files, relative to baseDir. Returns the statement of the attestation.
+func VerifyFile(attestationFile, publicKeyFile, baseDir string) (*Statement, error) {
+	publicKey, err := LoadPublicKey(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(attestationFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the attestation file %s: %w", attestationFile, err)
+	}
+
+	envelope := Envelope{}
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the attestation file %s: %w", attestationFile, err)
+	}
+
+	statement, err := VerifyEnvelope(&envelope, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature of %s: %w", attestationFile, err)
+	}
+
+	if err := VerifySubjects(statement, baseDir); err != nil {
+		return statement, err
+	}
+
+	return statement, nil
+}
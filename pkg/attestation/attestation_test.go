@@ -0,0 +1,168 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyFiles writes the PEM private and public key files of the key in dir.
+func writeKeyFiles(t *testing.T, dir string, key crypto.Signer) (privateKeyFile, publicKeyFile string) {
+	t.Helper()
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	privateKeyFile = filepath.Join(dir, "key.pem")
+	publicKeyFile = filepath.Join(dir, "key.pub")
+	require.NoError(t, os.WriteFile(privateKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600))
+	require.NoError(t, os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+
+	return privateKeyFile, publicKeyFile
+}
+
+func writeSignedFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	claimFile := filepath.Join(dir, "claim.json")
+	reportFile := filepath.Join(dir, "report.html")
+	require.NoError(t, os.WriteFile(claimFile, []byte(`{"claim":{}}`), 0o600))
+	require.NoError(t, os.WriteFile(reportFile, []byte("<html></html>"), 0o600))
+
+	return []string{claimFile, reportFile}
+}
+
+func TestPAE(t *testing.T) {
+	// Example of the DSSE protocol specification.
+	assert.Equal(t, "DSSEv1 29 http://example.com/HelloWorld 11 hello world", string(pae("http://example.com/HelloWorld", []byte("hello world"))))
+}
+
+func TestSignAndVerify(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for name, key := range map[string]crypto.Signer{"ed25519": ed25519Key, "ecdsa": ecdsaKey, "rsa": rsaKey} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			privateKeyFile, publicKeyFile := writeKeyFiles(t, dir, key)
+
+			signKey, err := LoadPrivateKey(privateKeyFile)
+			require.NoError(t, err)
+
+			attestationFile := filepath.Join(dir, "certsuite"+FileSuffix)
+			predicate := Predicate{CertSuiteVersion: "v5.6.0", BinaryDigest: map[string]string{"sha256": "abc"}}
+			require.NoError(t, SignFilesToFile(signKey, attestationFile, writeSignedFiles(t, dir), &predicate))
+
+			statement, err := VerifyFile(attestationFile, publicKeyFile, dir)
+			require.NoError(t, err)
+			assert.Equal(t, StatementType, statement.Type)
+			assert.Equal(t, predicate, statement.Predicate)
+			require.Len(t, statement.Subject, 2)
+			assert.Equal(t, "claim.json", statement.Subject[0].Name)
+			assert.Equal(t, "report.html", statement.Subject[1].Name)
+		})
+	}
+}
+
+func TestVerifyFileModified(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	_, publicKeyFile := writeKeyFiles(t, dir, key)
+	filePaths := writeSignedFiles(t, dir)
+
+	attestationFile := filepath.Join(dir, "certsuite"+FileSuffix)
+	require.NoError(t, SignFilesToFile(key, attestationFile, filePaths, &Predicate{}))
+
+	require.NoError(t, os.WriteFile(filePaths[0], []byte(`{"claim":{"results":{}}}`), 0o600))
+	require.NoError(t, os.Remove(filePaths[1]))
+
+	_, err = VerifyFile(attestationFile, publicKeyFile, dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file claim.json was modified")
+	assert.Contains(t, err.Error(), "failed to get the digest of file report.html")
+}
+
+func TestVerifyEnvelope(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	envelope, err := SignFiles(key, dir, writeSignedFiles(t, dir), &Predicate{})
+	require.NoError(t, err)
+
+	keyID, err := KeyID(key.Public())
+	require.NoError(t, err)
+	assert.Equal(t, keyID, envelope.Signatures[0].KeyID)
+
+	_, err = VerifyEnvelope(envelope, key.Public())
+	require.NoError(t, err)
+
+	_, err = VerifyEnvelope(envelope, otherPublicKey)
+	assert.ErrorIs(t, err, ErrSignatureMismatch)
+
+	// A statement with a subject digest changed after signing.
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	require.NoError(t, err)
+	statement := Statement{}
+	require.NoError(t, json.Unmarshal(payload, &statement))
+	statement.Subject[0].Digest["sha256"] = "0000"
+	payload, err = json.Marshal(statement)
+	require.NoError(t, err)
+	envelope.Payload = base64.StdEncoding.EncodeToString(payload)
+
+	_, err = VerifyEnvelope(envelope, key.Public())
+	assert.ErrorIs(t, err, ErrSignatureMismatch)
+}
+
+func TestLoadKeys(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	privateKeyFile, publicKeyFile := writeKeyFiles(t, dir, key)
+
+	_, err = LoadPrivateKey(publicKeyFile)
+	assert.EqualError(t, err, "key file "+publicKeyFile+` has no private key, but a "PUBLIC KEY" PEM block`)
+
+	_, err = LoadPublicKey(privateKeyFile)
+	assert.EqualError(t, err, "key file "+privateKeyFile+` has no public key, but a "PRIVATE KEY" PEM block`)
+
+	notPEMFile := filepath.Join(dir, "key.txt")
+	require.NoError(t, os.WriteFile(notPEMFile, []byte("key"), 0o600))
+	_, err = LoadPrivateKey(notPEMFile)
+	assert.EqualError(t, err, "key file "+notPEMFile+" is not PEM encoded")
+
+	// SEC 1 ECDSA private keys.
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(ecdsaKey)
+	require.NoError(t, err)
+	ecKeyFile := filepath.Join(dir, "ec.pem")
+	require.NoError(t, os.WriteFile(ecKeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+	signKey, err := LoadPrivateKey(ecKeyFile)
+	require.NoError(t, err)
+	assert.Equal(t, ecdsaKey.Public(), signKey.Public())
+}
@@ -338,19 +338,24 @@ func DoAutoDiscover(config *configuration.TestConfiguration) DiscoveredTestData
 		log.Fatal("Cannot get list of network attachment definitions, err: %v", err)
 	}
 
-	data.ExecutedBy = config.ExecutedBy
-	data.PartnerName = config.PartnerName
-	data.CollectorAppPassword = config.CollectorAppPassword
-	data.CollectorAppEndpoint = config.CollectorAppEndpoint
-	data.ConnectAPIKey = config.ConnectAPIConfig.APIKey
-	data.ConnectAPIBaseURL = config.ConnectAPIConfig.BaseURL
-	data.ConnectProjectID = config.ConnectAPIConfig.ProjectID
-	data.ConnectAPIProxyURL = config.ConnectAPIConfig.ProxyURL
-	data.ConnectAPIProxyPort = config.ConnectAPIConfig.ProxyPort
+	setConfigParams(&data, config)
 
 	return data
 }
 
+// setConfigParams copies the collector and Red Hat Connect params from the config file.
+func setConfigParams(discoveredData *DiscoveredTestData, config *configuration.TestConfiguration) {
+	discoveredData.ExecutedBy = config.ExecutedBy
+	discoveredData.PartnerName = config.PartnerName
+	discoveredData.CollectorAppPassword = config.CollectorAppPassword
+	discoveredData.CollectorAppEndpoint = config.CollectorAppEndpoint
+	discoveredData.ConnectAPIKey = config.ConnectAPIConfig.APIKey
+	discoveredData.ConnectAPIBaseURL = config.ConnectAPIConfig.BaseURL
+	discoveredData.ConnectProjectID = config.ConnectAPIConfig.ProjectID
+	discoveredData.ConnectAPIProxyURL = config.ConnectAPIConfig.ProxyURL
+	discoveredData.ConnectAPIProxyPort = config.ConnectAPIConfig.ProxyPort
+}
+
 func namespacesListToStringList(namespaceList []configuration.Namespace) (stringList []string) {
 	for _, ns := range namespaceList {
 		stringList = append(stringList, ns.Name)
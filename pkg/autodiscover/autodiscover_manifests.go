@@ -0,0 +1,413 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package autodiscover
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	nadClient "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	olmv1Alpha "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/compatibility"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	release "helm.sh/helm/v4/pkg/release/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	defaultManifestsNamespace = "default"
+	// Suffix used to name the pods (and replicasets) created from the workloads' pod templates.
+	manifestPodNameSuffix  = "manifest"
+	manifestDecoderBufSize = 4096
+)
+
+var (
+	manifestsScheme = runtime.NewScheme()
+	manifestsCodecs = serializer.NewCodecFactory(manifestsScheme)
+
+	// Kinds that are not namespaced, so they never get the default namespace.
+	clusterScopedKinds = map[string]bool{
+		"Namespace":                true,
+		"Node":                     true,
+		"PersistentVolume":         true,
+		"StorageClass":             true,
+		"ClusterRole":              true,
+		"ClusterRoleBinding":       true,
+		"CustomResourceDefinition": true,
+	}
+
+	manifestsExtensions = map[string]bool{
+		".yaml": true,
+		".yml":  true,
+		".json": true,
+	}
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(manifestsScheme))
+	utilruntime.Must(apiextv1.AddToScheme(manifestsScheme))
+	utilruntime.Must(olmv1Alpha.AddToScheme(manifestsScheme))
+	utilruntime.Must(nadClient.AddToScheme(manifestsScheme))
+}
+
+// DoManifestDiscover is the offline counterpart of DoAutoDiscover: instead of querying a live cluster,
+// the objects under test are read from the manifests found in manifestsPath (a file or a directory, e.g.
+// the output of "helm template" or "kustomize build"). The manifests are loaded into in-memory clients
+// so the same discovery helpers and filters are used. As there are no running pods, one pod is created
+// from each workload's pod template.
+//
+//nolint:funlen
+func DoManifestDiscover(config *configuration.TestConfiguration, manifestsPath string) (DiscoveredTestData, error) {
+	defaultNamespace := defaultManifestsNamespace
+	if len(config.TargetNameSpaces) > 0 {
+		defaultNamespace = config.TargetNameSpaces[0].Name
+	}
+
+	objects, err := LoadManifests(manifestsPath, defaultNamespace)
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	log.Info("Loaded %d objects from manifests in %q", len(objects), manifestsPath)
+
+	oc := clientsholder.SetOfflineClientsHolder(objects)
+
+	manifestData := DiscoveredTestData{}
+	podsUnderTestLabelsObjects := CreateLabels(config.PodsUnderTestLabels)
+	operatorsUnderTestLabelsObjects := CreateLabels(config.OperatorsUnderTestLabels)
+
+	manifestData.AllNamespaces, err = getAllNamespaces(oc.K8sClient.CoreV1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+
+	// Without target namespaces in the config, every namespace found in the manifests is under test.
+	targetNamespaces := config.TargetNameSpaces
+	if len(targetNamespaces) == 0 {
+		for _, ns := range manifestData.AllNamespaces {
+			targetNamespaces = append(targetNamespaces, configuration.Namespace{Name: ns})
+		}
+	}
+	manifestData.Namespaces = namespacesListToStringList(targetNamespaces)
+
+	manifestData.StorageClasses, err = getAllStorageClasses(oc.K8sClient.StorageV1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+
+	manifestData.Pods, manifestData.AllPods = FindPodsByLabels(oc.K8sClient.CoreV1(), podsUnderTestLabelsObjects, manifestData.Namespaces)
+	manifestData.PodStates.BeforeExecution = CountPodsByStatus(manifestData.AllPods)
+	manifestData.Deployments = findDeploymentsByLabels(oc.K8sClient.AppsV1(), podsUnderTestLabelsObjects, manifestData.Namespaces)
+	manifestData.StatefulSet = findStatefulSetsByLabels(oc.K8sClient.AppsV1(), podsUnderTestLabelsObjects, manifestData.Namespaces)
+	manifestData.Hpas = findHpaControllers(oc.K8sClient, manifestData.Namespaces)
+
+	manifestData.AllSubscriptions = findSubscriptions(oc.OlmClient.OperatorsV1alpha1(), []string{""})
+	manifestData.Subscriptions = findSubscriptions(oc.OlmClient.OperatorsV1alpha1(), manifestData.Namespaces)
+	manifestData.AllCsvs, err = getAllOperators(oc.OlmClient.OperatorsV1alpha1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.Csvs = findOperatorsByLabels(oc.OlmClient.OperatorsV1alpha1(), operatorsUnderTestLabelsObjects, targetNamespaces)
+	manifestData.AllInstallPlans = getAllInstallPlans(oc.OlmClient.OperatorsV1alpha1())
+	manifestData.AllCatalogSources = getAllCatalogSources(oc.OlmClient.OperatorsV1alpha1())
+	// Operator controller pods are not running, so there's nothing to map the CSVs to.
+	manifestData.CSVToPodListMap = map[types.NamespacedName][]*corev1.Pod{}
+
+	manifestData.AllCrds, err = getClusterCrdNames()
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.Crds = FindTestCrdNames(manifestData.AllCrds, config.CrdFilters)
+
+	manifestData.ResourceQuotaItems, err = getResourceQuotas(oc.K8sClient.CoreV1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.PodDisruptionBudgets, err = getPodDisruptionBudgets(oc.K8sClient.PolicyV1(), manifestData.Namespaces)
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.NetworkPolicies, err = getNetworkPolicies(oc.K8sNetworkingClient)
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.ClusterRoleBindings, err = getClusterRoleBindings(oc.K8sClient.RbacV1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.RoleBindings, err = getRoleBindings(oc.K8sClient.RbacV1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.Roles, err = getRoles(oc.K8sClient.RbacV1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.PersistentVolumes, err = getPersistentVolumes(oc.K8sClient.CoreV1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.PersistentVolumeClaims, err = getPersistentVolumeClaims(oc.K8sClient.CoreV1())
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.Services, err = getServices(oc.K8sClient.CoreV1(), manifestData.Namespaces, config.ServicesIgnoreList)
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.AllServices, err = getServices(oc.K8sClient.CoreV1(), manifestData.AllNamespaces, config.ServicesIgnoreList)
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.ServiceAccounts, err = getServiceAccounts(oc.K8sClient.CoreV1(), manifestData.Namespaces)
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.AllServiceAccounts, err = getServiceAccounts(oc.K8sClient.CoreV1(), []string{metav1.NamespaceAll})
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+	manifestData.NetworkAttachmentDefinitions, err = getNetworkAttachmentDefinitions(oc, manifestData.Namespaces)
+	if err != nil {
+		return DiscoveredTestData{}, err
+	}
+
+	nodes := &corev1.NodeList{}
+	for _, obj := range objects {
+		if node, ok := obj.(*corev1.Node); ok {
+			nodes.Items = append(nodes.Items, *node)
+		}
+	}
+	manifestData.Nodes = nodes
+	manifestData.HelmChartReleases = map[string][]*release.Release{}
+
+	// There's no cluster to get the version from.
+	manifestData.OpenshiftVersion = NonOpenshiftClusterVersion
+	manifestData.OCPStatus = compatibility.DetermineOCPStatus(manifestData.OpenshiftVersion, time.Now())
+	manifestData.ValidProtocolNames = config.ValidProtocolNames
+	manifestData.ServicesIgnoreList = config.ServicesIgnoreList
+
+	setConfigParams(&manifestData, config)
+
+	return manifestData, nil
+}
+
+// LoadManifests decodes all the k8s objects found in manifestsPath, which can be a single file or a
+// directory that is walked recursively. Multi-document YAML and v1/List objects are supported. Objects
+// of unknown kinds are ignored. Namespaced objects without namespace are set to defaultNamespace. The
+// returned list also contains the namespaces referenced by the objects and one pod for each workload.
+func LoadManifests(manifestsPath, defaultNamespace string) ([]runtime.Object, error) {
+	files, err := getManifestFiles(manifestsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []runtime.Object{}
+	for _, file := range files {
+		fileObjects, err := loadManifestFile(file)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, fileObjects...)
+	}
+
+	namespaces := map[string]bool{}
+	for _, obj := range objects {
+		metaObj, ok := obj.(metav1.Object)
+		if !ok {
+			continue
+		}
+
+		if ns, isNs := obj.(*corev1.Namespace); isNs {
+			namespaces[ns.Name] = true
+			continue
+		}
+
+		if clusterScopedKinds[obj.GetObjectKind().GroupVersionKind().Kind] {
+			continue
+		}
+
+		if metaObj.GetNamespace() == "" {
+			metaObj.SetNamespace(defaultNamespace)
+		}
+
+		if !namespaces[metaObj.GetNamespace()] {
+			namespaces[metaObj.GetNamespace()] = true
+			objects = append(objects, &corev1.Namespace{
+				TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: metaObj.GetNamespace()},
+			})
+		}
+	}
+
+	objects = append(objects, createPodsFromWorkloads(objects)...)
+
+	return objects, nil
+}
+
+func getManifestFiles(manifestsPath string) ([]string, error) {
+	info, err := os.Stat(manifestsPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not access manifests path %q: %w", manifestsPath, err)
+	}
+
+	if !info.IsDir() {
+		return []string{manifestsPath}, nil
+	}
+
+	files := []string{}
+	err = filepath.WalkDir(manifestsPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && manifestsExtensions[strings.ToLower(filepath.Ext(path))] {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not walk manifests directory %q: %w", manifestsPath, err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func loadManifestFile(file string) ([]runtime.Object, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest file %q: %w", file, err)
+	}
+
+	objects := []runtime.Object{}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), manifestDecoderBufSize)
+	for {
+		raw := runtime.RawExtension{}
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("could not parse manifest file %q: %w", file, err)
+		}
+
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+
+		docObjects, err := decodeManifest(raw.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode object in manifest file %q: %w", file, err)
+		}
+		objects = append(objects, docObjects...)
+	}
+
+	return objects, nil
+}
+
+func decodeManifest(raw []byte) ([]runtime.Object, error) {
+	obj, gvk, err := manifestsCodecs.UniversalDeserializer().Decode(raw, nil, nil)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			log.Debug("Skipping manifest object of unsupported kind: %v", err)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	list, isList := obj.(*corev1.List)
+	if !isList {
+		// The decoder clears the TypeMeta, but it's needed later to know the object's kind.
+		obj.GetObjectKind().SetGroupVersionKind(*gvk)
+		return []runtime.Object{obj}, nil
+	}
+
+	objects := []runtime.Object{}
+	for _, item := range list.Items {
+		itemObjects, err := decodeManifest(item.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, itemObjects...)
+	}
+	return objects, nil
+}
+
+// createPodsFromWorkloads returns one pod for each workload's pod template, owned by the same kind
+// of resource a controller would have used, so the pod owner checks get the expected references.
+func createPodsFromWorkloads(objects []runtime.Object) []runtime.Object {
+	pods := []runtime.Object{}
+	for _, obj := range objects {
+		switch workload := obj.(type) {
+		case *appsv1.Deployment:
+			pods = append(pods, newManifestPod(&workload.ObjectMeta, &workload.Spec.Template, "apps/v1", "ReplicaSet",
+				workload.Name+"-"+manifestPodNameSuffix))
+		case *appsv1.ReplicaSet:
+			pods = append(pods, newManifestPod(&workload.ObjectMeta, &workload.Spec.Template, "apps/v1", "ReplicaSet", workload.Name))
+		case *appsv1.StatefulSet:
+			pods = append(pods, newManifestPod(&workload.ObjectMeta, &workload.Spec.Template, "apps/v1", "StatefulSet", workload.Name))
+		case *appsv1.DaemonSet:
+			pods = append(pods, newManifestPod(&workload.ObjectMeta, &workload.Spec.Template, "apps/v1", "DaemonSet", workload.Name))
+		case *batchv1.Job:
+			pods = append(pods, newManifestPod(&workload.ObjectMeta, &workload.Spec.Template, "batch/v1", "Job", workload.Name))
+		case *batchv1.CronJob:
+			pods = append(pods, newManifestPod(&workload.ObjectMeta, &workload.Spec.JobTemplate.Spec.Template, "batch/v1", "Job",
+				workload.Name+"-"+manifestPodNameSuffix))
+		}
+	}
+
+	return pods
+}
+
+func newManifestPod(workloadMeta *metav1.ObjectMeta, template *corev1.PodTemplateSpec, ownerAPIVersion, ownerKind, ownerName string) *corev1.Pod {
+	isController := true
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        workloadMeta.Name + "-" + manifestPodNameSuffix,
+			Namespace:   workloadMeta.Namespace,
+			Labels:      template.Labels,
+			Annotations: template.Annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: ownerAPIVersion,
+				Kind:       ownerKind,
+				Name:       ownerName,
+				Controller: &isController,
+			}},
+		},
+		Spec: *template.Spec.DeepCopy(),
+		// The pods are considered running so they are not filtered out by the discovery.
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	started := true
+	for i := range pod.Spec.Containers {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:    pod.Spec.Containers[i].Name,
+			Image:   pod.Spec.Containers[i].Image,
+			Ready:   true,
+			Started: &started,
+			State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		})
+	}
+
+	return pod
+}
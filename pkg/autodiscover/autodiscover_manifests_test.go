@@ -0,0 +1,168 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package autodiscover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testDeploymentManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-deployment
+  namespace: tnf
+  labels:
+    app: test
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+        redhat-best-practices-for-k8s.com/generic: target
+    spec:
+      containers:
+      - name: test-container
+        image: registry.example.com/test:1.0
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: test-service
+spec:
+  ports:
+  - port: 8080
+---
+apiVersion: example.com/v1
+kind: UnknownKind
+metadata:
+  name: ignored
+`
+
+const testListManifest = `{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {
+      "apiVersion": "apps/v1",
+      "kind": "StatefulSet",
+      "metadata": {"name": "test-statefulset", "namespace": "tnf"},
+      "spec": {
+        "selector": {"matchLabels": {"app": "db"}},
+        "template": {
+          "metadata": {"labels": {"app": "db"}},
+          "spec": {"containers": [{"name": "db", "image": "registry.example.com/db:1.0"}]}
+        }
+      }
+    },
+    {
+      "apiVersion": "v1",
+      "kind": "ServiceAccount",
+      "metadata": {"name": "test-sa", "namespace": "tnf"}
+    }
+  ]
+}`
+
+func writeTestManifests(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "deployment.yaml"), []byte(testDeploymentManifest), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "list.json"), []byte(testListManifest), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a manifest"), 0o600))
+	return dir
+}
+
+func findObject[T runtime.Object](objects []runtime.Object, match func(T) bool) T {
+	var zero T
+	for _, obj := range objects {
+		if typed, ok := obj.(T); ok && match(typed) {
+			return typed
+		}
+	}
+	return zero
+}
+
+func TestLoadManifests(t *testing.T) {
+	objects, err := LoadManifests(writeTestManifests(t), "defaultns")
+	require.NoError(t, err)
+
+	// Deployment, Service, StatefulSet, ServiceAccount, namespaces tnf and defaultns and two pods.
+	assert.Len(t, objects, 8)
+
+	service := findObject(objects, func(s *corev1.Service) bool { return s.Name == "test-service" })
+	require.NotNil(t, service)
+	assert.Equal(t, "defaultns", service.Namespace)
+	assert.Equal(t, "Service", service.Kind)
+
+	for _, ns := range []string{"tnf", "defaultns"} {
+		assert.NotNil(t, findObject(objects, func(n *corev1.Namespace) bool { return n.Name == ns }))
+	}
+
+	assert.NotNil(t, findObject(objects, func(s *appsv1.StatefulSet) bool { return s.Name == "test-statefulset" }))
+
+	deploymentPod := findObject(objects, func(p *corev1.Pod) bool { return p.Name == "test-deployment-manifest" })
+	require.NotNil(t, deploymentPod)
+	assert.Equal(t, "tnf", deploymentPod.Namespace)
+	assert.Equal(t, "target", deploymentPod.Labels["redhat-best-practices-for-k8s.com/generic"])
+	require.Len(t, deploymentPod.OwnerReferences, 1)
+	assert.Equal(t, "ReplicaSet", deploymentPod.OwnerReferences[0].Kind)
+	assert.Equal(t, "test-deployment-manifest", deploymentPod.OwnerReferences[0].Name)
+	assert.Equal(t, corev1.PodRunning, deploymentPod.Status.Phase)
+	require.Len(t, deploymentPod.Status.ContainerStatuses, 1)
+	assert.True(t, deploymentPod.Status.ContainerStatuses[0].Ready)
+
+	statefulSetPod := findObject(objects, func(p *corev1.Pod) bool { return p.Name == "test-statefulset-manifest" })
+	require.NotNil(t, statefulSetPod)
+	assert.Equal(t, "StatefulSet", statefulSetPod.OwnerReferences[0].Kind)
+	assert.Equal(t, "test-statefulset", statefulSetPod.OwnerReferences[0].Name)
+}
+
+func TestLoadManifestsErrors(t *testing.T) {
+	_, err := LoadManifests(filepath.Join(t.TempDir(), "missing"), "defaultns")
+	assert.Error(t, err)
+
+	badFile := filepath.Join(t.TempDir(), "bad.yaml")
+	require.NoError(t, os.WriteFile(badFile, []byte("kind: [unclosed"), 0o600))
+	_, err = LoadManifests(badFile, "defaultns")
+	assert.Error(t, err)
+}
+
+func TestDoManifestDiscover(t *testing.T) {
+	defer clientsholder.ClearTestClientsHolder()
+
+	config := &configuration.TestConfiguration{
+		TargetNameSpaces:    []configuration.Namespace{{Name: "tnf"}},
+		PodsUnderTestLabels: []string{"redhat-best-practices-for-k8s.com/generic: target"},
+		PartnerName:         "partner",
+	}
+
+	data, err := DoManifestDiscover(config, writeTestManifests(t))
+	require.NoError(t, err)
+
+	assert.True(t, clientsholder.GetClientsHolder().IsOffline())
+	assert.Equal(t, []string{"tnf"}, data.Namespaces)
+	assert.ElementsMatch(t, []string{"tnf"}, data.AllNamespaces)
+	require.Len(t, data.Pods, 1)
+	assert.Equal(t, "test-deployment-manifest", data.Pods[0].Name)
+	assert.Len(t, data.AllPods, 1)
+	require.Len(t, data.Deployments, 1)
+	assert.Equal(t, "test-deployment", data.Deployments[0].Name)
+	assert.Len(t, data.ServiceAccounts, 1)
+	assert.Equal(t, NonOpenshiftClusterVersion, data.OpenshiftVersion)
+	assert.Equal(t, "partner", data.PartnerName)
+}
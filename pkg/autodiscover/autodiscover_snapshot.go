@@ -0,0 +1,298 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package autodiscover
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/versions"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// SnapshotFormatVersion is the version of the snapshot archive layout. Snapshots with a
+	// different version can't be loaded.
+	SnapshotFormatVersion = "v1"
+
+	snapshotMetadataFile    = "metadata.json"
+	snapshotDiscoveryFile   = "discovery.json"
+	snapshotExecRecordsFile = "exec.json"
+	snapshotFilePerm        = 0o600
+)
+
+// SnapshotMetadata describes how and when a snapshot was taken.
+type SnapshotMetadata struct {
+	FormatVersion    string    `json:"formatVersion"`
+	CertsuiteVersion string    `json:"certsuiteVersion"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// Snapshot holds everything needed to replay a test run without the original cluster: the
+// discovered data and the output of the commands exec'ed in the containers and probe pods.
+type Snapshot struct {
+	Metadata    SnapshotMetadata
+	Data        DiscoveredTestData
+	ExecRecords []clientsholder.ExecRecord
+}
+
+// csvPods is the serializable version of an entry of DiscoveredTestData.CSVToPodListMap, as
+// json can't encode maps with struct keys.
+type csvPods struct {
+	CSV  types.NamespacedName
+	Pods []*corev1.Pod
+}
+
+// snapshotDiscoveredData shadows the fields of DiscoveredTestData that can't be serialized as is.
+type snapshotDiscoveredData struct {
+	DiscoveredTestData
+	CSVToPodListMap []csvPods
+}
+
+// SaveSnapshot writes the discovered data and the exec records into a tar.gz snapshot archive.
+// Credentials (Connect API key and collector password) are not saved.
+func SaveSnapshot(snapshotFile string, discoveredData *DiscoveredTestData, execRecords []clientsholder.ExecRecord) error {
+	metadata := SnapshotMetadata{
+		FormatVersion:    SnapshotFormatVersion,
+		CertsuiteVersion: versions.GitVersion(),
+		CreatedAt:        time.Now().UTC(),
+	}
+
+	snapshotData := snapshotDiscoveredData{DiscoveredTestData: *discoveredData}
+	snapshotData.Env.ConnectAPIKey = ""
+	snapshotData.CollectorAppPassword = ""
+	snapshotData.ConnectAPIKey = ""
+	for csv, pods := range discoveredData.CSVToPodListMap {
+		snapshotData.CSVToPodListMap = append(snapshotData.CSVToPodListMap, csvPods{CSV: csv, Pods: pods})
+	}
+
+	if execRecords == nil {
+		execRecords = []clientsholder.ExecRecord{}
+	}
+
+	entries := []struct {
+		name    string
+		content any
+	}{
+		{snapshotMetadataFile, metadata},
+		{snapshotDiscoveryFile, snapshotData},
+		{snapshotExecRecordsFile, execRecords},
+	}
+
+	file, err := os.OpenFile(snapshotFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, snapshotFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file %s: %w", snapshotFile, err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, entry := range entries {
+		content, err := json.Marshal(entry.content)
+		if err != nil {
+			return fmt.Errorf("failed to marshal snapshot %s: %w", entry.name, err)
+		}
+
+		header := &tar.Header{Name: entry.name, Mode: snapshotFilePerm, Size: int64(len(content)), ModTime: metadata.CreatedAt}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write tar header for %s: %w", entry.name, err)
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			return fmt.Errorf("failed to write %s to snapshot: %w", entry.name, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot tar writer: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot gzip writer: %w", err)
+	}
+
+	log.Info("Discovery snapshot saved to %s (%d exec records)", snapshotFile, len(execRecords))
+	return nil
+}
+
+// LoadSnapshot reads a snapshot archive created by SaveSnapshot.
+func LoadSnapshot(snapshotFile string) (*Snapshot, error) {
+	file, err := os.Open(snapshotFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file %s: %w", snapshotFile, err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file %s: %w", snapshotFile, err)
+	}
+	defer gzipReader.Close()
+
+	snapshot := Snapshot{}
+	snapshotData := snapshotDiscoveredData{}
+	found := map[string]bool{}
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot file %s: %w", snapshotFile, err)
+		}
+
+		var dest any
+		switch header.Name {
+		case snapshotMetadataFile:
+			dest = &snapshot.Metadata
+		case snapshotDiscoveryFile:
+			dest = &snapshotData
+		case snapshotExecRecordsFile:
+			dest = &snapshot.ExecRecords
+		default:
+			log.Warn("Ignoring unexpected file %s in snapshot %s", header.Name, snapshotFile)
+			continue
+		}
+
+		if err := json.NewDecoder(tarReader).Decode(dest); err != nil {
+			return nil, fmt.Errorf("failed to decode %s from snapshot %s: %w", header.Name, snapshotFile, err)
+		}
+		found[header.Name] = true
+
+		// Check the version asap so the rest of the files are not decoded with the wrong layout.
+		if header.Name == snapshotMetadataFile && snapshot.Metadata.FormatVersion != SnapshotFormatVersion {
+			return nil, fmt.Errorf("unsupported snapshot format version %q in %s (supported: %q)",
+				snapshot.Metadata.FormatVersion, snapshotFile, SnapshotFormatVersion)
+		}
+	}
+
+	for _, name := range []string{snapshotMetadataFile, snapshotDiscoveryFile, snapshotExecRecordsFile} {
+		if !found[name] {
+			return nil, fmt.Errorf("snapshot %s is missing %s", snapshotFile, name)
+		}
+	}
+
+	snapshot.Data = snapshotData.DiscoveredTestData
+	snapshot.Data.CSVToPodListMap = map[types.NamespacedName][]*corev1.Pod{}
+	for _, entry := range snapshotData.CSVToPodListMap {
+		snapshot.Data.CSVToPodListMap[entry.CSV] = entry.Pods
+	}
+
+	return &snapshot, nil
+}
+
+// Objects returns the k8s objects of the snapshot's discovered data, so they can be loaded
+// into the in-memory clients used when replaying it.
+//
+//nolint:funlen
+func (snapshot *Snapshot) Objects() []runtime.Object {
+	objects := []runtime.Object{}
+	added := map[string]bool{}
+	add := func(obj runtime.Object) {
+		metaObj, ok := obj.(metav1.Object)
+		if !ok {
+			return
+		}
+		key := fmt.Sprintf("%T/%s/%s", obj, metaObj.GetNamespace(), metaObj.GetName())
+		if added[key] {
+			return
+		}
+		added[key] = true
+		objects = append(objects, obj)
+	}
+
+	data := &snapshot.Data
+	for _, ns := range data.AllNamespaces {
+		add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}})
+	}
+	if data.Nodes != nil {
+		for i := range data.Nodes.Items {
+			add(&data.Nodes.Items[i])
+		}
+	}
+	for _, pods := range [][]corev1.Pod{data.AllPods, data.Pods, data.ProbePods} {
+		for i := range pods {
+			add(&pods[i])
+		}
+	}
+	for _, pod := range data.OperandPods {
+		add(pod)
+	}
+	for _, pods := range data.CSVToPodListMap {
+		for _, pod := range pods {
+			add(pod)
+		}
+	}
+	for i := range data.Deployments {
+		add(&data.Deployments[i])
+	}
+	for i := range data.StatefulSet {
+		add(&data.StatefulSet[i])
+	}
+	for _, hpa := range data.Hpas {
+		add(hpa)
+	}
+	for i := range data.ResourceQuotaItems {
+		add(&data.ResourceQuotaItems[i])
+	}
+	for i := range data.PodDisruptionBudgets {
+		add(&data.PodDisruptionBudgets[i])
+	}
+	for i := range data.NetworkPolicies {
+		add(&data.NetworkPolicies[i])
+	}
+	for _, crd := range data.AllCrds {
+		add(crd)
+	}
+	for _, csv := range data.AllCsvs {
+		add(csv)
+	}
+	for i := range data.AllSubscriptions {
+		add(&data.AllSubscriptions[i])
+	}
+	for _, installPlan := range data.AllInstallPlans {
+		add(installPlan)
+	}
+	for _, catalogSource := range data.AllCatalogSources {
+		add(catalogSource)
+	}
+	for i := range data.NetworkAttachmentDefinitions {
+		add(&data.NetworkAttachmentDefinitions[i])
+	}
+	for i := range data.PersistentVolumes {
+		add(&data.PersistentVolumes[i])
+	}
+	for i := range data.PersistentVolumeClaims {
+		add(&data.PersistentVolumeClaims[i])
+	}
+	for i := range data.ClusterRoleBindings {
+		add(&data.ClusterRoleBindings[i])
+	}
+	for i := range data.RoleBindings {
+		add(&data.RoleBindings[i])
+	}
+	for i := range data.Roles {
+		add(&data.Roles[i])
+	}
+	for _, service := range data.AllServices {
+		add(service)
+	}
+	for _, serviceAccount := range data.AllServiceAccounts {
+		add(serviceAccount)
+	}
+	for i := range data.StorageClasses {
+		add(&data.StorageClasses[i])
+	}
+
+	return objects
+}
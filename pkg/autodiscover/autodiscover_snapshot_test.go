@@ -0,0 +1,120 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package autodiscover
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newSnapshotTestData() *DiscoveredTestData {
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "tnf"}}
+	operatorPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "operator-pod", Namespace: "tnf"}}
+
+	return &DiscoveredTestData{
+		Env:           configuration.TestParameters{LabelsFilter: "all", ConnectAPIKey: "secret-key"},
+		Namespaces:    []string{"tnf"},
+		AllNamespaces: []string{"tnf", "other"},
+		Pods:          []corev1.Pod{pod},
+		AllPods:       []corev1.Pod{pod},
+		ProbePods:     []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "probe-1", Namespace: "certsuite"}, Spec: corev1.PodSpec{NodeName: "node1"}}},
+		CSVToPodListMap: map[types.NamespacedName][]*corev1.Pod{
+			{Namespace: "tnf", Name: "operator.v1.0.0"}: {operatorPod},
+		},
+		Deployments:          []appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Name: "dep1", Namespace: "tnf"}}},
+		Nodes:                &corev1.NodeList{Items: []corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}}},
+		OpenshiftVersion:     "4.16.0",
+		PartnerName:          "partner",
+		CollectorAppPassword: "secret-password",
+		ConnectAPIKey:        "secret-key",
+	}
+}
+
+func TestSaveAndLoadSnapshot(t *testing.T) {
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	execRecords := []clientsholder.ExecRecord{
+		{Namespace: "certsuite", Pod: "probe-1", Container: "container-00", Command: "uname -r", Stdout: "5.14.0\n", ExitCode: -1},
+	}
+
+	require.NoError(t, SaveSnapshot(snapshotFile, newSnapshotTestData(), execRecords))
+
+	snapshot, err := LoadSnapshot(snapshotFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, SnapshotFormatVersion, snapshot.Metadata.FormatVersion)
+	assert.False(t, snapshot.Metadata.CreatedAt.IsZero())
+	assert.Equal(t, execRecords, snapshot.ExecRecords)
+
+	data := snapshot.Data
+	assert.Equal(t, []string{"tnf"}, data.Namespaces)
+	assert.Equal(t, "4.16.0", data.OpenshiftVersion)
+	assert.Equal(t, "partner", data.PartnerName)
+	assert.Equal(t, "all", data.Env.LabelsFilter)
+	require.Len(t, data.Pods, 1)
+	assert.Equal(t, "pod1", data.Pods[0].Name)
+	require.Len(t, data.Nodes.Items, 1)
+	require.Len(t, data.CSVToPodListMap, 1)
+	csvPods := data.CSVToPodListMap[types.NamespacedName{Namespace: "tnf", Name: "operator.v1.0.0"}]
+	require.Len(t, csvPods, 1)
+	assert.Equal(t, "operator-pod", csvPods[0].Name)
+
+	// Credentials are never saved.
+	assert.Empty(t, data.Env.ConnectAPIKey)
+	assert.Empty(t, data.CollectorAppPassword)
+	assert.Empty(t, data.ConnectAPIKey)
+}
+
+func TestLoadSnapshotErrors(t *testing.T) {
+	_, err := LoadSnapshot(filepath.Join(t.TempDir(), "missing.tar.gz"))
+	assert.Error(t, err)
+
+	notGzipFile := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	require.NoError(t, os.WriteFile(notGzipFile, []byte("not a snapshot"), 0o600))
+	_, err = LoadSnapshot(notGzipFile)
+	assert.Error(t, err)
+
+	// Snapshot with an unsupported format version.
+	badVersionFile := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	f, err := os.Create(badVersionFile)
+	require.NoError(t, err)
+	gzipWriter := gzip.NewWriter(f)
+	tarWriter := tar.NewWriter(gzipWriter)
+	content, err := json.Marshal(SnapshotMetadata{FormatVersion: "v0"})
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: snapshotMetadataFile, Mode: 0o600, Size: int64(len(content))}))
+	_, err = tarWriter.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, f.Close())
+
+	_, err = LoadSnapshot(badVersionFile)
+	assert.ErrorContains(t, err, "unsupported snapshot format version")
+}
+
+func TestSnapshotObjects(t *testing.T) {
+	snapshot := Snapshot{Data: *newSnapshotTestData()}
+
+	objects := snapshot.Objects()
+
+	// 2 namespaces, 1 node, 3 pods (pod1 is both in Pods and AllPods) and 1 deployment.
+	assert.Len(t, objects, 7)
+
+	defer clientsholder.ClearTestClientsHolder()
+	oc := clientsholder.SetReplayClientsHolder(objects, nil)
+	pods, err := oc.K8sClient.CoreV1().Pods("tnf").List(t.Context(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 2)
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2026 Red Hat, Inc.
+
+// Package baseline compares the test cases results of a claim with the ones of a baseline claim, to
+// find the regressions. It's used by the claim compare command and the web server run history.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+)
+
+// Classifications of the test cases results against the baseline's ones.
+const (
+	NewFailure   = "new-failure"
+	Fixed        = "fixed"
+	StillFailing = "still-failing"
+	NewlySkipped = "newly-skipped"
+)
+
+const (
+	tcNotInClaim = "not found"
+	noneText     = "<none>"
+)
+
+// TcBaselineDifference is a test case whose result is different from the baseline's one, or that
+// is still failing, with the non-compliant objects that are new or fixed since the baseline.
+type TcBaselineDifference struct {
+	Name                     string   `json:"name"`
+	Classification           string   `json:"classification"`
+	BaselineResult           string   `json:"baselineResult"`
+	Result                   string   `json:"result"`
+	NewNonCompliantObjects   []string `json:"newNonCompliantObjects"`
+	FixedNonCompliantObjects []string `json:"fixedNonCompliantObjects"`
+	Regression               bool     `json:"regression"`
+}
+
+// Report holds the test cases whose result is different from the baseline's one, or still failing.
+type Report struct {
+	TestCases   []TcBaselineDifference `json:"testCases"`
+	Regressions int                    `json:"regressions"`
+}
+
+func isFailure(state string) bool {
+	return state == claimhelper.TestStateFailed || state == claimhelper.TestStateError
+}
+
+// getNonCompliantObjects returns the names of the non-compliant objects in the check details of
+// the test case result, or an empty map if they could not be parsed.
+func getNonCompliantObjects(result *claim.Result) map[string]struct{} {
+	objects := map[string]struct{}{}
+
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(result.CheckDetails), &details); err != nil {
+		return objects
+	}
+
+	for _, obj := range details.NonCompliantObjectsOut {
+		if obj != nil {
+			objects[claimhelper.ReportObjectName(obj)] = struct{}{}
+		}
+	}
+
+	return objects
+}
+
+// objectsOnlyIn returns the sorted names of the objects in objects1 that are not in objects2.
+func objectsOnlyIn(objects1, objects2 map[string]struct{}) []string {
+	names := []string{}
+	for name := range objects1 {
+		if _, found := objects2[name]; !found {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// classify returns the classification of the test case result against the baseline's one, or an
+// empty string if it did not change.
+func classify(baselineState, state string) string {
+	switch {
+	case isFailure(state) && isFailure(baselineState):
+		return StillFailing
+	case isFailure(state):
+		return NewFailure
+	case isFailure(baselineState) && strings.HasPrefix(state, claimhelper.TestStatePassed):
+		return Fixed
+	case state == claimhelper.TestStateSkipped && baselineState != claimhelper.TestStateSkipped && baselineState != tcNotInClaim:
+		return NewlySkipped
+	}
+
+	return ""
+}
+
+// GetReport compares the test cases results with the baseline's ones. A test case is a regression
+// if it fails now but did not in the baseline, or if it is still failing with non-compliant objects
+// that were compliant (or not there) in the baseline. Test cases that did not run are ignored. The
+// results are the ones of the claims, by test case ID.
+func GetReport(baselineResults, results map[string]claim.Result) *Report {
+	names := []string{}
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := Report{TestCases: []TcBaselineDifference{}}
+	for _, name := range names {
+		result := results[name]
+		baselineResult, found := baselineResults[name]
+		baselineState := baselineResult.State
+		if !found {
+			baselineState = tcNotInClaim
+		}
+
+		classification := classify(baselineState, result.State)
+		if classification == "" {
+			continue
+		}
+
+		baselineObjects := getNonCompliantObjects(&baselineResult)
+		objects := getNonCompliantObjects(&result)
+		diff := TcBaselineDifference{
+			Name:                     name,
+			Classification:           classification,
+			BaselineResult:           baselineState,
+			Result:                   result.State,
+			NewNonCompliantObjects:   objectsOnlyIn(objects, baselineObjects),
+			FixedNonCompliantObjects: objectsOnlyIn(baselineObjects, objects),
+		}
+		diff.Regression = classification == NewFailure || (classification == StillFailing && len(diff.NewNonCompliantObjects) > 0)
+		if diff.Regression {
+			report.Regressions++
+		}
+
+		report.TestCases = append(report.TestCases, diff)
+	}
+
+	return &report
+}
+
+// HasRegressions returns true if any test case regressed since the baseline.
+func (r *Report) HasRegressions() bool {
+	return r.Regressions > 0
+}
+
+// Stringer method for the Report. Will return a table with the test cases whose result changed since
+// the baseline, or still failing, followed by their new and fixed non-compliant objects:
+//
+//	TEST CASE NAME                                              CLASSIFICATION   BASELINE  RESULT    REGRESSION
+//	access-control-net-admin-capability-check                   still-failing    failed    failed    yes
+//	access-control-ssh-daemons                                  fixed            failed    passed    no
+//
+//	access-control-net-admin-capability-check
+//	  New non-compliant objects:
+//	    Container{Namespace=tnf, Pod Name=test-1, Container Name=test}
+//	  Fixed non-compliant objects:
+//	    <none>
+//
+//	Regressions: 1
+func (r *Report) String() string {
+	const tcBaselineRowFmt = "%-60s%-17s%-10s%-10s%-s\n"
+
+	str := "BASELINE COMPARISON\n"
+	str += "-------------------\n"
+	if len(r.TestCases) == 0 {
+		str += noneText + "\n"
+		return str
+	}
+
+	str += fmt.Sprintf(tcBaselineRowFmt, "TEST CASE NAME", "CLASSIFICATION", "BASELINE", "RESULT", "REGRESSION")
+	for _, diff := range r.TestCases {
+		regression := "no"
+		if diff.Regression {
+			regression = "yes"
+		}
+		str += fmt.Sprintf(tcBaselineRowFmt, diff.Name, diff.Classification, diff.BaselineResult, diff.Result, regression)
+	}
+
+	for _, diff := range r.TestCases {
+		if len(diff.NewNonCompliantObjects) == 0 && len(diff.FixedNonCompliantObjects) == 0 {
+			continue
+		}
+
+		str += "\n" + diff.Name + "\n"
+		str += "  New non-compliant objects:\n" + objectsList(diff.NewNonCompliantObjects)
+		str += "  Fixed non-compliant objects:\n" + objectsList(diff.FixedNonCompliantObjects)
+	}
+
+	str += fmt.Sprintf("\nRegressions: %d\n", r.Regressions)
+	return str
+}
+
+func objectsList(names []string) string {
+	if len(names) == 0 {
+		return "    " + noneText + "\n"
+	}
+
+	str := ""
+	for _, name := range names {
+		str += "    " + name + "\n"
+	}
+
+	return str
+}
@@ -0,0 +1,163 @@
+package baseline
+
+import (
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCaseResult(t *testing.T, id, state string, nonCompliantPods ...string) claim.Result {
+	t.Helper()
+
+	result := claim.Result{State: state, TestID: &claim.Identifier{Id: id}}
+	if len(nonCompliantPods) > 0 {
+		objects := []*testhelper.ReportObject{}
+		for _, pod := range nonCompliantPods {
+			objects = append(objects, testhelper.NewPodReportObject("tnf", pod, "Non compliant in "+state+" run", false))
+		}
+		checkDetails, err := testhelper.ResultObjectsToString(nil, objects, nil)
+		require.NoError(t, err)
+		result.CheckDetails = checkDetails
+	}
+
+	return result
+}
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		baselineState          string
+		state                  string
+		expectedClassification string
+	}{
+		{baselineState: "passed", state: "failed", expectedClassification: NewFailure},
+		{baselineState: tcNotInClaim, state: "error", expectedClassification: NewFailure},
+		{baselineState: "skipped", state: "failed", expectedClassification: NewFailure},
+		{baselineState: "failed", state: "passed-with-waivers", expectedClassification: Fixed},
+		{baselineState: "error", state: "failed", expectedClassification: StillFailing},
+		{baselineState: "passed", state: "skipped", expectedClassification: NewlySkipped},
+		{baselineState: "failed", state: "skipped", expectedClassification: NewlySkipped},
+		{baselineState: tcNotInClaim, state: "skipped", expectedClassification: ""},
+		{baselineState: "passed", state: "passed-after-retry", expectedClassification: ""},
+		{baselineState: "skipped", state: "skipped", expectedClassification: ""},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedClassification, classify(tc.baselineState, tc.state), "%s -> %s", tc.baselineState, tc.state)
+	}
+}
+
+func TestGetReport(t *testing.T) {
+	baselineResults := map[string]claim.Result{
+		"access-control-net-admin-capability-check": newTestCaseResult(t, "access-control-net-admin-capability-check", "failed", "test-0", "test-1"),
+		"access-control-ssh-daemons":                newTestCaseResult(t, "access-control-ssh-daemons", "failed", "test-0"),
+		"lifecycle-pod-owner-type":                  newTestCaseResult(t, "lifecycle-pod-owner-type", "failed", "test-0"),
+		"lifecycle-pod-scheduling":                  newTestCaseResult(t, "lifecycle-pod-scheduling", "passed"),
+		"observability-crd-status":                  newTestCaseResult(t, "observability-crd-status", "passed"),
+		"removed-test-case":                         newTestCaseResult(t, "removed-test-case", "failed", "test-0"),
+	}
+	results := map[string]claim.Result{
+		// Same non-compliant objects, with a different reason.
+		"access-control-net-admin-capability-check": newTestCaseResult(t, "access-control-net-admin-capability-check", "failed", "test-1", "test-0"),
+		"access-control-ssh-daemons":                newTestCaseResult(t, "access-control-ssh-daemons", "passed"),
+		"lifecycle-pod-owner-type":                  newTestCaseResult(t, "lifecycle-pod-owner-type", "failed", "test-1"),
+		"lifecycle-pod-scheduling":                  newTestCaseResult(t, "lifecycle-pod-scheduling", "failed", "test-0"),
+		"observability-crd-status":                  newTestCaseResult(t, "observability-crd-status", "skipped"),
+	}
+
+	report := GetReport(baselineResults, results)
+	assert.Equal(t, &Report{
+		TestCases: []TcBaselineDifference{
+			{
+				Name:                     "access-control-net-admin-capability-check",
+				Classification:           StillFailing,
+				BaselineResult:           "failed",
+				Result:                   "failed",
+				NewNonCompliantObjects:   []string{},
+				FixedNonCompliantObjects: []string{},
+			},
+			{
+				Name:                     "access-control-ssh-daemons",
+				Classification:           Fixed,
+				BaselineResult:           "failed",
+				Result:                   "passed",
+				NewNonCompliantObjects:   []string{},
+				FixedNonCompliantObjects: []string{"Pod{Namespace=tnf, Pod Name=test-0}"},
+			},
+			{
+				Name:                     "lifecycle-pod-owner-type",
+				Classification:           StillFailing,
+				BaselineResult:           "failed",
+				Result:                   "failed",
+				NewNonCompliantObjects:   []string{"Pod{Namespace=tnf, Pod Name=test-1}"},
+				FixedNonCompliantObjects: []string{"Pod{Namespace=tnf, Pod Name=test-0}"},
+				Regression:               true,
+			},
+			{
+				Name:                     "lifecycle-pod-scheduling",
+				Classification:           NewFailure,
+				BaselineResult:           "passed",
+				Result:                   "failed",
+				NewNonCompliantObjects:   []string{"Pod{Namespace=tnf, Pod Name=test-0}"},
+				FixedNonCompliantObjects: []string{},
+				Regression:               true,
+			},
+			{
+				Name:                     "observability-crd-status",
+				Classification:           NewlySkipped,
+				BaselineResult:           "passed",
+				Result:                   "skipped",
+				NewNonCompliantObjects:   []string{},
+				FixedNonCompliantObjects: []string{},
+			},
+		},
+		Regressions: 2,
+	}, report)
+	assert.True(t, report.HasRegressions())
+
+	// Only the still failing test cases with the same non-compliant objects.
+	delete(results, "lifecycle-pod-owner-type")
+	delete(results, "lifecycle-pod-scheduling")
+	assert.False(t, GetReport(baselineResults, results).HasRegressions())
+}
+
+func TestReportString(t *testing.T) {
+	assert.Equal(t, "BASELINE COMPARISON\n-------------------\n<none>\n", (&Report{}).String())
+
+	report := Report{
+		TestCases: []TcBaselineDifference{
+			{
+				Name:                     "lifecycle-pod-owner-type",
+				Classification:           StillFailing,
+				BaselineResult:           "failed",
+				Result:                   "failed",
+				NewNonCompliantObjects:   []string{"Pod{Namespace=tnf, Pod Name=test-1}"},
+				FixedNonCompliantObjects: []string{},
+				Regression:               true,
+			},
+			{
+				Name:           "observability-crd-status",
+				Classification: NewlySkipped,
+				BaselineResult: "passed",
+				Result:         "skipped",
+			},
+		},
+		Regressions: 1,
+	}
+
+	assert.Equal(t, "BASELINE COMPARISON\n"+
+		"-------------------\n"+
+		"TEST CASE NAME                                              CLASSIFICATION   BASELINE  RESULT    REGRESSION\n"+
+		"lifecycle-pod-owner-type                                    still-failing    failed    failed    yes\n"+
+		"observability-crd-status                                    newly-skipped    passed    skipped   no\n"+
+		"\n"+
+		"lifecycle-pod-owner-type\n"+
+		"  New non-compliant objects:\n"+
+		"    Pod{Namespace=tnf, Pod Name=test-1}\n"+
+		"  Fixed non-compliant objects:\n"+
+		"    <none>\n"+
+		"\n"+
+		"Regressions: 1\n", report.String())
+}
@@ -2,6 +2,8 @@
 package certsuite
 
 import (
+	"crypto"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +14,7 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/results"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/attestation"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/autodiscover"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/claimhelper"
@@ -54,12 +57,30 @@ func LoadChecksDB(labelsExpr string) {
 
 const (
 	junitXMLOutputFileName = "certsuite-tests_junit.xml"
+	sarifOutputFileName    = "certsuite.sarif"
+	summaryOutputFileName  = "certsuite-summary.md"
 	claimFileName          = "claim.json"
+	attestationFileName    = "certsuite-attestation" + attestation.FileSuffix
+	snapshotFileName       = "discovery-snapshot.tar.gz"
+	checkpointFileName     = "checkpoint.jsonl"
+	eventsFileName         = "certsuite-events.jsonl"
 	collectorAppURL        = "http://claims-collector.cnf-certifications.sysdeseng.com"
 	timeoutDefaultvalue    = 24 * time.Hour
 	noLabelsFilterExpr     = "none"
 )
 
+// ErrMandatoryTestCasesFailed is returned by Run when mandatory test cases of the --fail-on category
+// failed, once all the output artifacts are created.
+var ErrMandatoryTestCasesFailed = errors.New("mandatory test cases failed")
+
+// setUnavailableRequirements flags the capabilities the checks can't use in the environment under
+// test, so the checks that need them are skipped instead of failing.
+func setUnavailableRequirements(env *provider.TestEnvironment) {
+	if env.ManifestMode {
+		checksdb.SetRequirementsUnavailable("manifest mode (no live cluster)", checksdb.RequireProbe, checksdb.RequireExec)
+	}
+}
+
 func getK8sClientsConfigFileNames() []string {
 	params := configuration.GetTestParameters()
 	fileNames := []string{}
@@ -92,7 +113,12 @@ func Startup() {
 		os.Exit(1)
 	}
 
-	if err := log.CreateGlobalLogFile(testParams.OutputDir, testParams.LogLevel); err != nil {
+	// The logs of the run being resumed are kept.
+	createLogFile := log.CreateGlobalLogFile
+	if testParams.Resume {
+		createLogFile = log.AppendGlobalLogFile
+	}
+	if err := createLogFile(testParams.OutputDir, testParams.LogLevel); err != nil {
 		fmt.Fprintf(os.Stderr, "Could not create the log file, err: %v\n", err)
 		os.Exit(1)
 	}
@@ -102,8 +128,19 @@ func Startup() {
 		log.Warn("The Best Practices Test Suite will run in diagnostic mode so no test case will be launched")
 	}
 
-	// Set clientsholder singleton with the filenames from the env vars.
-	_ = clientsholder.GetClientsHolder(getK8sClientsConfigFileNames()...)
+	// Set clientsholder singleton with the filenames from the env vars. In manifest and snapshot
+	// modes there is no cluster: the offline clientsholder is set up during the discovery.
+	switch {
+	case testParams.ManifestsDir != "":
+		log.Warn("Running in manifest mode from %q: checks that need a live cluster will be skipped", testParams.ManifestsDir)
+	case testParams.FromSnapshot != "":
+		log.Warn("Replaying discovery snapshot %q: no live cluster will be queried", testParams.FromSnapshot)
+	default:
+		oc := clientsholder.GetClientsHolder(getK8sClientsConfigFileNames()...)
+		if testParams.CreateSnapshot {
+			oc.EnableExecRecording()
+		}
+	}
 	LoadChecksDB(testParams.LabelsFilter)
 
 	log.Info("Certsuite Version: %v", versions.GitVersion())
@@ -133,10 +170,62 @@ func Shutdown() {
 func Run(labelsFilter, outputFolder string) error {
 	testParams := configuration.GetTestParameters()
 
+	if testParams.GroupsOrder != "" {
+		if err := checksdb.SetGroupsOrder(strings.Split(testParams.GroupsOrder, ",")); err != nil {
+			return fmt.Errorf("invalid test suites order %q: %w", testParams.GroupsOrder, err)
+		}
+	}
+
+	resumedChecks, err := checksdb.OpenCheckpoint(filepath.Join(outputFolder, checkpointFileName), testParams.Resume)
+	if err != nil {
+		return fmt.Errorf("could not set up the checks checkpoint: %w", err)
+	}
+	defer func() {
+		if err := checksdb.CloseCheckpoint(); err != nil {
+			log.Error("Could not close the checks checkpoint: %v", err)
+		}
+	}()
+
+	eventsFile := filepath.Join(outputFolder, eventsFileName)
+	if err := checksdb.OpenEventsFile(eventsFile); err != nil {
+		return fmt.Errorf("could not set up the run events: %w", err)
+	}
+	defer func() {
+		if err := checksdb.CloseEventsFile(); err != nil {
+			log.Error("Could not close the run events file: %v", err)
+		}
+	}()
+	checksdb.EmitRunStarted(labelsFilter)
+
+	if testParams.Resume {
+		log.Info("Resuming the run in %s, %d checks already completed", outputFolder, resumedChecks)
+		fmt.Printf("Resuming the run in %s, %d checks already completed\n\n", outputFolder, resumedChecks)
+	}
+
 	fmt.Println("Running discovery of CNF target resources...")
 	fmt.Print("\n")
 
 	env := provider.GetTestEnvironment()
+	checksdb.EmitDiscoveryFinished(getDiscoveredObjectsCount(&env))
+
+	setUnavailableRequirements(&env)
+
+	checksdb.SetGroupsConcurrency(testParams.GroupsConcurrency)
+	checksdb.SetDefaultCheckTimeout(testParams.CheckTimeout)
+
+	retryPolicies := map[string]checksdb.RetryPolicy{}
+	for _, policy := range env.Config.CheckRetries {
+		retryPolicies[policy.ID] = checksdb.RetryPolicy{Retries: policy.Retries, Backoff: policy.Backoff}
+	}
+	checksdb.SetRetryPolicyOverrides(retryPolicies)
+
+	waivers, err := getWaivers(env.Config.Waivers)
+	if err != nil {
+		return fmt.Errorf("invalid waivers: %w", err)
+	}
+	if err := checksdb.SetWaivers(waivers); err != nil {
+		return fmt.Errorf("invalid waivers: %w", err)
+	}
 
 	log.Info("Running checks matching labels expr %q with timeout %v", labelsFilter, testParams.Timeout)
 	startTime := time.Now()
@@ -149,37 +238,94 @@ func Run(labelsFilter, outputFolder string) error {
 
 	claimOutputFile := filepath.Join(outputFolder, claimFileName)
 
-	oc := clientsholder.GetClientsHolder()
-	_, allPods := autodiscover.FindPodsByLabels(oc.K8sClient.CoreV1(), autodiscover.CreateLabels(env.Config.PodsUnderTestLabels), env.Namespaces)
-	env.PodStates.AfterExecution = autodiscover.CountPodsByStatus(allPods)
+	if !env.IsLiveCluster() {
+		// Nothing could have changed the pods loaded from the manifests or snapshot.
+		env.PodStates.AfterExecution = env.PodStates.BeforeExecution
+	} else {
+		oc := clientsholder.GetClientsHolder()
+		_, allPods := autodiscover.FindPodsByLabels(oc.K8sClient.CoreV1(), autodiscover.CreateLabels(env.Config.PodsUnderTestLabels), env.Namespaces)
+		env.PodStates.AfterExecution = autodiscover.CountPodsByStatus(allPods)
+	}
 	if env.PodStates.BeforeExecution["ready"] != env.PodStates.AfterExecution["ready"] {
 		log.Warn("Some pods were not ready during entire test execution. See %s podStates section for more details", claimOutputFile)
 	}
 
+	if testParams.CreateSnapshot {
+		snapshotFile := filepath.Join(outputFolder, snapshotFileName)
+		if err := provider.SaveDiscoverySnapshot(snapshotFile); err != nil {
+			log.Error("Failed to save the discovery snapshot: %v", err)
+		}
+	}
+
 	claimBuilder, err := claimhelper.NewClaimBuilder(&env)
 	if err != nil {
 		log.Fatal("Failed to get claim builder: %v", err)
 	}
 
+	if testParams.RerunFailedClaim != "" && testParams.MergeResults {
+		log.Info("Merging the results into a copy of claim file %s", testParams.RerunFailedClaim)
+		if err := claimBuilder.MergeIntoClaim(testParams.RerunFailedClaim); err != nil {
+			return fmt.Errorf("failed to merge the results into claim file %s: %w", testParams.RerunFailedClaim, err)
+		}
+	}
+
 	if failedCtr > 0 {
 		log.Warn("Some checks failed. See %s for details", claimOutputFile)
 	}
 
 	// Marshal the claim and output to file
+	claimBuilder.SetFailOn(testParams.FailOn)
 	claimBuilder.Build(claimOutputFile)
+	claimhelper.PrintVerdict(claimBuilder.GetVerdict())
+
+	if configuration.GetTestParameters().SanitizeClaim {
+		claimOutputFile, err = claimhelper.SanitizeClaimFile(claimOutputFile, configuration.GetTestParameters().LabelsFilter)
+		if err != nil {
+			log.Error("Failed to sanitize claim file: %v", err)
+		}
+	}
+
+	// Redact the claim file in place before creating any artifact from the claim, so they are all
+	// redacted.
+	if configuration.GetTestParameters().RedactProfile != "" {
+		redactionProfile, err := claimhelper.LoadRedactionProfile(configuration.GetTestParameters().RedactProfile)
+		if err != nil {
+			return fmt.Errorf("failed to load the redaction profile: %w", err)
+		}
+
+		if err := redactionProfile.SetSalt(configuration.GetTestParameters().RedactSalt); err != nil {
+			return fmt.Errorf("failed to set the redaction salt: %w", err)
+		}
+
+		err = claimBuilder.Redact(claimOutputFile, redactionProfile)
+		if err != nil {
+			return fmt.Errorf("failed to redact claim file: %w", err)
+		}
+	}
 
 	// Create JUnit file if required
 	if configuration.GetTestParameters().EnableXMLCreation {
 		junitOutputFileName := filepath.Join(outputFolder, junitXMLOutputFileName)
 		log.Info("JUnit XML file creation is enabled. Creating JUnit XML file: %s", junitOutputFileName)
-		claimBuilder.ToJUnitXML(junitOutputFileName, startTime, endTime)
+		if configuration.GetTestParameters().JUnitPerObject {
+			claimBuilder.ToJUnitXMLPerObject(junitOutputFileName, startTime, endTime)
+		} else {
+			claimBuilder.ToJUnitXML(junitOutputFileName, startTime, endTime)
+		}
 	}
 
-	if configuration.GetTestParameters().SanitizeClaim {
-		claimOutputFile, err = claimhelper.SanitizeClaimFile(claimOutputFile, configuration.GetTestParameters().LabelsFilter)
-		if err != nil {
-			log.Error("Failed to sanitize claim file: %v", err)
-		}
+	// Create SARIF file if required
+	if configuration.GetTestParameters().EnableSARIFCreation {
+		sarifOutputFile := filepath.Join(outputFolder, sarifOutputFileName)
+		log.Info("SARIF file creation is enabled. Creating SARIF file: %s", sarifOutputFile)
+		claimBuilder.ToSARIF(sarifOutputFile)
+	}
+
+	// Create markdown summary file if required
+	if configuration.GetTestParameters().EnableMarkdownSummary {
+		summaryOutputFile := filepath.Join(outputFolder, summaryOutputFileName)
+		log.Info("Markdown summary creation is enabled. Creating summary file: %s", summaryOutputFile)
+		claimBuilder.ToMarkdownSummary(summaryOutputFile, configuration.GetTestParameters().SummaryCategory, configuration.GetTestParameters().SummaryMaxSize)
 	}
 
 	// Send claim file to the collector if specified by env var
@@ -206,8 +352,55 @@ func Run(labelsFilter, outputFolder string) error {
 	// Add all the web artifacts file paths.
 	allArtifactsFilePaths = append(allArtifactsFilePaths, webFilePaths...)
 
-	// Add the log file path
-	allArtifactsFilePaths = append(allArtifactsFilePaths, filepath.Join(outputFolder, log.LogFileName))
+	// Create the standalone HTML report. Unlike the web files, it's kept in the output folder, as it
+	// needs no other file to be viewed.
+	htmlReportFilePath, err := results.CreateHTMLReport(resultsOutputDir, claimFileName)
+	if err != nil {
+		log.Error("Failed to create the HTML report: %v", err)
+	} else {
+		allArtifactsFilePaths = append(allArtifactsFilePaths, htmlReportFilePath)
+	}
+
+	// Sign the artifacts kept in the output folder, but the log file, which is still being written.
+	// The web files are removed once compressed unless kept, so they are signed only then. The
+	// attestation is added to the tar.gz, so the artifacts can be verified once extracted.
+	var signKey crypto.Signer
+	var attestationPredicate attestation.Predicate
+	if configuration.GetTestParameters().SignKeyFile != "" {
+		signKey, err = attestation.LoadPrivateKey(configuration.GetTestParameters().SignKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load the signing key: %w", err)
+		}
+
+		attestationPredicate, err = attestation.NewPredicate()
+		if err != nil {
+			return fmt.Errorf("failed to create the attestation: %w", err)
+		}
+
+		// The run events are all saved once the checks run, so the events file is closed to be signed.
+		if err := checksdb.CloseEventsFile(); err != nil {
+			return fmt.Errorf("failed to close the run events file: %w", err)
+		}
+
+		signedFilePaths := []string{filepath.Join(outputFolder, claimFileName), eventsFile}
+		if htmlReportFilePath != "" {
+			signedFilePaths = append(signedFilePaths, htmlReportFilePath)
+		}
+		if configuration.GetTestParameters().IncludeWebFilesInOutputFolder {
+			signedFilePaths = append(signedFilePaths, webFilePaths...)
+		}
+
+		attestationFilePath := filepath.Join(outputFolder, attestationFileName)
+		log.Info("Signing the results artifacts into %s", attestationFilePath)
+		err = attestation.SignFilesToFile(signKey, attestationFilePath, signedFilePaths, &attestationPredicate)
+		if err != nil {
+			return fmt.Errorf("failed to sign the results artifacts: %w", err)
+		}
+		allArtifactsFilePaths = append(allArtifactsFilePaths, attestationFilePath)
+	}
+
+	// Add the events and log file paths
+	allArtifactsFilePaths = append(allArtifactsFilePaths, eventsFile, filepath.Join(outputFolder, log.LogFileName))
 
 	// Override the env vars if they are not set.
 	if env.ConnectAPIKey == "" {
@@ -294,6 +487,16 @@ func Run(labelsFilter, outputFolder string) error {
 		}
 	}
 
+	// Sign the tar.gz file, as a whole, when it's kept.
+	if signKey != nil && zipFile != "" && !configuration.GetTestParameters().OmitArtifactsZipFile {
+		zipAttestationFilePath := zipFile + attestation.FileSuffix
+		log.Info("Signing the results artifacts file into %s", zipAttestationFilePath)
+		err = attestation.SignFilesToFile(signKey, zipAttestationFilePath, []string{zipFile}, &attestationPredicate)
+		if err != nil {
+			return fmt.Errorf("failed to sign the results artifacts file: %w", err)
+		}
+	}
+
 	// Remove web artifacts if user does not want them.
 	if !configuration.GetTestParameters().IncludeWebFilesInOutputFolder {
 		for _, file := range webFilePaths {
@@ -305,11 +508,51 @@ func Run(labelsFilter, outputFolder string) error {
 	}
 
 	// Cleanup probe daemonset if requested
-	if configuration.GetTestParameters().CleanupProbe {
+	if configuration.GetTestParameters().CleanupProbe && env.IsLiveCluster() {
 		if err := provider.CleanupProbeDaemonset(env.Config.ProbeDaemonSetNamespace); err != nil {
 			log.Error("Failed to cleanup probe daemonset: %v", err)
 		}
 	}
 
+	if claimBuilder.GetVerdict().Failed() {
+		return fmt.Errorf("%w in the %s category", ErrMandatoryTestCasesFailed, testParams.FailOn)
+	}
+
 	return nil
 }
+
+// getDiscoveredObjectsCount returns the number of objects under test found by the discovery, by kind.
+func getDiscoveredObjectsCount(env *provider.TestEnvironment) map[string]int {
+	return map[string]int{
+		"namespaces":   len(env.Namespaces),
+		"pods":         len(env.Pods),
+		"containers":   len(env.Containers),
+		"deployments":  len(env.Deployments),
+		"statefulSets": len(env.StatefulSets),
+		"operators":    len(env.Operators),
+		"crds":         len(env.Crds),
+		"helmCharts":   len(env.HelmChartReleases),
+		"nodes":        len(env.Nodes),
+	}
+}
+
+// getWaivers returns the checks' waivers set in the configuration.
+func getWaivers(configWaivers []configuration.Waiver) ([]checksdb.Waiver, error) {
+	waivers := []checksdb.Waiver{}
+	for i := range configWaivers {
+		expiresAt, err := configWaivers[i].ExpiresAt()
+		if err != nil {
+			return nil, fmt.Errorf("waiver %d (%s): %w", i, configWaivers[i].ID, err)
+		}
+
+		waivers = append(waivers, checksdb.Waiver{
+			ID:            configWaivers[i].ID,
+			ObjectType:    configWaivers[i].ObjectType,
+			Fields:        configWaivers[i].Fields,
+			Justification: configWaivers[i].Justification,
+			ExpiresAt:     expiresAt,
+		})
+	}
+
+	return waivers, nil
+}
@@ -1 +1,104 @@
 package certsuite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const manifestModeConfig = `
+targetNameSpaces:
+  - name: tnf
+podsUnderTestLabels:
+  - "redhat-best-practices-for-k8s.com/generic: target"
+`
+
+const manifestModeManifests = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-deployment
+  namespace: tnf
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+        redhat-best-practices-for-k8s.com/generic: target
+    spec:
+      containers:
+      - name: test-container
+        image: registry.example.com/test:1.0
+        ports:
+        - containerPort: 8080
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: test-service
+  namespace: tnf
+spec:
+  selector:
+    app: test
+  ports:
+  - port: 8080
+`
+
+func TestManifestModeCatalogNoExecErrors(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "certsuite_config.yml")
+	require.NoError(t, os.WriteFile(configFile, []byte(manifestModeConfig), 0o600))
+	manifestsDir := filepath.Join(dir, "manifests")
+	require.NoError(t, os.MkdirAll(manifestsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(manifestsDir, "workload.yaml"), []byte(manifestModeManifests), 0o600))
+
+	testParams := configuration.GetTestParameters()
+	savedParams := *testParams
+	testParams.ConfigFile = configFile
+	testParams.ManifestsDir = manifestsDir
+	defer func() {
+		*testParams = savedParams
+		configuration.ResetConfiguration()
+		provider.ResetTestEnvironment()
+		clientsholder.ClearTestClientsHolder()
+		checksdb.ResetChecksDB()
+	}()
+	configuration.ResetConfiguration()
+	provider.ResetTestEnvironment()
+	checksdb.ResetChecksDB()
+
+	env := provider.GetTestEnvironment()
+	require.True(t, env.ManifestMode)
+	require.NotEmpty(t, env.Containers)
+
+	LoadInternalChecksDB()
+	require.NoError(t, checksdb.InitLabelsExprEvaluator("all"))
+	setUnavailableRequirements(&env)
+
+	_, err := checksdb.RunChecks(time.Minute)
+	require.NoError(t, err)
+
+	// Without a cluster, exec'ing in the containers under test fails with ErrOffline and there
+	// are no probe pods to exec in.
+	execErrors := []string{clientsholder.ErrOffline.Error(), "probe pod not found"}
+	results := checksdb.GetResults()
+	require.NotEmpty(t, results)
+	for id, result := range results {
+		assert.NotEqual(t, checksdb.CheckResultError, result.State, "check %s errored: %s", id, result.SkipReason)
+		for _, execError := range execErrors {
+			assert.NotContains(t, result.CheckDetails+result.CapturedTestOutput, execError, "check %s tried to exec in manifest mode", id)
+		}
+	}
+}
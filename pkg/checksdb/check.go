@@ -1,12 +1,14 @@
 package checksdb
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
@@ -18,6 +20,10 @@ const (
 	CheckResultFailed  = "failed"
 	CheckResultError   = "error"
 	CheckResultAborted = "aborted"
+	// CheckResultPassedAfterRetry is set on checks that passed after failing at least once.
+	CheckResultPassedAfterRetry = "passed-after-retry"
+	// CheckResultPassedWithWaivers is set on checks whose non-compliant objects are all waived.
+	CheckResultPassedWithWaivers = "passed-with-waivers"
 )
 
 type skipMode int
@@ -43,6 +49,15 @@ type Check struct {
 
 	SkipCheckFns []func() (skip bool, reason string)
 	SkipMode     skipMode
+	Requirements []Requirement
+	// IDs of the checks that must run before this one.
+	RunAfter []string
+	// IDs of the checks that must not fail for this one to run.
+	DependsOn []string
+	// How many times the check runs again when it fails.
+	RetryPolicy RetryPolicy
+	// Intrusive checks modify the workloads under test, so they never run along with other checks.
+	Intrusive bool
 
 	Result         CheckResult
 	CapturedOutput string
@@ -50,12 +65,26 @@ type Check struct {
 	skipReason     string
 
 	logger     *log.Logger
-	logArchive *strings.Builder
+	logArchive *logArchive
 
 	StartTime, EndTime time.Time
 	Timeout            time.Duration
 	Error              error
 	abortChan          chan string
+	// Closed when the run is aborted.
+	stopChan <-chan bool
+	// Cancelled when the check times out, the run is aborted or the check functions return.
+	ctx context.Context
+	// Set once the check functions are abandoned after a timeout or an abort, as they may still
+	// be running: the results they set and the aborts they issue are ignored from then on.
+	finished bool
+	// Closed when the check functions of the last attempt return.
+	fnsDone <-chan struct{}
+
+	// Result got in the run being resumed, if the check completed then.
+	resumedResult *claim.Result
+	// Set once the event of the check's result is emitted, under its group's lock.
+	resultEmitted bool
 }
 
 func NewCheck(id string, labels []string) *Check {
@@ -63,7 +92,7 @@ func NewCheck(id string, labels []string) *Check {
 		ID:         id,
 		Labels:     labels,
 		Result:     CheckResultPassed,
-		logArchive: &strings.Builder{},
+		logArchive: &logArchive{},
 	}
 
 	check.logger = log.GetMultiLogger(check.logArchive, cli.CliCheckLogSniffer).With("check", check.ID)
@@ -77,7 +106,9 @@ func (check *Check) Abort(reason string) {
 
 	abortMsg := check.ID + " issued non-graceful abort: " + reason
 
-	check.abortChan <- abortMsg
+	if !check.finished {
+		check.abortChan <- abortMsg
+	}
 	panic(AbortPanicMsg(abortMsg))
 }
 
@@ -85,6 +116,12 @@ func (check *Check) SetAbortChan(abortChan chan string) {
 	check.abortChan = abortChan
 }
 
+// setStopChan sets the channel closed when the run is aborted, so the check stops waiting for its
+// functions.
+func (check *Check) setStopChan(stopChan <-chan bool) {
+	check.stopChan = stopChan
+}
+
 func (check *Check) LogDebug(msg string, args ...any) {
 	log.Logf(check.logger, log.LevelDebug, msg, args...)
 }
@@ -111,6 +148,27 @@ func (check *Check) GetLogs() string {
 	return check.logArchive.String()
 }
 
+// logArchive keeps the logs of a check, which may be written by its abandoned functions while
+// they are read.
+type logArchive struct {
+	mutex sync.Mutex
+	logs  strings.Builder
+}
+
+func (a *logArchive) Write(p []byte) (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.logs.Write(p)
+}
+
+func (a *logArchive) String() string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.logs.String()
+}
+
 func (check *Check) GetLogger() *log.Logger {
 	return check.logger
 }
@@ -152,6 +210,17 @@ func (check *Check) WithSkipCheckFn(skipCheckFn ...func() (skip bool, reason str
 	return check
 }
 
+// WithIntrusive flags the check as one that disrupts the workloads under test (scaling, pod
+// recreation, node cordoning...), so no other check will run at the same time.
+func (check *Check) WithIntrusive() *Check {
+	if check.Error != nil {
+		return check
+	}
+
+	check.Intrusive = true
+	return check
+}
+
 // This modifier is provided for the sake of completeness, but it's not necessary to use it,
 // as the SkipModeAny is the default skip mode.
 func (check *Check) WithSkipModeAny() *Check {
@@ -174,6 +243,8 @@ func (check *Check) WithSkipModeAll() *Check {
 	return check
 }
 
+// WithTimeout sets the maximum time the check can run. Once it expires, the check's context is
+// cancelled and its result is set as error. It overrides the default check timeout.
 func (check *Check) WithTimeout(duration time.Duration) *Check {
 	if check.Error != nil {
 		return check
@@ -188,11 +259,12 @@ func (check *Check) SetResult(compliantObjects, nonCompliantObjects []*testhelpe
 	check.mutex.Lock()
 	defer check.mutex.Unlock()
 
-	if check.Result == CheckResultAborted {
+	if check.Result == CheckResultAborted || check.finished {
 		return
 	}
 
-	resultObjectsStr, err := testhelper.ResultObjectsToString(compliantObjects, nonCompliantObjects)
+	nonCompliantObjects, waivedObjects := applyWaivers(check, nonCompliantObjects)
+	resultObjectsStr, err := testhelper.ResultObjectsToString(compliantObjects, nonCompliantObjects, waivedObjects)
 	if err != nil {
 		check.LogError("Failed to get result objects string for check %s: %v", check.ID, err)
 	}
@@ -207,6 +279,9 @@ func (check *Check) SetResult(compliantObjects, nonCompliantObjects []*testhelpe
 	if len(nonCompliantObjects) > 0 {
 		check.Result = CheckResultFailed
 		check.skipReason = ""
+	} else if len(waivedObjects) > 0 {
+		check.Result = CheckResultPassedWithWaivers
+		check.skipReason = ""
 	} else if len(compliantObjects) == 0 {
 		// Mark this check as skipped.
 		check.LogWarn("Check %s marked as skipped as both compliant and non-compliant objects lists are empty.", check.ID)
@@ -215,11 +290,22 @@ func (check *Check) SetResult(compliantObjects, nonCompliantObjects []*testhelpe
 	}
 }
 
+// resetResult clears the result of a previous attempt before running the check again.
+func (check *Check) resetResult() {
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+
+	check.Result = CheckResultPassed
+	check.details = ""
+	check.skipReason = ""
+	check.finished = false
+}
+
 func (check *Check) SetResultSkipped(reason string) {
 	check.mutex.Lock()
 	defer check.mutex.Unlock()
 
-	if check.Result == CheckResultAborted {
+	if check.Result == CheckResultAborted || check.finished {
 		return
 	}
 
@@ -231,7 +317,7 @@ func (check *Check) SetResultError(reason string) {
 	check.mutex.Lock()
 	defer check.mutex.Unlock()
 
-	if check.Result == CheckResultAborted {
+	if check.Result == CheckResultAborted || check.finished {
 		return
 	}
 
@@ -268,6 +354,10 @@ func (check *Check) Run() error {
 	}()
 
 	check.LogInfo("Running check (labels: %v)", check.Labels)
+	return runWithTimeout(check, check.runFns)
+}
+
+func (check *Check) runFns() error {
 	if check.BeforeCheckFn != nil {
 		if err := check.BeforeCheckFn(check); err != nil {
 			return fmt.Errorf("check %s failed in before check function: %w", check.ID, err)
@@ -284,14 +374,12 @@ func (check *Check) Run() error {
 		}
 	}
 
-	printCheckResult(check)
-
 	return nil
 }
 
 func printCheckResult(check *Check) {
 	switch check.Result {
-	case CheckResultPassed:
+	case CheckResultPassed, CheckResultPassedAfterRetry, CheckResultPassedWithWaivers:
 		cli.PrintCheckPassed(check.ID)
 	case CheckResultFailed:
 		cli.PrintCheckFailed(check.ID)
@@ -302,4 +390,5 @@ func printCheckResult(check *Check) {
 	case CheckResultError:
 		cli.PrintCheckErrored(check.ID)
 	}
+	emitCheckFinished(check)
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -326,9 +327,18 @@ func TestLogMethods(t *testing.T) {
 	assert.Contains(t, logs, "error message 4")
 }
 
+// disableRunningCheckLineForTest avoids leaking the running check line goroutine started by
+// Check.Run(), as the result that would stop it is only printed by runCheck.
+func disableRunningCheckLineForTest(t *testing.T) {
+	t.Helper()
+	cli.DisableRunningCheckLine()
+	t.Cleanup(cli.EnableRunningCheckLine)
+}
+
 func TestRun(t *testing.T) {
 	// Not parallel: Check.Run() calls cli.PrintCheckRunning/PrintCheckPassed
 	// which use package-level channels unsafe for concurrent access.
+	disableRunningCheckLineForTest(t)
 
 	tests := []struct {
 		name        string
@@ -425,6 +435,7 @@ func TestRunNilCheck(t *testing.T) {
 
 func TestRunSetsTimestamps(t *testing.T) {
 	// Not parallel: same cli channel race as TestRun.
+	disableRunningCheckLineForTest(t)
 
 	check := NewCheck("test-timestamps", []string{"test"})
 	check.WithCheckFn(func(c *Check) error { return nil })
@@ -0,0 +1,171 @@
+package checksdb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+)
+
+const (
+	checkpointFilePermissions = 0o644
+)
+
+var (
+	checkpointLock sync.Mutex
+	checkpointFile *os.File
+
+	// Results of the checks that completed in the run being resumed, by check ID.
+	resumedResults = map[string]claim.Result{}
+)
+
+// checkpointRecord is a line of the checkpoint file.
+type checkpointRecord struct {
+	ID     string       `json:"id"`
+	Result claim.Result `json:"result"`
+}
+
+// OpenCheckpoint makes the result of every check to be appended to the checkpoint file as soon
+// as the check completes. When resuming, the results already in the file are loaded so those
+// checks won't run again, and the number of loaded results is returned, and the record the
+// interrupted run may have left incomplete is removed, so the next ones are appended after the last
+// complete one. Otherwise, the file is truncated.
+func OpenCheckpoint(fileName string, resume bool) (resumed int, err error) {
+	checkpointLock.Lock()
+	defer checkpointLock.Unlock()
+
+	resumedResults = map[string]claim.Result{}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		var validSize int64
+		if resumedResults, validSize, err = loadCheckpoint(fileName); err != nil {
+			return 0, err
+		}
+		if err = os.Truncate(fileName, validSize); err != nil {
+			return 0, fmt.Errorf("could not remove the incomplete records of checkpoint file %s: %w", fileName, err)
+		}
+		flags = os.O_WRONLY | os.O_APPEND
+	}
+
+	checkpointFile, err = os.OpenFile(fileName, flags, checkpointFilePermissions)
+	if err != nil {
+		return 0, fmt.Errorf("could not open checkpoint file %s: %w", fileName, err)
+	}
+
+	return len(resumedResults), nil
+}
+
+// CloseCheckpoint stops saving the check results to the checkpoint file.
+func CloseCheckpoint() error {
+	checkpointLock.Lock()
+	defer checkpointLock.Unlock()
+
+	if checkpointFile == nil {
+		return nil
+	}
+
+	err := checkpointFile.Close()
+	checkpointFile = nil
+	return err
+}
+
+// loadCheckpoint returns the results in the checkpoint file, and the size of the file up to the end
+// of its last valid record.
+func loadCheckpoint(fileName string) (results map[string]claim.Result, validSize int64, err error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not open checkpoint file %s: %w", fileName, err)
+	}
+	defer f.Close()
+
+	results = map[string]claim.Result{}
+	// Lines hold the checks logs, which can be way bigger than a scanner's max size.
+	reader := bufio.NewReader(f)
+	var size int64
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, 0, fmt.Errorf("could not read checkpoint file %s: %w", fileName, err)
+		}
+		if len(line) == 0 {
+			break
+		}
+		size += int64(len(line))
+
+		var record checkpointRecord
+		if !bytes.HasSuffix(line, []byte{'\n'}) {
+			// The last line is incomplete if the run was killed while writing it.
+			log.Warn("Ignoring incomplete line %d in checkpoint file %s", lineNum, fileName)
+			break
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			log.Warn("Ignoring invalid line %d in checkpoint file %s: %v", lineNum, fileName, err)
+			continue
+		}
+		results[record.ID] = record.Result
+		validSize = size
+	}
+
+	return results, validSize, nil
+}
+
+// saveCheckpoint appends the check's result to the checkpoint file, if enabled.
+func saveCheckpoint(check *Check) {
+	result, found := newClaimResult(check)
+	if !found {
+		return
+	}
+
+	line, err := json.Marshal(checkpointRecord{ID: check.ID, Result: result})
+	if err != nil {
+		log.Error("Could not marshal the checkpoint of check %s: %v", check.ID, err)
+		return
+	}
+
+	checkpointLock.Lock()
+	defer checkpointLock.Unlock()
+
+	if checkpointFile == nil {
+		return
+	}
+
+	if _, err := checkpointFile.Write(append(line, '\n')); err != nil {
+		log.Error("Could not save the checkpoint of check %s: %v", check.ID, err)
+	}
+}
+
+// restoreCheckpointResult sets the result the check got in the run being resumed, if it completed.
+func restoreCheckpointResult(check *Check) bool {
+	checkpointLock.Lock()
+	result, found := resumedResults[check.ID]
+	checkpointLock.Unlock()
+	if !found {
+		check.resumedResult = nil
+		return false
+	}
+
+	check.LogInfo("Restoring result %q from the checkpoint", result.State)
+	check.resumedResult = &result
+	check.Result = CheckResult(result.State)
+	check.skipReason = result.SkipReason
+	check.details = result.CheckDetails
+	printCheckResult(check)
+
+	return true
+}
+
+// isCheckpointable returns whether the check completed, so it doesn't need to run when resuming.
+func isCheckpointable(check *Check) bool {
+	switch check.Result.String() {
+	case CheckResultPassed, CheckResultPassedAfterRetry, CheckResultPassedWithWaivers, CheckResultFailed, CheckResultSkipped:
+		return true
+	}
+	return false
+}
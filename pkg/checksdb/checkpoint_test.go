@@ -0,0 +1,109 @@
+package checksdb
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointAndResume(t *testing.T) {
+	ids := resetDBStateForRun(t,
+		identifiers.TestOperatorInstallStatusSucceededIdentifier,
+		identifiers.TestOperatorNoSCCAccess,
+		identifiers.TestOperatorIsInstalledViaOLMIdentifier,
+		identifiers.TestOperatorHasSemanticVersioningIdentifier)
+	passedID, failedID, erroredID, notRunID := ids[0], ids[1], ids[2], ids[3]
+	t.Cleanup(func() {
+		_ = CloseCheckpoint()
+		resumedResults = map[string]claim.Result{}
+	})
+
+	checkpointFile := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+
+	ran := map[string]int{}
+	newGroup := func(erroredCheckFn func(*Check) error) *ChecksGroup {
+		dbByGroup = map[string]*ChecksGroup{}
+		dbGroups = nil
+		group := NewChecksGroup("operator")
+		group.Add(NewCheck(passedID, []string{"test"}).WithCheckFn(func(c *Check) error {
+			ran[c.ID]++
+			return passingCheckFn(c)
+		}))
+		group.Add(NewCheck(failedID, []string{"test"}).WithCheckFn(func(c *Check) error {
+			ran[c.ID]++
+			return failingCheckFn(c)
+		}))
+		group.Add(NewCheck(erroredID, []string{"test"}).WithCheckFn(func(c *Check) error {
+			ran[c.ID]++
+			return erroredCheckFn(c)
+		}))
+		group.Add(NewCheck(notRunID, []string{"test"}).WithCheckFn(func(c *Check) error {
+			ran[c.ID]++
+			return nil
+		}))
+		return group
+	}
+
+	// First run: the third check errors, so the fourth one never runs.
+	resumed, err := OpenCheckpoint(checkpointFile, false)
+	require.NoError(t, err)
+	assert.Zero(t, resumed)
+
+	group := newGroup(func(*Check) error { return errors.New("connection refused") })
+	_, failedChecks := group.RunChecks(make(chan bool), make(chan string, 1))
+	assert.Equal(t, 1, failedChecks)
+	require.NoError(t, CloseCheckpoint())
+
+	content, err := os.ReadFile(checkpointFile)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), "\n"))
+
+	// Simulates a run killed while writing a line.
+	f, err := os.OpenFile(checkpointFile, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"id":"` + notRunID + `","res`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// Resumed run: only the checks that did not complete run.
+	resumed, err = OpenCheckpoint(checkpointFile, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, resumed)
+
+	resultsDB = map[string]claim.Result{}
+	group = newGroup(func(*Check) error { return nil })
+	_, failedChecks = group.RunChecks(make(chan bool), make(chan string, 1))
+	assert.Equal(t, 1, failedChecks)
+	group.RecordChecksResults()
+
+	assert.Equal(t, map[string]int{passedID: 1, failedID: 1, erroredID: 2, notRunID: 1}, ran)
+	assert.Equal(t, CheckResultPassed, resultsDB[passedID].State)
+	assert.Equal(t, CheckResultFailed, resultsDB[failedID].State)
+	assert.Equal(t, CheckResultPassed, resultsDB[erroredID].State)
+	assert.Equal(t, CheckResultPassed, resultsDB[notRunID].State)
+	// The restored results keep the logs of the previous run.
+	assert.Contains(t, resultsDB[failedID].CapturedTestOutput, "Running check")
+	require.NoError(t, CloseCheckpoint())
+
+	// Resumed again: the records appended after the incomplete line are read, so nothing runs.
+	resumed, err = OpenCheckpoint(checkpointFile, true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, resumed)
+
+	group = newGroup(func(*Check) error { return nil })
+	_, failedChecks = group.RunChecks(make(chan bool), make(chan string, 1))
+	assert.Equal(t, 1, failedChecks)
+	assert.Equal(t, map[string]int{passedID: 1, failedID: 1, erroredID: 2, notRunID: 1}, ran)
+}
+
+func TestOpenCheckpointResumeWithoutCheckpoint(t *testing.T) {
+	_, err := OpenCheckpoint(filepath.Join(t.TempDir(), "checkpoint.jsonl"), true)
+	assert.ErrorContains(t, err, "could not open checkpoint file")
+}
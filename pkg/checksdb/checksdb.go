@@ -23,15 +23,21 @@ import (
 var (
 	dbLock    sync.Mutex
 	dbByGroup map[string]*ChecksGroup
+	// Groups in registration order, which is the default execution order.
+	dbGroups []*ChecksGroup
 
 	resultsDB = map[string]claim.Result{}
 
 	labelsExprEvaluator labels.LabelsExprEvaluator
+
+	// Cancels the checks run in progress, or the next one if CancelRun was called before it started.
+	cancelLock    sync.Mutex
+	cancelRunChan chan string
+	pendingCancel string
 )
 
 type AbortPanicMsg string
 
-//nolint:funlen
 func RunChecks(timeout time.Duration) (failedCtr int, err error) {
 	dbLock.Lock()
 	defer dbLock.Unlock()
@@ -45,61 +51,22 @@ func RunChecks(timeout time.Duration) (failedCtr int, err error) {
 	// turn off ctrl-c capture on exit
 	defer signal.Stop(sigIntChan)
 
-	abort := false
-	var abortReason string
-	var errs []error
-	for _, group := range dbByGroup {
-		if abort {
-			_ = group.OnAbort(abortReason)
-			group.RecordChecksResults()
-			continue
-		}
-
-		// Stop channel, so we can send a stop signal to group.RunChecks()
-		stopChan := make(chan bool, 1)
-		abortChan := make(chan string, 1)
-
-		// Done channel for the goroutine that runs group.RunChecks().
-		groupDone := make(chan bool)
-		go func() {
-			checks, failedCheckCtr := group.RunChecks(stopChan, abortChan)
-			failedCtr += failedCheckCtr
-			errs = append(errs, checks...)
-			groupDone <- true
-		}()
-
-		select {
-		case <-groupDone:
-			log.Debug("Group %s finished running checks.", group.name)
-		case abortReason = <-abortChan:
-			log.Warn("Group %s aborted.", group.name)
-			stopChan <- true
-
-			abort = true
-			_ = group.OnAbort(abortReason)
-		case <-timeOutChan:
-			log.Warn("Running all checks timed-out.")
-			stopChan <- true
-
-			abort = true
-			abortReason = "global time-out"
-			_ = group.OnAbort(abortReason)
-		case <-sigIntChan:
-			log.Warn("SIGINT/SIGTERM received.")
-			stopChan <- true
-
-			abort = true
-			abortReason = "SIGINT/SIGTERM"
-			_ = group.OnAbort(abortReason)
-		}
-
-		group.RecordChecksResults()
+	plan, err := planExecution(dbGroups)
+	if err != nil {
+		return 0, err
 	}
 
+	resetExecutionOrder()
+	startCancelableRun()
+	defer endCancelableRun()
+	failedCtr, errs := runGroups(plan, timeOutChan, sigIntChan)
+	emitRunFinished()
+
 	// Print the results in the CLI
-	cli.PrintResultsTable(getResultsSummary())
+	cli.PrintResultsTable(getResultsSummary(plan.groups))
 	printFailedChecksLog()
 	printDaemonsetSkippedChecks()
+	printExpiredWaivers()
 
 	if len(errs) > 0 {
 		log.Error("RunChecks errors: %v", errs)
@@ -109,7 +76,73 @@ func RunChecks(timeout time.Duration) (failedCtr int, err error) {
 	return failedCtr, nil
 }
 
+// CancelRun aborts the checks run in progress with the reason, like a SIGINT does. When the checks
+// are not running yet, e.g. during the discovery, the next run is aborted as soon as it starts.
+func CancelRun(reason string) {
+	cancelLock.Lock()
+	defer cancelLock.Unlock()
+
+	if cancelRunChan == nil {
+		pendingCancel = reason
+		return
+	}
+
+	select {
+	case cancelRunChan <- reason:
+	default:
+		// Already canceled.
+	}
+}
+
+func startCancelableRun() {
+	cancelLock.Lock()
+	defer cancelLock.Unlock()
+
+	cancelRunChan = make(chan string, 1)
+	if pendingCancel != "" {
+		cancelRunChan <- pendingCancel
+		pendingCancel = ""
+	}
+}
+
+func endCancelableRun() {
+	cancelLock.Lock()
+	defer cancelLock.Unlock()
+
+	cancelRunChan = nil
+}
+
+// getCancelRunChan returns the channel the reason of the cancellation of the run in progress is
+// sent to, nil if the checks are not running.
+func getCancelRunChan() <-chan string {
+	cancelLock.Lock()
+	defer cancelLock.Unlock()
+
+	return cancelRunChan
+}
+
+// ResetChecksDB removes the loaded checks and their results, so the checks can be loaded again for
+// another run in the same process, as in the web server mode.
+func ResetChecksDB() {
+	dbLock.Lock()
+	dbByGroup = nil
+	dbGroups = nil
+	resultsDB = map[string]claim.Result{}
+	dbLock.Unlock()
+
+	cancelLock.Lock()
+	pendingCancel = ""
+	cancelLock.Unlock()
+
+	ResetRequirements()
+}
+
 func recordCheckResult(check *Check) {
+	if check.resumedResult != nil {
+		resultsDB[check.ID] = *check.resumedResult
+		return
+	}
+
 	claimID, ok := identifiers.TestIDToClaimID[check.ID]
 	if !ok {
 		check.LogDebug("TestID %s has no corresponding Claim ID - skipping result recording", check.ID)
@@ -117,7 +150,17 @@ func recordCheckResult(check *Check) {
 	}
 
 	check.LogInfo("Recording result %q, claimID: %+v", strings.ToUpper(check.Result.String()), claimID)
-	resultsDB[check.ID] = claim.Result{
+	resultsDB[check.ID], _ = newClaimResult(check)
+}
+
+// newClaimResult returns the claim result of the check, or false if the check has no claim ID.
+func newClaimResult(check *Check) (claim.Result, bool) {
+	claimID, ok := identifiers.TestIDToClaimID[check.ID]
+	if !ok {
+		return claim.Result{}, false
+	}
+
+	return claim.Result{
 		TestID:             &claimID,
 		State:              check.Result.String(),
 		StartTime:          check.StartTime.String(),
@@ -138,7 +181,7 @@ func recordCheckResult(check *Check) {
 			BestPracticeReference: identifiers.Catalog[claimID].BestPracticeReference,
 			ExceptionProcess:      identifiers.Catalog[claimID].ExceptionProcess,
 		},
-	}
+	}, true
 }
 
 // GetReconciledResults is a function added to aggregate a Claim's results.  Due to the limitations of
@@ -162,13 +205,15 @@ const (
 	SKIPPED = 2
 )
 
-func getResultsSummary() map[string][]int {
-	results := make(map[string][]int)
-	for groupName, group := range dbByGroup {
+// getResultsSummary returns the number of passed, failed and skipped checks of the groups, in the
+// groups' order.
+func getResultsSummary(groups []*ChecksGroup) []cli.GroupResults {
+	results := []cli.GroupResults{}
+	for _, group := range groups {
 		groupResults := []int{0, 0, 0}
 		for _, check := range group.checks {
 			switch check.Result {
-			case CheckResultPassed:
+			case CheckResultPassed, CheckResultPassedAfterRetry, CheckResultPassedWithWaivers:
 				groupResults[PASSED]++
 			case CheckResultFailed:
 				groupResults[FAILED]++
@@ -176,7 +221,7 @@ func getResultsSummary() map[string][]int {
 				groupResults[SKIPPED]++
 			}
 		}
-		results[groupName] = groupResults
+		results = append(results, cli.GroupResults{Name: group.name, Results: groupResults})
 	}
 	return results
 }
@@ -184,7 +229,7 @@ func getResultsSummary() map[string][]int {
 const nbColorSymbols = 9
 
 func printFailedChecksLog() {
-	for _, group := range dbByGroup {
+	for _, group := range dbGroups {
 		for _, check := range group.checks {
 			if check.Result != CheckResultFailed {
 				continue
@@ -206,7 +251,7 @@ func printFailedChecksLog() {
 
 func printDaemonsetSkippedChecks() {
 	var skippedIDs []string
-	for _, group := range dbByGroup {
+	for _, group := range dbGroups {
 		for _, check := range group.checks {
 			if check.Result == CheckResultSkipped && check.skipReason == testhelper.DaemonsetFailedToSpawnSkipReason {
 				skippedIDs = append(skippedIDs, check.ID)
@@ -262,9 +307,18 @@ func GetTestsCountByState(state string) int {
 	return count
 }
 
+// FilterCheckIDs returns the IDs of the checks matching the labels filter, in execution order.
 func FilterCheckIDs() ([]string, error) {
+	dbLock.Lock()
+	defer dbLock.Unlock()
+
+	plan, err := planExecution(dbGroups)
+	if err != nil {
+		return nil, err
+	}
+
 	filteredCheckIDs := []string{}
-	for _, group := range dbByGroup {
+	for _, group := range plan.groups {
 		for _, check := range group.checks {
 			if labelsExprEvaluator.Eval(check.Labels) {
 				filteredCheckIDs = append(filteredCheckIDs, check.ID)
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -12,14 +15,45 @@ func saveAndResetDBState(t *testing.T) {
 	t.Helper()
 	origResultsDB := resultsDB
 	origDbByGroup := dbByGroup
+	origDbGroups := dbGroups
+	origGroupsOrder := groupsOrder
 	origEvaluator := labelsExprEvaluator
 	t.Cleanup(func() {
 		resultsDB = origResultsDB
 		dbByGroup = origDbByGroup
+		dbGroups = origDbGroups
+		groupsOrder = origGroupsOrder
 		labelsExprEvaluator = origEvaluator
 	})
 	resultsDB = map[string]claim.Result{}
 	dbByGroup = map[string]*ChecksGroup{}
+	dbGroups = nil
+	groupsOrder = nil
+}
+
+// resetDBStateForRun resets the DB state to run the checks labeled "test". It returns the test IDs
+// of the given identifiers, which, unlike made-up check IDs, have their results recorded.
+func resetDBStateForRun(t *testing.T, recordedIdentifiers ...claim.Identifier) []string {
+	t.Helper()
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+
+	ids := []string{}
+	for _, identifier := range recordedIdentifiers {
+		id, _ := identifiers.GetTestIDAndLabels(identifier)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func passingCheckFn(c *Check) error {
+	c.SetResult([]*testhelper.ReportObject{testhelper.NewReportObject("reason", "type", true)}, nil)
+	return nil
+}
+
+func failingCheckFn(c *Check) error {
+	c.SetResult(nil, []*testhelper.ReportObject{testhelper.NewReportObject("reason", "type", false)})
+	return nil
 }
 
 func TestGetResults(t *testing.T) {
@@ -153,6 +187,7 @@ func TestFilterCheckIDs(t *testing.T) {
 		},
 	}
 	dbByGroup["test-group"] = group
+	dbGroups = append(dbGroups, group)
 
 	err := InitLabelsExprEvaluator("common")
 	require.NoError(t, err)
@@ -166,9 +201,16 @@ func TestFilterCheckIDs(t *testing.T) {
 
 	ids, err = FilterCheckIDs()
 	require.NoError(t, err)
-	assert.Len(t, ids, 2)
-	assert.Contains(t, ids, "check-common")
-	assert.Contains(t, ids, "check-extended")
+	assert.Equal(t, []string{"check-common", "check-extended"}, ids)
+
+	// The checks of the groups run first come first.
+	first := NewChecksGroup("first-group")
+	first.Add(NewCheck("check-first", []string{"common"}))
+	require.NoError(t, SetGroupsOrder([]string{"first-group", GroupsOrderWildcard}))
+
+	ids, err = FilterCheckIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"check-first", "check-common", "check-extended"}, ids)
 }
 
 func TestGetResultsSummary(t *testing.T) {
@@ -183,17 +225,17 @@ func TestGetResultsSummary(t *testing.T) {
 	net4 := NewCheck("net-4", []string{"test"})
 	net4.Result = CheckResultPassed
 
-	group := &ChecksGroup{
+	networking := &ChecksGroup{
 		name:   "networking",
 		checks: []*Check{net1, net2, net3, net4},
 	}
-	dbByGroup["networking"] = group
+	observability := &ChecksGroup{name: "observability"}
 
-	summary := getResultsSummary()
-	require.Contains(t, summary, "networking")
-	assert.Equal(t, 2, summary["networking"][PASSED])
-	assert.Equal(t, 1, summary["networking"][FAILED])
-	assert.Equal(t, 1, summary["networking"][SKIPPED])
+	summary := getResultsSummary([]*ChecksGroup{observability, networking})
+	assert.Equal(t, []cli.GroupResults{
+		{Name: "observability", Results: []int{0, 0, 0}},
+		{Name: "networking", Results: []int{2, 1, 1}},
+	}, summary)
 }
 
 func TestRecordCheckResultNotFound(t *testing.T) {
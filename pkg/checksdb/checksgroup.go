@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
@@ -17,12 +19,22 @@ const (
 type ChecksGroup struct {
 	name   string
 	checks []*Check
+	// Names of the groups that must finish before this one starts.
+	runAfter []string
 
 	beforeAllFn, afterAllFn func(checks []*Check) error
 
 	beforeEachFn, afterEachFn func(check *Check) error
 
 	currentRunningCheckIdx int
+
+	// Held while the group's checks run, except while waiting for the group functions, the skip
+	// functions or the intrusive checks lock, so a group still running after an abort can be aborted.
+	mutex sync.Mutex
+	// Set once the run is stopped while the group's checks were running.
+	stopped bool
+	// Set once the group is aborted. From then on, its running goroutine changes none of its checks.
+	aborted bool
 }
 
 func NewChecksGroup(groupName string) *ChecksGroup {
@@ -44,6 +56,7 @@ func NewChecksGroup(groupName string) *ChecksGroup {
 		currentRunningCheckIdx: checkIdxNone,
 	}
 	dbByGroup[groupName] = group
+	dbGroups = append(dbGroups, group)
 
 	return group
 }
@@ -98,6 +111,15 @@ func skipCheck(check *Check, reason string) {
 	printCheckResult(check)
 }
 
+// skipNotMatchingCheck skips a check not matching the labels filter. Unlike skipCheck, no event is
+// emitted, as it was never meant to run.
+func skipNotMatchingCheck(check *Check) {
+	const reason = "no matching labels"
+	check.LogInfo("Skipping check %s, reason: %s", check.ID, reason)
+	check.SetResultSkipped(reason)
+	cli.PrintCheckSkipped(check.ID, reason)
+}
+
 func skipAll(checks []*Check, reason string) {
 	for _, check := range checks {
 		skipCheck(check, reason)
@@ -108,6 +130,7 @@ func onFailure(failureType, failureMsg string, group *ChecksGroup, currentCheck
 	// Set current Check's result as error.
 	fmt.Printf("\r[ %s ] %-60s\n", cli.CheckResultTagError, currentCheck.ID)
 	currentCheck.SetResultError(failureType + ": " + failureMsg)
+	emitCheckFinished(currentCheck)
 	// Set the remaining checks as skipped, using a simplified reason msg.
 	reason := "group " + group.name + " " + failureType
 	skipAll(remainingChecks, reason)
@@ -115,6 +138,22 @@ func onFailure(failureType, failureMsg string, group *ChecksGroup, currentCheck
 	return errors.New(reason)
 }
 
+// unlocked calls fn without holding the group's lock, so the group can be aborted while fn is
+// blocked. If it was, errCheckStopped is returned instead of fn's error or panic, so the checks
+// results set by OnAbort are not changed.
+func (group *ChecksGroup) unlocked(fn func() error) (err error) {
+	group.mutex.Unlock()
+	defer func() {
+		group.mutex.Lock()
+		if group.aborted {
+			_ = recover()
+			err = errCheckStopped
+		}
+	}()
+
+	return fn()
+}
+
 func runBeforeAllFn(group *ChecksGroup, checks []*Check) (err error) {
 	log.Debug("GROUP %s - Running beforeAll", group.name)
 	if group.beforeAllFn == nil {
@@ -131,7 +170,11 @@ func runBeforeAllFn(group *ChecksGroup, checks []*Check) (err error) {
 		}
 	}()
 
-	if err := group.beforeAllFn(checks); err != nil {
+	err = group.unlocked(func() error { return group.beforeAllFn(checks) })
+	if errors.Is(err, errCheckStopped) {
+		return err
+	}
+	if err != nil {
 		log.Error("Unexpected error while running beforeAll function: %v", err)
 		// Set first check's result as error and skip the remaining ones.
 		return onFailure("beforeAll function unexpected error", err.Error(), group, firstCheck, checks)
@@ -158,7 +201,11 @@ func runAfterAllFn(group *ChecksGroup, checks []*Check) (err error) {
 		}
 	}()
 
-	if err := group.afterAllFn(group.checks); err != nil {
+	err = group.unlocked(func() error { return group.afterAllFn(group.checks) })
+	if errors.Is(err, errCheckStopped) {
+		return err
+	}
+	if err != nil {
 		log.Error("Unexpected error while running afterAll function: %v", err.Error())
 		// Set last check's result as error, no need to skip anyone.
 		return onFailure("afterAll function unexpected error", err.Error(), group, lastCheck, zeroRemainingChecks)
@@ -182,7 +229,11 @@ func runBeforeEachFn(group *ChecksGroup, check *Check, remainingChecks []*Check)
 		}
 	}()
 
-	if err := group.beforeEachFn(check); err != nil {
+	err = group.unlocked(func() error { return group.beforeEachFn(check) })
+	if errors.Is(err, errCheckStopped) {
+		return err
+	}
+	if err != nil {
 		log.Error("Unexpected error while running beforeEach function:\n%v", err.Error())
 		// Set last check's result as error, no need to skip anyone.
 		return onFailure("beforeEach function unexpected error", err.Error(), group, check, remainingChecks)
@@ -207,7 +258,11 @@ func runAfterEachFn(group *ChecksGroup, check *Check, remainingChecks []*Check)
 		}
 	}()
 
-	if err := group.afterEachFn(check); err != nil {
+	err = group.unlocked(func() error { return group.afterEachFn(check) })
+	if errors.Is(err, errCheckStopped) {
+		return err
+	}
+	if err != nil {
 		log.Error("Unexpected error while running afterEach function:\n%v", err.Error())
 		// Set last check's result as error, no need to skip anyone.
 		return onFailure("afterEach function unexpected error", err.Error(), group, check, remainingChecks)
@@ -283,11 +338,47 @@ func runCheck(check *Check, group *ChecksGroup, remainingChecks []*Check) (err e
 		}
 	}()
 
-	if err := check.Run(); err != nil {
+	// Released as soon as the check functions return or are abandoned after a timeout or an abort.
+	// Abandoned functions may still be running in the background, even the intrusive ones, until
+	// they notice their context was cancelled, but they are not waited for so a check ignoring its
+	// context cannot block the rest of the run.
+	var unlock func()
+	lockErr := group.unlocked(func() error {
+		unlock = lockCheckRun(check)
+		return nil
+	})
+	defer unlock()
+
+	// The run may have been aborted while waiting for the lock: the check must not start then.
+	select {
+	case <-check.stopChan:
+		lockErr = errCheckStopped
+	default:
+	}
+	if lockErr != nil {
+		// The check is set as aborted by its group.
+		return nil
+	}
+
+	emitCheckStarted(check)
+	err = runWithRetries(check)
+	if errors.Is(err, errCheckStopped) {
+		// The run was aborted, the check is set as aborted by its group.
+		return nil
+	}
+	if errors.Is(err, ErrCheckTimedOut) {
+		// Unlike other errors, a timeout only affects this check, so the rest of them can run.
+		check.LogError("Check %s cancelled: %v", check.ID, err)
+		printCheckResult(check)
+		return nil
+	}
+	if err != nil {
 		check.LogError("Unexpected error while running check %s function: %v", check.ID, err.Error())
 		return onFailure(fmt.Sprintf("check %s function unexpected error", check.ID), err.Error(), group, check, remainingChecks)
 	}
 
+	printCheckResult(check)
+
 	return nil
 }
 
@@ -310,61 +401,92 @@ func (group *ChecksGroup) RunChecks(stopChan <-chan bool, abortChan chan string)
 	log.Info("Running group %q checks.", group.name)
 	fmt.Printf("Running suite %s\n", strings.ToUpper(group.name))
 
-	// Get checks to run based on the label expr.
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+
+	// Get checks to run based on the label expr and the ones that completed in the run being resumed.
 	checks := []*Check{}
 	for _, check := range group.checks {
 		if !labelsExprEvaluator.Eval(check.Labels) {
-			skipCheck(check, "no matching labels")
+			skipNotMatchingCheck(check)
+			continue
+		}
+		if restoreCheckpointResult(check) {
+			if check.Result.String() == CheckResultFailed {
+				failedChecks++
+			}
 			continue
 		}
 		checks = append(checks, check)
 	}
 
 	if len(checks) == 0 {
-		return nil, 0
+		return nil, failedChecks
 	}
 
 	// Run afterAllFn always, no matter previous panics/crashes.
 	defer func() {
-		if err := runAfterAllFn(group, checks); err != nil {
+		if err := runAfterAllFn(group, checks); err != nil && !errors.Is(err, errCheckStopped) {
 			errs = append(errs, err)
 		}
 	}()
 
 	if err := runBeforeAllFn(group, checks); err != nil {
+		if errors.Is(err, errCheckStopped) {
+			group.stopped = true
+			return nil, 0
+		}
 		errs = append(errs, err)
-		return errs, 0
+		return errs, failedChecks
 	}
 
 	log.Info("Checks to run: %d (group's total=%d)", len(checks), len(group.checks))
-	group.currentRunningCheckIdx = 0
 	for i, check := range checks {
 		// Fast stop in case the stop (abort/timeout) signal received.
 		select {
 		case <-stopChan:
+			group.stopped = true
 			return nil, 0
 		default:
 		}
 
+		group.currentRunningCheckIdx = slices.Index(group.checks, check)
+
 		// Create a remainingChecks list excluding the current check.
 		remainingChecks := []*Check{}
 		if i+1 < len(checks) {
 			remainingChecks = checks[i+1:]
 		}
 
+		recordCheckExecution(check)
 		if err := runBeforeEachFn(group, check, remainingChecks); err != nil {
 			errs = []error{err}
 		}
 
 		if len(errs) == 0 {
 			// Should we skip this check?
-			skip, reasons := shouldSkipCheck(check)
-			if skip {
+			var skip bool
+			var reasons []string
+			err := group.unlocked(func() error {
+				skip, reasons = shouldSkipCheck(check)
+				return nil
+			})
+			if missing := missingRequirements(check); len(missing) > 0 {
+				skip, reasons = true, missing
+			}
+			if failed := failedPrerequisites(check); len(failed) > 0 {
+				skip, reasons = true, failed
+			}
+			switch {
+			case err != nil:
+				// The group was aborted while running the skip functions.
+				errs = []error{err}
+			case skip:
 				skipCheck(check, strings.Join(reasons, ", "))
-			} else {
+			default:
 				check.SetAbortChan(abortChan) // Set the abort channel for the check.
-				err := runCheck(check, group, remainingChecks)
-				if err != nil {
+				check.setStopChan(stopChan)
+				if err := runCheck(check, group, remainingChecks); err != nil {
 					errs = append(errs, err)
 				}
 			}
@@ -374,11 +496,29 @@ func (group *ChecksGroup) RunChecks(stopChan <-chan bool, abortChan chan string)
 			errs = append(errs, err)
 		}
 
+		// The group was aborted while still running, its checks were set as aborted by OnAbort.
+		if slices.ContainsFunc(errs, func(err error) bool { return errors.Is(err, errCheckStopped) }) {
+			group.stopped = true
+			return nil, 0
+		}
+
 		// Don't run more checks if any of beforeEach, the checkFn or afterEach functions errored/panicked.
 		if len(errs) > 0 {
 			break
 		}
 
+		// The check stopped by an abort is set as aborted by OnAbort, its result must not be saved.
+		select {
+		case <-stopChan:
+			group.stopped = true
+			return nil, 0
+		default:
+		}
+
+		if isCheckpointable(check) {
+			saveCheckpoint(check)
+		}
+
 		// Increment the failed checks counter.
 		if check.Result.String() == CheckResultFailed {
 			failedChecks++
@@ -390,7 +530,18 @@ func (group *ChecksGroup) RunChecks(stopChan <-chan bool, abortChan chan string)
 	return errs, failedChecks
 }
 
+// OnAbort sets the group's check that was running when the run was aborted as aborted, and the
+// ones after it as skipped. A group is only aborted once: the checks of a group still running are
+// not changed from then on.
 func (group *ChecksGroup) OnAbort(abortReason string) error {
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+
+	if group.aborted {
+		return nil
+	}
+	group.aborted = true
+
 	// If this wasn't the group with the aborted check.
 	if group.currentRunningCheckIdx == checkIdxNone {
 		fmt.Printf("Skipping checks from suite %s\n", strings.ToUpper(group.name))
@@ -402,17 +553,27 @@ func (group *ChecksGroup) OnAbort(abortReason string) error {
 			continue
 		}
 
+		// Completed in the run being resumed, or finished before the abort: their result was
+		// already printed and emitted.
+		if check.resumedResult != nil || check.resultEmitted {
+			continue
+		}
+
 		// If none of this group's checks was running yet, skip all.
 		if group.currentRunningCheckIdx == checkIdxNone {
 			check.SetResultSkipped(abortReason)
+			emitCheckFinished(check)
 			continue
 		}
 
 		// Abort the check that was running when it was aborted and skip the rest.
-		if i == group.currentRunningCheckIdx {
+		switch {
+		case i == group.currentRunningCheckIdx:
 			check.SetResultAborted(abortReason)
-		} else if i > group.currentRunningCheckIdx {
+		case i > group.currentRunningCheckIdx:
 			check.SetResultSkipped(abortReason)
+		default:
+			continue
 		}
 
 		printCheckResult(check)
@@ -422,6 +583,9 @@ func (group *ChecksGroup) OnAbort(abortReason string) error {
 }
 
 func (group *ChecksGroup) RecordChecksResults() {
+	group.mutex.Lock()
+	defer group.mutex.Unlock()
+
 	log.Info("Recording checks results of group %s", group.name)
 	for _, check := range group.checks {
 		recordCheckResult(check)
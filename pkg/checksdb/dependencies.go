@@ -0,0 +1,48 @@
+package checksdb
+
+import "fmt"
+
+// WithDependsOn declares the checks this check depends on. The check runs after them, and it's
+// skipped when any of them failed or errored, as its own result would be meaningless. IDs of
+// checks that don't exist are ignored.
+func (check *Check) WithDependsOn(checkIDs ...string) *Check {
+	if check.Error != nil {
+		return check
+	}
+
+	check.DependsOn = append(check.DependsOn, checkIDs...)
+	return check.WithRunAfter(checkIDs...)
+}
+
+// failedPrerequisites returns the reasons for the check's prerequisites that failed or errored.
+// Prerequisites always run before their dependents, so their results are final by now.
+func failedPrerequisites(check *Check) (reasons []string) {
+	for _, id := range check.DependsOn {
+		prerequisite := getCheckByID(id)
+		if prerequisite == nil {
+			continue
+		}
+
+		switch prerequisite.Result.String() {
+		case CheckResultFailed:
+			reasons = append(reasons, fmt.Sprintf("prerequisite check %s failed", id))
+		case CheckResultError:
+			reasons = append(reasons, fmt.Sprintf("prerequisite check %s errored", id))
+		}
+	}
+
+	return reasons
+}
+
+// getCheckByID returns the check with the given ID or nil if it doesn't exist.
+func getCheckByID(id string) *Check {
+	for _, group := range dbGroups {
+		for _, check := range group.checks {
+			if check.ID == id {
+				return check
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package checksdb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDependsOn(t *testing.T) {
+	check := NewCheck("myID", []string{"label1"}).WithDependsOn("check-1", "check-2")
+	assert.Equal(t, []string{"check-1", "check-2"}, check.DependsOn)
+	assert.Equal(t, []string{"check-1", "check-2"}, check.RunAfter)
+
+	// The modifier is a no-op on checks with errors.
+	errCheck := NewCheck("errID", nil)
+	errCheck.Error = errors.New("some error")
+	errCheck.WithDependsOn("check-1")
+	assert.Empty(t, errCheck.DependsOn)
+	assert.Empty(t, errCheck.RunAfter)
+}
+
+func TestRunChecksSkipsDependentsOfFailedChecks(t *testing.T) {
+	dependentID := resetDBStateForRun(t, identifiers.TestOperatorCrdSchemaIdentifier)[0]
+	dependentsRan := map[string]bool{}
+	dependentCheckFn := func(c *Check) error {
+		dependentsRan[c.ID] = true
+		return passingCheckFn(c)
+	}
+
+	operators := NewChecksGroup("operators")
+	// Registered before its prerequisite, which must run first anyway.
+	operators.Add(NewCheck(dependentID, []string{"test"}).WithDependsOn("install-status").WithCheckFn(dependentCheckFn))
+	operators.Add(NewCheck("install-status", []string{"test"}).WithCheckFn(failingCheckFn))
+	operators.Add(NewCheck("semantic-versioning", []string{"test"}).WithDependsOn("olm-subscription").WithCheckFn(dependentCheckFn))
+	operators.Add(NewCheck("olm-subscription", []string{"test"}).WithCheckFn(passingCheckFn))
+
+	services := NewChecksGroup("services")
+	services.Add(NewCheck("tls-version", []string{"test"}).WithDependsOn("install-status", "unknown-check").WithCheckFn(dependentCheckFn))
+
+	plan, err := planExecution(dbGroups)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"operators", "services"}, groupNames(plan.groups))
+
+	_, errs := runGroups(plan, nil, nil)
+	assert.Empty(t, errs)
+
+	assert.Equal(t, map[string]bool{"semantic-versioning": true}, dependentsRan)
+	for _, check := range []*Check{operators.checks[1], services.checks[0]} {
+		assert.Equal(t, CheckResultSkipped, check.Result.String())
+		assert.Equal(t, "prerequisite check install-status failed", check.skipReason)
+	}
+
+	require.Contains(t, resultsDB, dependentID)
+	assert.Equal(t, CheckResultSkipped, resultsDB[dependentID].State)
+	assert.Equal(t, "prerequisite check install-status failed", resultsDB[dependentID].SkipReason)
+}
+
+func TestFailedPrerequisites(t *testing.T) {
+	saveAndResetDBState(t)
+
+	group := NewChecksGroup("group")
+	failed := NewCheck("failed", nil)
+	failed.Result = CheckResultFailed
+	errored := NewCheck("errored", nil)
+	errored.SetResultError("panic")
+	skipped := NewCheck("skipped", nil)
+	skipped.SetResultSkipped("no pods")
+	group.Add(failed)
+	group.Add(errored)
+	group.Add(skipped)
+
+	check := NewCheck("dependent", nil).WithDependsOn("failed", "errored", "skipped", "unknown")
+	assert.Equal(t, []string{
+		"prerequisite check failed failed",
+		"prerequisite check errored errored",
+	}, failedPrerequisites(check))
+}
@@ -0,0 +1,180 @@
+package checksdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+)
+
+// Types of the run events.
+const (
+	EventRunStarted        = "run-started"
+	EventDiscoveryFinished = "discovery-finished"
+	EventCheckStarted      = "check-started"
+	EventCheckSkipped      = "check-skipped"
+	EventCheckPassed       = "check-passed"
+	EventCheckFailed       = "check-failed"
+	EventCheckErrored      = "check-errored"
+	EventCheckAborted      = "check-aborted"
+	EventRunFinished       = "run-finished"
+)
+
+const eventsFilePermissions = 0o644
+
+var (
+	eventsLock sync.Mutex
+	eventsFile *os.File
+	eventsSeq  int
+)
+
+// Event is a line of the events file, telling the progress of the run.
+type Event struct {
+	// Sequence number of the event in the run, from 1.
+	Seq  int       `json:"seq"`
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+
+	// Check events fields. The state is the check result, which tells apart e.g. the checks that
+	// passed after a retry.
+	CheckID    string `json:"checkId,omitempty"`
+	Suite      string `json:"suite,omitempty"`
+	State      string `json:"state,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	DurationMs int64  `json:"durationMs,omitempty"`
+
+	// Labels filter of the run started event.
+	LabelsFilter string `json:"labelsFilter,omitempty"`
+	// Number of objects found by kind, of the discovery finished event.
+	Discovered map[string]int `json:"discovered,omitempty"`
+	// Number of checks by result, of the run finished event.
+	Summary map[string]int `json:"summary,omitempty"`
+}
+
+// OpenEventsFile makes the run events to be appended to the events file, one JSON object per line,
+// as soon as they happen. The file is truncated.
+func OpenEventsFile(fileName string) error {
+	eventsLock.Lock()
+	defer eventsLock.Unlock()
+
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, eventsFilePermissions)
+	if err != nil {
+		return fmt.Errorf("could not open events file %s: %w", fileName, err)
+	}
+
+	eventsFile = f
+	eventsSeq = 0
+	return nil
+}
+
+// CloseEventsFile stops saving the run events to the events file.
+func CloseEventsFile() error {
+	eventsLock.Lock()
+	defer eventsLock.Unlock()
+
+	if eventsFile == nil {
+		return nil
+	}
+
+	err := eventsFile.Close()
+	eventsFile = nil
+	return err
+}
+
+// emitEvent appends the event to the events file, if enabled.
+func emitEvent(event Event) {
+	eventsLock.Lock()
+	defer eventsLock.Unlock()
+
+	if eventsFile == nil {
+		return
+	}
+
+	eventsSeq++
+	event.Seq = eventsSeq
+	event.Time = time.Now().UTC()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		log.Error("Could not marshal the %s event: %v", event.Type, err)
+		return
+	}
+
+	if _, err := eventsFile.Write(append(line, '\n')); err != nil {
+		log.Error("Could not save the %s event: %v", event.Type, err)
+	}
+}
+
+// EmitRunStarted emits the event of the start of the run, before the discovery.
+func EmitRunStarted(labelsFilter string) {
+	emitEvent(Event{Type: EventRunStarted, LabelsFilter: labelsFilter})
+}
+
+// EmitDiscoveryFinished emits the event of the end of the discovery, with the number of objects
+// found by kind.
+func EmitDiscoveryFinished(discovered map[string]int) {
+	emitEvent(Event{Type: EventDiscoveryFinished, Discovered: discovered})
+}
+
+func getCheckSuite(check *Check) string {
+	if claimID, found := identifiers.TestIDToClaimID[check.ID]; found {
+		return claimID.Suite
+	}
+	return ""
+}
+
+func emitCheckStarted(check *Check) {
+	emitEvent(Event{Type: EventCheckStarted, CheckID: check.ID, Suite: getCheckSuite(check)})
+}
+
+// emitCheckFinished emits the event of the check's result.
+func emitCheckFinished(check *Check) {
+	event := Event{
+		CheckID: check.ID,
+		Suite:   getCheckSuite(check),
+		State:   check.Result.String(),
+		Reason:  check.skipReason,
+	}
+
+	switch check.Result {
+	case CheckResultPassed, CheckResultPassedAfterRetry, CheckResultPassedWithWaivers:
+		event.Type = EventCheckPassed
+	case CheckResultFailed:
+		event.Type = EventCheckFailed
+	case CheckResultSkipped:
+		event.Type = EventCheckSkipped
+	case CheckResultAborted:
+		event.Type = EventCheckAborted
+	case CheckResultError:
+		event.Type = EventCheckErrored
+	default:
+		return
+	}
+
+	if !check.StartTime.IsZero() && check.EndTime.After(check.StartTime) {
+		event.DurationMs = check.EndTime.Sub(check.StartTime).Milliseconds()
+	}
+
+	check.resultEmitted = true
+	emitEvent(event)
+}
+
+// emitRunFinished emits the event of the end of the checks run, with the number of checks of each
+// result, the ones not matching the labels filter left out.
+func emitRunFinished() {
+	summary := map[string]int{}
+	for _, group := range dbGroups {
+		for _, check := range group.checks {
+			if labelsExprEvaluator != nil && !labelsExprEvaluator.Eval(check.Labels) {
+				continue
+			}
+			summary[check.Result.String()]++
+		}
+	}
+
+	emitEvent(Event{Type: EventRunFinished, Summary: summary})
+}
@@ -0,0 +1,147 @@
+package checksdb
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEventsFile(t *testing.T, fileName string) []Event {
+	t.Helper()
+
+	f, err := os.Open(fileName)
+	require.NoError(t, err)
+	defer f.Close()
+
+	events := []Event{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		event := Event{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+
+	return events
+}
+
+func TestRunEvents(t *testing.T) {
+	passedID := resetDBStateForRun(t, identifiers.TestICMPv4ConnectivityIdentifier)[0]
+
+	eventsFile := filepath.Join(t.TempDir(), "events.jsonl")
+	require.NoError(t, OpenEventsFile(eventsFile))
+	EmitRunStarted("test")
+	EmitDiscoveryFinished(map[string]int{"pods": 2})
+
+	group := NewChecksGroup("networking")
+	group.Add(NewCheck(passedID, []string{"test"}).WithCheckFn(func(*Check) error { return nil }))
+	group.Add(NewCheck("failed-check", []string{"test"}).WithCheckFn(func(check *Check) error {
+		check.SetResult(nil, []*testhelper.ReportObject{testhelper.NewPodReportObject("ns", "pod", "reason", false)})
+		return nil
+	}))
+	group.Add(NewCheck("skipped-check", []string{"test"}).WithSkipCheckFn(func() (bool, string) { return true, "not applicable" }))
+	// Not matching the labels filter: no events.
+	group.Add(NewCheck("filtered-check", []string{"other"}))
+
+	_, errs := runGroups(executionPlan{groups: dbGroups}, nil, nil)
+	assert.Empty(t, errs)
+	emitRunFinished()
+	require.NoError(t, CloseEventsFile())
+
+	events := readEventsFile(t, eventsFile)
+	types := []string{}
+	for i, event := range events {
+		assert.Equal(t, i+1, event.Seq)
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{
+		EventRunStarted, EventDiscoveryFinished,
+		EventCheckStarted, EventCheckPassed,
+		EventCheckStarted, EventCheckFailed,
+		EventCheckSkipped,
+		EventRunFinished,
+	}, types)
+
+	assert.Equal(t, "test", events[0].LabelsFilter)
+	assert.Equal(t, map[string]int{"pods": 2}, events[1].Discovered)
+	assert.Equal(t, passedID, events[3].CheckID)
+	assert.Equal(t, "networking", events[3].Suite)
+	assert.Equal(t, CheckResultPassed, events[3].State)
+	assert.Equal(t, "not applicable", events[6].Reason)
+	assert.Equal(t, map[string]int{CheckResultPassed: 1, CheckResultFailed: 1, CheckResultSkipped: 1}, events[7].Summary)
+}
+
+func TestRunEventsAfterAbort(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setGroupsConcurrencyForTest(t, 3)
+	setAbortGracePeriodForTest(t, 500*time.Millisecond)
+
+	eventsFile := filepath.Join(t.TempDir(), "events.jsonl")
+	require.NoError(t, OpenEventsFile(eventsFile))
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	passingCheckFn := func(*Check) error { return nil }
+
+	// Finishes in the grace period.
+	finishing := NewChecksGroup("finishing").WithAfterAllFn(func([]*Check) error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+	finishing.Add(NewCheck("finished-check", []string{"test"}).WithCheckFn(passingCheckFn))
+	// Stops in the grace period.
+	running := NewChecksGroup("running")
+	running.Add(NewCheck("passed-check", []string{"test"}).WithCheckFn(passingCheckFn))
+	running.Add(NewCheck("running-check", []string{"test"}).WithCheckFn(func(*Check) error {
+		<-release
+		return nil
+	}))
+	running.Add(NewCheck("not-run-check", []string{"test"}))
+	// Still running after the grace period.
+	stuck := NewChecksGroup("stuck").WithAfterEachFn(func(*Check) error {
+		<-release
+		return nil
+	})
+	stuck.Add(NewCheck("stuck-check", []string{"test"}).WithCheckFn(passingCheckFn))
+
+	timeOutChan := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		timeOutChan <- time.Now()
+	}()
+
+	_, errs := runGroups(executionPlan{groups: []*ChecksGroup{finishing, running, stuck}}, timeOutChan, nil)
+	assert.Empty(t, errs)
+	require.NoError(t, CloseEventsFile())
+
+	finishedEvents := map[string][]string{}
+	for _, event := range readEventsFile(t, eventsFile) {
+		if event.CheckID != "" && event.Type != EventCheckStarted {
+			finishedEvents[event.CheckID] = append(finishedEvents[event.CheckID], event.Type)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"finished-check": {EventCheckPassed},
+		"passed-check":   {EventCheckPassed},
+		"running-check":  {EventCheckAborted},
+		"not-run-check":  {EventCheckSkipped},
+		"stuck-check":    {EventCheckPassed},
+	}, finishedEvents)
+}
+
+func TestEmitEventWithoutEventsFile(t *testing.T) {
+	require.NoError(t, CloseEventsFile())
+
+	// Nothing is saved, and it doesn't fail.
+	EmitRunStarted("all")
+	emitCheckStarted(NewCheck("check", []string{"test"}))
+}
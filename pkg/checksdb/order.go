@@ -0,0 +1,291 @@
+package checksdb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+)
+
+// GroupsOrderWildcard refers to all the groups not explicitly listed in the groups order.
+const GroupsOrderWildcard = "*"
+
+var (
+	groupsOrder []string
+
+	executionOrderLock sync.Mutex
+	executionOrder     ExecutionOrder
+)
+
+// ExecutionOrder holds the order in which the groups were started and their checks were run or
+// skipped in the last run.
+type ExecutionOrder struct {
+	Groups []string `json:"groups"`
+	Checks []string `json:"checks"`
+}
+
+// executionPlan holds the groups sorted in execution order along with the groups each one must
+// wait for before starting.
+type executionPlan struct {
+	groups   []*ChecksGroup
+	runAfter map[*ChecksGroup][]*ChecksGroup
+}
+
+// WithRunAfter makes the group wait for the given groups to finish before running its checks.
+// Names of groups that don't exist are ignored.
+func (group *ChecksGroup) WithRunAfter(groupNames ...string) *ChecksGroup {
+	group.runAfter = append(group.runAfter, groupNames...)
+
+	return group
+}
+
+// WithRunAfter makes the check run after the given checks. When any of them belongs to another
+// group, the whole check's group runs after that group. IDs of checks that don't exist are ignored.
+func (check *Check) WithRunAfter(checkIDs ...string) *Check {
+	if check.Error != nil {
+		return check
+	}
+
+	check.RunAfter = append(check.RunAfter, checkIDs...)
+	return check
+}
+
+// SetGroupsOrder sets the order in which the groups run. Every group waits for the groups listed
+// before it to finish. The GroupsOrderWildcard stands for the groups that are not in the list,
+// which are placed at the end when it's missing. E.g. "*,lifecycle" runs lifecycle last.
+// The order is validated against the registered groups and their ordering constraints.
+func SetGroupsOrder(order []string) error {
+	dbLock.Lock()
+	defer dbLock.Unlock()
+
+	groupsOrder = nil
+	for _, name := range order {
+		if name = strings.TrimSpace(name); name != "" {
+			groupsOrder = append(groupsOrder, name)
+		}
+	}
+
+	_, err := planExecution(dbGroups)
+	return err
+}
+
+// GetExecutionOrder returns the order in which the groups and checks ran in the last run.
+func GetExecutionOrder() ExecutionOrder {
+	executionOrderLock.Lock()
+	defer executionOrderLock.Unlock()
+
+	return ExecutionOrder{
+		Groups: append([]string{}, executionOrder.Groups...),
+		Checks: append([]string{}, executionOrder.Checks...),
+	}
+}
+
+func resetExecutionOrder() {
+	executionOrderLock.Lock()
+	defer executionOrderLock.Unlock()
+
+	executionOrder = ExecutionOrder{}
+}
+
+func recordGroupExecution(group *ChecksGroup) {
+	executionOrderLock.Lock()
+	defer executionOrderLock.Unlock()
+
+	executionOrder.Groups = append(executionOrder.Groups, group.name)
+}
+
+func recordCheckExecution(check *Check) {
+	executionOrderLock.Lock()
+	defer executionOrderLock.Unlock()
+
+	executionOrder.Checks = append(executionOrder.Checks, check.ID)
+}
+
+// planExecution sorts the groups, and the checks inside each group, so that the ordering
+// constraints and the groups order are honored. Otherwise, the registration order is kept.
+// Returns an error in case the constraints have a cycle or the groups order has unknown groups.
+func planExecution(groups []*ChecksGroup) (executionPlan, error) {
+	groupsByName := map[string]*ChecksGroup{}
+	groupByCheckID := map[string]*ChecksGroup{}
+	checksByID := map[string]*Check{}
+	for _, group := range groups {
+		groupsByName[group.name] = group
+		for _, check := range group.checks {
+			groupByCheckID[check.ID] = group
+			checksByID[check.ID] = check
+		}
+	}
+
+	runAfter := map[*ChecksGroup][]*ChecksGroup{}
+	addGroupDependency := func(group, dependency *ChecksGroup) {
+		for _, existing := range runAfter[group] {
+			if existing == dependency {
+				return
+			}
+		}
+		runAfter[group] = append(runAfter[group], dependency)
+	}
+
+	for _, group := range groups {
+		for _, name := range group.runAfter {
+			dependency, found := groupsByName[name]
+			if !found {
+				log.Debug("Group %s runs after unknown group %s, ignoring it.", group.name, name)
+				continue
+			}
+			addGroupDependency(group, dependency)
+		}
+
+		checksRunAfter := map[*Check][]*Check{}
+		for _, check := range group.checks {
+			for _, id := range check.RunAfter {
+				dependencyGroup, found := groupByCheckID[id]
+				switch {
+				case !found:
+					log.Debug("Check %s runs after unknown check %s, ignoring it.", check.ID, id)
+				case dependencyGroup == group:
+					checksRunAfter[check] = append(checksRunAfter[check], checksByID[id])
+				default:
+					addGroupDependency(group, dependencyGroup)
+				}
+			}
+		}
+
+		sortedChecks, err := sortByDependencies(group.checks, checksRunAfter, func(check *Check) string { return check.ID })
+		if err != nil {
+			return executionPlan{}, fmt.Errorf("invalid order of the checks in group %s: %w", group.name, err)
+		}
+		group.checks = sortedChecks
+	}
+
+	ranks, err := groupsOrderRanks(groups)
+	if err != nil {
+		return executionPlan{}, err
+	}
+	for _, group := range groups {
+		for _, other := range groups {
+			if ranks[other] < ranks[group] {
+				addGroupDependency(group, other)
+			}
+		}
+	}
+
+	sortedGroups, err := sortByDependencies(groups, runAfter, func(group *ChecksGroup) string { return group.name })
+	if err != nil {
+		return executionPlan{}, fmt.Errorf("invalid order of the groups: %w", err)
+	}
+
+	return executionPlan{groups: sortedGroups, runAfter: runAfter}, nil
+}
+
+// groupsOrderRanks returns the position of each group in the groups order. Groups with a lower
+// rank must finish before the ones with a higher rank start.
+func groupsOrderRanks(groups []*ChecksGroup) (map[*ChecksGroup]int, error) {
+	wildcardRank := len(groupsOrder)
+	listedRanks := map[string]int{}
+	for i, name := range groupsOrder {
+		if name == GroupsOrderWildcard {
+			wildcardRank = i
+			continue
+		}
+		if _, found := listedRanks[name]; found {
+			return nil, fmt.Errorf("group %s appears more than once in the groups order", name)
+		}
+		listedRanks[name] = i
+	}
+
+	ranks := map[*ChecksGroup]int{}
+	for _, group := range groups {
+		rank, found := listedRanks[group.name]
+		if !found {
+			rank = wildcardRank
+		}
+		ranks[group] = rank
+		delete(listedRanks, group.name)
+	}
+
+	if len(listedRanks) > 0 {
+		unknown := []string{}
+		for _, name := range groupsOrder {
+			if _, found := listedRanks[name]; found {
+				unknown = append(unknown, name)
+			}
+		}
+		return nil, fmt.Errorf("unknown groups in the groups order: %s", strings.Join(unknown, ", "))
+	}
+
+	return ranks, nil
+}
+
+// sortByDependencies returns the items sorted so that each one comes after the items it depends on.
+// Items keep their original order unless a dependency forces to change it. Returns an error with
+// the items that form a cycle, if any.
+func sortByDependencies[T comparable](items []T, dependencies map[T][]T, name func(T) string) ([]T, error) {
+	sorted := make([]T, 0, len(items))
+	placed := map[T]bool{}
+
+	isReady := func(item T) bool {
+		for _, dependency := range dependencies[item] {
+			if !placed[dependency] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for len(sorted) < len(items) {
+		progress := false
+		for _, item := range items {
+			if placed[item] || !isReady(item) {
+				continue
+			}
+			sorted = append(sorted, item)
+			placed[item] = true
+			progress = true
+			// Start over so the first items in the original order always go first.
+			break
+		}
+
+		if !progress {
+			return nil, errors.New("cycle found: " + findCycle(items, dependencies, placed, name))
+		}
+	}
+
+	return sorted, nil
+}
+
+// findCycle returns the description of a cycle among the items that could not be placed. Each of
+// them depends on at least another one that couldn't be placed either, so following them always
+// ends up in a cycle.
+func findCycle[T comparable](items []T, dependencies map[T][]T, placed map[T]bool, name func(T) string) string {
+	var item T
+	for _, item = range items {
+		if !placed[item] {
+			break
+		}
+	}
+
+	path := []T{}
+	pathIndex := map[T]int{}
+	for {
+		if idx, found := pathIndex[item]; found {
+			names := []string{}
+			for _, pathItem := range path[idx:] {
+				names = append(names, name(pathItem))
+			}
+			names = append(names, name(item))
+			return strings.Join(names, " runs after ")
+		}
+
+		pathIndex[item] = len(path)
+		path = append(path, item)
+		for _, dependency := range dependencies[item] {
+			if !placed[dependency] {
+				item = dependency
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package checksdb
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func groupNames(groups []*ChecksGroup) []string {
+	names := []string{}
+	for _, group := range groups {
+		names = append(names, group.name)
+	}
+	return names
+}
+
+func checkIDs(checks []*Check) []string {
+	ids := []string{}
+	for _, check := range checks {
+		ids = append(ids, check.ID)
+	}
+	return ids
+}
+
+func TestPlanExecutionGroups(t *testing.T) {
+	testCases := []struct {
+		name           string
+		groupsOrder    []string
+		setup          func(groups map[string]*ChecksGroup)
+		expectedGroups []string
+		expectedErr    string
+	}{
+		{
+			name:           "registration order",
+			expectedGroups: []string{"access-control", "lifecycle", "networking", "observability"},
+		},
+		{
+			name: "group constraint",
+			setup: func(groups map[string]*ChecksGroup) {
+				groups["lifecycle"].WithRunAfter("observability")
+			},
+			expectedGroups: []string{"access-control", "networking", "observability", "lifecycle"},
+		},
+		{
+			name: "unknown groups in constraints are ignored",
+			setup: func(groups map[string]*ChecksGroup) {
+				groups["access-control"].WithRunAfter("preflight")
+			},
+			expectedGroups: []string{"access-control", "lifecycle", "networking", "observability"},
+		},
+		{
+			name: "check constraint on a check from another group",
+			setup: func(groups map[string]*ChecksGroup) {
+				groups["access-control"].checks[0].WithRunAfter("networking-check-1")
+			},
+			expectedGroups: []string{"lifecycle", "networking", "access-control", "observability"},
+		},
+		{
+			name:           "groups order with wildcard",
+			groupsOrder:    []string{"*", "lifecycle"},
+			expectedGroups: []string{"access-control", "networking", "observability", "lifecycle"},
+		},
+		{
+			name:           "groups order without wildcard",
+			groupsOrder:    []string{"observability", "networking"},
+			expectedGroups: []string{"observability", "networking", "access-control", "lifecycle"},
+		},
+		{
+			name: "cycle between groups",
+			setup: func(groups map[string]*ChecksGroup) {
+				groups["lifecycle"].WithRunAfter("networking")
+				groups["networking"].WithRunAfter("observability")
+				groups["observability"].WithRunAfter("lifecycle")
+			},
+			expectedErr: "invalid order of the groups: cycle found: lifecycle runs after networking runs after observability runs after lifecycle",
+		},
+		{
+			name:        "groups order contradicts a constraint",
+			groupsOrder: []string{"*", "lifecycle"},
+			setup: func(groups map[string]*ChecksGroup) {
+				groups["networking"].WithRunAfter("lifecycle")
+			},
+			expectedErr: "cycle found",
+		},
+		{
+			name:        "unknown groups in the groups order",
+			groupsOrder: []string{"lifecycle", "unknown-1", "*", "unknown-2"},
+			expectedErr: "unknown groups in the groups order: unknown-1, unknown-2",
+		},
+		{
+			name:        "duplicated group in the groups order",
+			groupsOrder: []string{"lifecycle", "*", "lifecycle"},
+			expectedErr: "group lifecycle appears more than once in the groups order",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			saveAndResetDBState(t)
+
+			groups := map[string]*ChecksGroup{}
+			for _, name := range []string{"access-control", "lifecycle", "networking", "observability"} {
+				groups[name] = NewChecksGroup(name)
+				groups[name].Add(NewCheck(name+"-check-1", []string{"test"}))
+			}
+			if tc.setup != nil {
+				tc.setup(groups)
+			}
+
+			err := SetGroupsOrder(tc.groupsOrder)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			plan, err := planExecution(dbGroups)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGroups, groupNames(plan.groups))
+		})
+	}
+}
+
+func TestPlanExecutionChecks(t *testing.T) {
+	saveAndResetDBState(t)
+
+	group := NewChecksGroup("lifecycle")
+	group.Add(NewCheck("check-1", []string{"test"}).WithRunAfter("check-3"))
+	group.Add(NewCheck("check-2", []string{"test"}))
+	group.Add(NewCheck("check-3", []string{"test"}).WithRunAfter("check-4", "unknown-check"))
+	group.Add(NewCheck("check-4", []string{"test"}))
+
+	_, err := planExecution(dbGroups)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"check-2", "check-4", "check-3", "check-1"}, checkIDs(group.checks))
+
+	// A check that runs after itself.
+	group.Add(NewCheck("check-5", []string{"test"}).WithRunAfter("check-5"))
+	_, err = planExecution(dbGroups)
+	assert.EqualError(t, err, "invalid order of the checks in group lifecycle: cycle found: check-5 runs after check-5")
+}
+
+func TestRunGroupsHonorsConstraints(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setGroupsConcurrencyForTest(t, 3)
+
+	slowGroupDone := atomic.Bool{}
+	slowGroupDoneBeforeLast := atomic.Bool{}
+
+	slow := NewChecksGroup("slow")
+	slow.Add(NewCheck("slow-check", []string{"test"}).WithCheckFn(func(*Check) error {
+		time.Sleep(100 * time.Millisecond)
+		slowGroupDone.Store(true)
+		return nil
+	}))
+	last := NewChecksGroup("last")
+	last.Add(NewCheck("last-check", []string{"test"}).WithCheckFn(func(*Check) error {
+		slowGroupDoneBeforeLast.Store(slowGroupDone.Load())
+		return nil
+	}))
+	noOpCheckFn := func(*Check) error { return nil }
+	fast := NewChecksGroup("fast")
+	fast.Add(NewCheck("fast-check-2", []string{"test"}).WithRunAfter("fast-check-1").WithCheckFn(noOpCheckFn))
+	fast.Add(NewCheck("fast-check-1", []string{"test"}).WithCheckFn(noOpCheckFn))
+
+	require.NoError(t, SetGroupsOrder([]string{"*", "last"}))
+	plan, err := planExecution(dbGroups)
+	require.NoError(t, err)
+
+	resetExecutionOrder()
+	_, errs := runGroups(plan, nil, nil)
+	assert.Empty(t, errs)
+	assert.True(t, slowGroupDoneBeforeLast.Load())
+
+	order := GetExecutionOrder()
+	assert.Equal(t, []string{"slow", "fast", "last"}, order.Groups)
+	require.Len(t, order.Checks, 4)
+	assert.Equal(t, "last-check", order.Checks[3])
+	assert.Less(t, indexOf(order.Checks, "fast-check-1"), indexOf(order.Checks, "fast-check-2"))
+}
+
+func indexOf(items []string, item string) int {
+	for i := range items {
+		if items[i] == item {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,65 @@
+package checksdb
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Requirement is a capability of the environment that a check needs in order to run.
+type Requirement string
+
+const (
+	// RequireProbe is set on checks that run commands through the probe daemonset pods.
+	RequireProbe Requirement = "probe"
+	// RequireExec is set on checks that exec commands in the containers under test.
+	RequireExec Requirement = "exec"
+)
+
+var (
+	requirementsLock        sync.Mutex
+	unavailableRequirements = map[Requirement]string{}
+)
+
+// WithRequirements declares the environment capabilities the check needs. The check is
+// skipped when any of them has been flagged as unavailable with SetRequirementsUnavailable.
+func (check *Check) WithRequirements(reqs ...Requirement) *Check {
+	if check.Error != nil {
+		return check
+	}
+
+	check.Requirements = append(check.Requirements, reqs...)
+	return check
+}
+
+// SetRequirementsUnavailable flags the given requirements as not available in the current
+// run, so every check that needs any of them will be skipped with the given reason.
+func SetRequirementsUnavailable(reason string, reqs ...Requirement) {
+	requirementsLock.Lock()
+	defer requirementsLock.Unlock()
+
+	for _, req := range reqs {
+		unavailableRequirements[req] = reason
+	}
+}
+
+// ResetRequirements flags all the requirements as available again.
+func ResetRequirements() {
+	requirementsLock.Lock()
+	defer requirementsLock.Unlock()
+
+	unavailableRequirements = map[Requirement]string{}
+}
+
+// missingRequirements returns the reasons for the check's requirements that are not available.
+func missingRequirements(check *Check) (reasons []string) {
+	requirementsLock.Lock()
+	defer requirementsLock.Unlock()
+
+	for _, req := range check.Requirements {
+		if reason, found := unavailableRequirements[req]; found {
+			reasons = append(reasons, fmt.Sprintf("requires %s: unavailable in %s", req, reason))
+		}
+	}
+
+	return reasons
+}
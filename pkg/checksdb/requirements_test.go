@@ -0,0 +1,71 @@
+package checksdb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequirements(t *testing.T) {
+	check := NewCheck("myID", []string{"label1"}).WithRequirements(RequireProbe, RequireExec)
+	assert.Equal(t, []Requirement{RequireProbe, RequireExec}, check.Requirements)
+
+	// The modifier is a no-op on checks with errors.
+	errCheck := NewCheck("errID", nil)
+	errCheck.Error = errors.New("some error")
+	errCheck.WithRequirements(RequireProbe)
+	assert.Empty(t, errCheck.Requirements)
+}
+
+func TestMissingRequirements(t *testing.T) {
+	t.Cleanup(ResetRequirements)
+
+	check := NewCheck("myID", nil).WithRequirements(RequireProbe, RequireExec)
+	assert.Empty(t, missingRequirements(check))
+
+	SetRequirementsUnavailable("manifest mode", RequireExec)
+	assert.Equal(t, []string{"requires exec: unavailable in manifest mode"}, missingRequirements(check))
+
+	ResetRequirements()
+	assert.Empty(t, missingRequirements(check))
+}
+
+func TestRunChecksSkipsUnavailableRequirements(t *testing.T) {
+	saveAndResetDBState(t)
+	t.Cleanup(ResetRequirements)
+
+	err := InitLabelsExprEvaluator("test")
+	require.NoError(t, err)
+
+	SetRequirementsUnavailable("manifest mode", RequireProbe)
+
+	group := NewChecksGroup("requirements-test")
+
+	probeCheck := NewCheck("probe-check", []string{"test"}).
+		WithRequirements(RequireProbe).
+		WithCheckFn(func(c *Check) error {
+			t.Fatal("check with unavailable requirements should not run")
+			return nil
+		})
+	group.Add(probeCheck)
+
+	ran := false
+	staticCheck := NewCheck("static-check", []string{"test"}).
+		WithCheckFn(func(c *Check) error {
+			ran = true
+			return nil
+		})
+	group.Add(staticCheck)
+
+	stopChan := make(chan bool, 1)
+	abortChan := make(chan string, 1)
+
+	errs, _ := group.RunChecks(stopChan, abortChan)
+	assert.Empty(t, errs)
+	assert.Equal(t, CheckResultSkipped, probeCheck.Result.String())
+	assert.Equal(t, "requires probe: unavailable in manifest mode", probeCheck.skipReason)
+	assert.True(t, ran)
+	assert.Equal(t, CheckResultPassed, staticCheck.Result.String())
+}
@@ -0,0 +1,159 @@
+package checksdb
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+)
+
+// RetryPolicy sets how many times a failed check runs again and how long to wait between attempts.
+type RetryPolicy struct {
+	Retries int
+	Backoff time.Duration
+}
+
+var (
+	retryPoliciesLock sync.Mutex
+	// Retry policies set in the configuration, by check ID. They take precedence over the checks' own.
+	retryPolicyOverrides = map[string]RetryPolicy{}
+)
+
+// WithRetries makes the check run again, up to the given number of times, whenever it fails,
+// waiting for the backoff duration before each new attempt. It's meant for checks that may
+// fail because of transient issues, like network glitches. The logs of every attempt are kept,
+// and a check that passes after failing is marked as "passed-after-retry".
+func (check *Check) WithRetries(retries int, backoff time.Duration) *Check {
+	if check.Error != nil {
+		return check
+	}
+
+	check.RetryPolicy = RetryPolicy{Retries: retries, Backoff: backoff}
+	return check
+}
+
+// SetRetryPolicyOverrides sets the retry policies, by check ID, used instead of the checks' own.
+func SetRetryPolicyOverrides(policies map[string]RetryPolicy) {
+	retryPoliciesLock.Lock()
+	defer retryPoliciesLock.Unlock()
+
+	retryPolicyOverrides = map[string]RetryPolicy{}
+	for id, policy := range policies {
+		retryPolicyOverrides[id] = policy
+	}
+}
+
+func getRetryPolicy(check *Check) RetryPolicy {
+	retryPoliciesLock.Lock()
+	defer retryPoliciesLock.Unlock()
+
+	if policy, found := retryPolicyOverrides[check.ID]; found {
+		return policy
+	}
+	return check.RetryPolicy
+}
+
+// runWithRetries runs the check, running it again while it fails or times out and its retry policy
+// allows. The result and duration of every attempt are added to the check's details.
+func runWithRetries(check *Check) error {
+	policy := getRetryPolicy(check)
+	attempts := policy.Retries + 1
+
+	var startTime time.Time
+	attemptsDetails := []*testhelper.CheckAttempt{}
+	defer func() {
+		// The check's duration includes all the attempts.
+		check.StartTime = startTime
+		if len(attemptsDetails) > 1 {
+			check.addAttemptsDetails(attemptsDetails)
+		}
+	}()
+
+	for attempt := 1; ; attempt++ {
+		if attempts > 1 {
+			check.LogInfo("Attempt %d of %d", attempt, attempts)
+		}
+
+		err := check.Run()
+		if attempt == 1 {
+			startTime = check.StartTime
+		}
+		timedOut := errors.Is(err, ErrCheckTimedOut)
+		if err != nil && !timedOut {
+			return err
+		}
+
+		attemptsDetails = append(attemptsDetails, &testhelper.CheckAttempt{
+			Result:     check.Result.String(),
+			DurationMs: check.EndTime.Sub(check.StartTime).Milliseconds(),
+		})
+
+		if !timedOut && check.Result != CheckResultFailed {
+			if attempt > 1 && check.Result == CheckResultPassed {
+				check.LogInfo("Check passed after %d failed attempts", attempt-1)
+				check.Result = CheckResultPassedAfterRetry
+			}
+			return nil
+		}
+
+		if attempt >= attempts {
+			return err
+		}
+
+		if timedOut {
+			check.LogWarn("Attempt %d of %d failed: %v", attempt, attempts, err)
+			// The check functions of the next attempt can't run along with the abandoned ones.
+			if !waitForAbandonedFns(check) {
+				check.LogWarn("The check functions of attempt %d are still running, not retrying", attempt)
+				return err
+			}
+		} else {
+			check.LogWarn("Attempt %d of %d failed with details: %s", attempt, attempts, check.details)
+		}
+
+		check.LogInfo("Retrying in %v", policy.Backoff)
+		select {
+		case <-time.After(policy.Backoff):
+		case <-check.stopChan:
+			return errCheckStopped
+		}
+		check.resetResult()
+	}
+}
+
+// waitForAbandonedFns waits for the check functions abandoned after a timeout, whose context was
+// cancelled, to return, for up to the check's timeout. Returns whether they did.
+func waitForAbandonedFns(check *Check) bool {
+	select {
+	case <-check.fnsDone:
+		return true
+	case <-time.After(getCheckTimeout(check)):
+		return false
+	case <-check.stopChan:
+		return false
+	}
+}
+
+// addAttemptsDetails adds the attempts of the check to its details.
+func (check *Check) addAttemptsDetails(attempts []*testhelper.CheckAttempt) {
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+
+	details := testhelper.FailureReasonOut{}
+	if check.details != "" {
+		if err := json.Unmarshal([]byte(check.details), &details); err != nil {
+			check.LogError("Failed to add the attempts to the details of check %s: %v", check.ID, err)
+			return
+		}
+	}
+
+	details.Attempts = attempts
+	bytes, err := json.Marshal(details)
+	if err != nil {
+		check.LogError("Failed to add the attempts to the details of check %s: %v", check.ID, err)
+		return
+	}
+	check.details = string(bytes)
+}
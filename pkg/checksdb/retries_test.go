@@ -0,0 +1,181 @@
+package checksdb
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setRetryPolicyOverridesForTest(t *testing.T, policies map[string]RetryPolicy) {
+	t.Helper()
+	t.Cleanup(func() { SetRetryPolicyOverrides(nil) })
+	SetRetryPolicyOverrides(policies)
+}
+
+// failingTimesCheckFn returns a check function that fails the given number of times before passing.
+func failingTimesCheckFn(failures int, attempts *int) func(*Check) error {
+	return func(c *Check) error {
+		*attempts++
+		if *attempts <= failures {
+			return failingCheckFn(c)
+		}
+		return passingCheckFn(c)
+	}
+}
+
+func TestWithRetries(t *testing.T) {
+	check := NewCheck("myID", []string{"label1"}).WithRetries(3, time.Second)
+	assert.Equal(t, RetryPolicy{Retries: 3, Backoff: time.Second}, check.RetryPolicy)
+
+	// The modifier is a no-op on checks with errors.
+	errCheck := NewCheck("errID", nil)
+	errCheck.Error = errors.New("some error")
+	errCheck.WithRetries(3, time.Second)
+	assert.Equal(t, RetryPolicy{}, errCheck.RetryPolicy)
+}
+
+func TestRunWithRetries(t *testing.T) {
+	testCases := []struct {
+		name             string
+		failures         int
+		retries          int
+		expectedResult   string
+		expectedAttempts int
+	}{
+		{name: "passes at the first attempt", failures: 0, retries: 2, expectedResult: CheckResultPassed, expectedAttempts: 1},
+		{name: "passes after retrying", failures: 2, retries: 2, expectedResult: CheckResultPassedAfterRetry, expectedAttempts: 3},
+		{name: "fails every attempt", failures: 3, retries: 2, expectedResult: CheckResultFailed, expectedAttempts: 3},
+		{name: "no retries", failures: 1, retries: 0, expectedResult: CheckResultFailed, expectedAttempts: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			check := NewCheck("flaky-check", []string{"test"}).
+				WithRetries(tc.retries, time.Millisecond).
+				WithCheckFn(failingTimesCheckFn(tc.failures, &attempts))
+
+			require.NoError(t, runWithRetries(check))
+			assert.Equal(t, tc.expectedResult, check.Result.String())
+			assert.Equal(t, tc.expectedAttempts, attempts)
+			assert.Equal(t, tc.expectedAttempts, strings.Count(check.GetLogs(), "Running check"))
+		})
+	}
+}
+
+func TestRunWithRetriesAttemptsDetails(t *testing.T) {
+	attempts := 0
+	check := NewCheck("flaky-check", []string{"test"}).
+		WithRetries(2, time.Millisecond).
+		WithCheckFn(failingTimesCheckFn(1, &attempts))
+	require.NoError(t, runWithRetries(check))
+
+	details := testhelper.FailureReasonOut{}
+	require.NoError(t, json.Unmarshal([]byte(check.details), &details))
+	require.Len(t, details.Attempts, 2)
+	assert.Equal(t, CheckResultFailed, details.Attempts[0].Result)
+	assert.Equal(t, CheckResultPassed, details.Attempts[1].Result)
+	// The details of the last attempt are kept.
+	assert.Len(t, details.CompliantObjectsOut, 1)
+
+	// Not added to the checks that ran once.
+	check = NewCheck("check", []string{"test"}).WithRetries(2, time.Millisecond).WithCheckFn(passingCheckFn)
+	require.NoError(t, runWithRetries(check))
+	assert.NotContains(t, check.details, "Attempts")
+}
+
+func TestRunWithRetriesTimedOut(t *testing.T) {
+	disableRunningCheckLineForTest(t)
+
+	attempts := 0
+	check := NewCheck("flaky-check", []string{"test"}).
+		WithTimeout(50*time.Millisecond).
+		WithRetries(1, time.Millisecond).
+		WithCheckFn(func(c *Check) error {
+			attempts++
+			if attempts == 1 {
+				<-c.Context().Done()
+				return nil
+			}
+			return passingCheckFn(c)
+		})
+	require.NoError(t, runWithRetries(check))
+	assert.Equal(t, CheckResultPassedAfterRetry, check.Result.String())
+	assert.Equal(t, 2, attempts)
+
+	details := testhelper.FailureReasonOut{}
+	require.NoError(t, json.Unmarshal([]byte(check.details), &details))
+	require.Len(t, details.Attempts, 2)
+	assert.Equal(t, CheckResultError, details.Attempts[0].Result)
+	assert.GreaterOrEqual(t, details.Attempts[0].DurationMs, int64(50))
+
+	// The checks whose abandoned functions don't return are not retried.
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	hungAttempts := atomic.Int32{}
+	check = NewCheck("hung-check", []string{"test"}).
+		WithTimeout(50*time.Millisecond).
+		WithRetries(1, time.Millisecond).
+		WithCheckFn(func(*Check) error {
+			hungAttempts.Add(1)
+			<-release
+			return nil
+		})
+	assert.ErrorIs(t, runWithRetries(check), ErrCheckTimedOut)
+	assert.Equal(t, CheckResultError, check.Result.String())
+	assert.Equal(t, int32(1), hungAttempts.Load())
+}
+
+func TestRunWithRetriesStopsBackoffOnAbort(t *testing.T) {
+	disableRunningCheckLineForTest(t)
+
+	stopChan := make(chan bool)
+	check := NewCheck("flaky-check", []string{"test"}).WithRetries(1, time.Hour).WithCheckFn(failingCheckFn)
+	check.setStopChan(stopChan)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(stopChan)
+	}()
+
+	assert.ErrorIs(t, runWithRetries(check), errCheckStopped)
+}
+
+func TestRunWithRetriesOverrides(t *testing.T) {
+	setRetryPolicyOverridesForTest(t, map[string]RetryPolicy{"flaky-check": {Retries: 1, Backoff: time.Millisecond}})
+
+	attempts := 0
+	check := NewCheck("flaky-check", []string{"test"}).
+		WithRetries(5, time.Millisecond).
+		WithCheckFn(failingTimesCheckFn(3, &attempts))
+
+	require.NoError(t, runWithRetries(check))
+	assert.Equal(t, CheckResultFailed, check.Result.String())
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRunChecksRecordsPassedAfterRetry(t *testing.T) {
+	checkID := resetDBStateForRun(t, identifiers.TestICMPv4ConnectivityIdentifier)[0]
+	attempts := 0
+	group := NewChecksGroup("networking")
+	group.Add(NewCheck(checkID, []string{"test"}).
+		WithRetries(1, time.Millisecond).
+		WithCheckFn(failingTimesCheckFn(1, &attempts)))
+
+	failedCtr, errs := runGroups(executionPlan{groups: dbGroups}, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, 0, failedCtr)
+
+	require.Contains(t, resultsDB, checkID)
+	assert.Equal(t, CheckResultPassedAfterRetry, resultsDB[checkID].State)
+	// The logs of the failed attempt are kept.
+	assert.Contains(t, resultsDB[checkID].CapturedTestOutput, "Attempt 1 of 2 failed")
+	assert.Contains(t, resultsDB[checkID].CapturedTestOutput, "NonCompliantObjectsOut\":[{")
+}
@@ -0,0 +1,195 @@
+package checksdb
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+)
+
+// DefaultGroupsConcurrency runs the groups one after the other.
+const DefaultGroupsConcurrency = 1
+
+var (
+	groupsConcurrency = DefaultGroupsConcurrency
+
+	// How long the running groups have to stop after an abort, before their checks' results are
+	// set as aborted and recorded.
+	abortGracePeriod = 10 * time.Second
+
+	// Every check holds it for reading while running, except the intrusive ones, which hold it for
+	// writing so they never run at the same time as any other check.
+	intrusiveChecksLock sync.RWMutex
+)
+
+// SetGroupsConcurrency sets the maximum number of groups whose checks run at the same time.
+// Zero (unset) sets the default. Negative values are ignored.
+func SetGroupsConcurrency(concurrency int) {
+	if concurrency < 0 {
+		log.Warn("Invalid groups concurrency %d, using %d", concurrency, DefaultGroupsConcurrency)
+	}
+	if concurrency < 1 {
+		concurrency = DefaultGroupsConcurrency
+	}
+
+	dbLock.Lock()
+	defer dbLock.Unlock()
+	groupsConcurrency = concurrency
+}
+
+// lockCheckRun waits until the check can run and returns the function to release it.
+func lockCheckRun(check *Check) (unlock func()) {
+	if check.Intrusive {
+		intrusiveChecksLock.Lock()
+		return intrusiveChecksLock.Unlock
+	}
+
+	intrusiveChecksLock.RLock()
+	return intrusiveChecksLock.RUnlock
+}
+
+type groupRunResult struct {
+	group        *ChecksGroup
+	errs         []error
+	failedChecks int
+}
+
+type groupRun struct {
+	group    *ChecksGroup
+	stopChan chan bool
+	// Set before stopChan is closed.
+	abortReason string
+}
+
+// waitWithTimeout waits for the wait group until the timeout expires, and returns whether it's done.
+func waitWithTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// runGroups runs the checks of the plan's groups, with up to groupsConcurrency groups running at
+// the same time. Groups are started in the plan's order, as soon as the groups they must run after
+// have finished. On abort, time-out, SIGINT/SIGTERM or cancellation, all the running groups are
+// stopped, waiting up to abortGracePeriod for their current checks to finish, and the ones that
+// were not started yet are skipped. The groups that stop in time set their checks as aborted
+// themselves, the ones still running are aborted from this goroutine, after which they don't change
+// their checks anymore. Results of every group are recorded from this goroutine only.
+//
+//nolint:funlen
+func runGroups(plan executionPlan, timeOutChan <-chan time.Time, sigIntChan <-chan os.Signal) (failedCtr int, errs []error) {
+	if groupsConcurrency > 1 {
+		log.Info("Running up to %d groups concurrently", groupsConcurrency)
+		// The live check line can only show one check at a time.
+		cli.DisableRunningCheckLine()
+		defer cli.EnableRunningCheckLine()
+	}
+
+	// Buffered so the groups that are still running after an abort never block.
+	doneChan := make(chan groupRunResult, len(plan.groups))
+	abortChan := make(chan string, len(plan.groups))
+	running := map[*ChecksGroup]*groupRun{}
+	finished := map[*ChecksGroup]bool{}
+	pending := append([]*ChecksGroup{}, plan.groups...)
+	cancelChan := getCancelRunChan()
+	var groupsWg sync.WaitGroup
+
+	abort := func(reason string) {
+		for _, run := range running {
+			run.abortReason = reason
+			close(run.stopChan)
+		}
+
+		if !waitWithTimeout(&groupsWg, abortGracePeriod) {
+			log.Warn("Groups still running %v after the abort, setting their checks as aborted.", abortGracePeriod)
+		}
+		// The groups that stopped or finished in the grace period still report their results.
+		for len(doneChan) > 0 {
+			result := <-doneChan
+			delete(running, result.group)
+			failedCtr += result.failedChecks
+			errs = append(errs, result.errs...)
+			result.group.RecordChecksResults()
+		}
+
+		for _, run := range running {
+			_ = run.group.OnAbort(reason)
+			run.group.RecordChecksResults()
+		}
+	}
+
+	canStart := func(group *ChecksGroup) bool {
+		for _, dependency := range plan.runAfter[group] {
+			if !finished[dependency] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for len(pending) > 0 || len(running) > 0 {
+		for i := 0; i < len(pending) && len(running) < groupsConcurrency; {
+			if !canStart(pending[i]) {
+				i++
+				continue
+			}
+
+			run := &groupRun{group: pending[i], stopChan: make(chan bool)}
+			running[run.group] = run
+			pending = append(pending[:i], pending[i+1:]...)
+			recordGroupExecution(run.group)
+
+			groupsWg.Add(1)
+			go func() {
+				defer groupsWg.Done()
+				checksErrs, failedCheckCtr := run.group.RunChecks(run.stopChan, abortChan)
+				if run.group.stopped {
+					_ = run.group.OnAbort(run.abortReason)
+				}
+				doneChan <- groupRunResult{group: run.group, errs: checksErrs, failedChecks: failedCheckCtr}
+			}()
+		}
+
+		var abortReason string
+		select {
+		case result := <-doneChan:
+			log.Debug("Group %s finished running checks.", result.group.name)
+			delete(running, result.group)
+			finished[result.group] = true
+			failedCtr += result.failedChecks
+			errs = append(errs, result.errs...)
+			result.group.RecordChecksResults()
+			continue
+		case abortReason = <-abortChan:
+			log.Warn("Check aborted: %s", abortReason)
+		case <-timeOutChan:
+			log.Warn("Running all checks timed-out.")
+			abortReason = "global time-out"
+		case <-sigIntChan:
+			log.Warn("SIGINT/SIGTERM received.")
+			abortReason = "SIGINT/SIGTERM"
+		case abortReason = <-cancelChan:
+			log.Warn("Run canceled: %s", abortReason)
+		}
+
+		abort(abortReason)
+		for _, group := range pending {
+			_ = group.OnAbort(abortReason)
+			group.RecordChecksResults()
+		}
+		break
+	}
+
+	return failedCtr, errs
+}
@@ -0,0 +1,353 @@
+package checksdb
+
+import (
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setGroupsConcurrencyForTest(t *testing.T, concurrency int) {
+	t.Helper()
+	orig := groupsConcurrency
+	t.Cleanup(func() { groupsConcurrency = orig })
+	SetGroupsConcurrency(concurrency)
+}
+
+func setAbortGracePeriodForTest(t *testing.T, gracePeriod time.Duration) {
+	t.Helper()
+	orig := abortGracePeriod
+	t.Cleanup(func() { abortGracePeriod = orig })
+	abortGracePeriod = gracePeriod
+}
+
+// activeChecksTracker counts the checks running at the same time.
+type activeChecksTracker struct {
+	active, maxActive atomic.Int32
+}
+
+func (tracker *activeChecksTracker) checkFn(wait time.Duration) func(*Check) error {
+	return func(*Check) error {
+		active := tracker.active.Add(1)
+		defer tracker.active.Add(-1)
+		for {
+			maxActive := tracker.maxActive.Load()
+			if active <= maxActive || tracker.maxActive.CompareAndSwap(maxActive, active) {
+				break
+			}
+		}
+		time.Sleep(wait)
+		return nil
+	}
+}
+
+func TestSetGroupsConcurrency(t *testing.T) {
+	setGroupsConcurrencyForTest(t, 4)
+	assert.Equal(t, 4, groupsConcurrency)
+
+	SetGroupsConcurrency(0)
+	assert.Equal(t, DefaultGroupsConcurrency, groupsConcurrency)
+
+	SetGroupsConcurrency(-1)
+	assert.Equal(t, DefaultGroupsConcurrency, groupsConcurrency)
+}
+
+func TestRunGroupsConcurrently(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+
+	testCases := []struct {
+		concurrency       int
+		expectedMaxActive int32
+	}{
+		{concurrency: 1, expectedMaxActive: 1},
+		{concurrency: 3, expectedMaxActive: 3},
+	}
+
+	for _, tc := range testCases {
+		setGroupsConcurrencyForTest(t, tc.concurrency)
+
+		tracker := &activeChecksTracker{}
+		groups := []*ChecksGroup{}
+		for _, name := range []string{"group1", "group2", "group3"} {
+			group := NewChecksGroup(name)
+			group.ResetChecks()
+			group.Add(NewCheck(name+"-check", []string{"test"}).WithCheckFn(tracker.checkFn(100 * time.Millisecond)))
+			groups = append(groups, group)
+		}
+
+		failedCtr, errs := runGroups(executionPlan{groups: groups}, nil, nil)
+		assert.Empty(t, errs)
+		assert.Equal(t, 0, failedCtr)
+		assert.Equal(t, tc.expectedMaxActive, tracker.maxActive.Load())
+		for _, group := range groups {
+			assert.Equal(t, CheckResultPassed, group.checks[0].Result.String())
+		}
+	}
+}
+
+func TestRunGroupsIntrusiveChecksRunAlone(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setGroupsConcurrencyForTest(t, 3)
+
+	tracker := &activeChecksTracker{}
+	othersActiveDuringIntrusive := atomic.Int32{}
+
+	readOnly1 := NewChecksGroup("read-only-1")
+	readOnly1.Add(NewCheck("read-only-check-1", []string{"test"}).WithCheckFn(tracker.checkFn(50 * time.Millisecond)))
+	readOnly1.Add(NewCheck("read-only-check-2", []string{"test"}).WithCheckFn(tracker.checkFn(50 * time.Millisecond)))
+
+	intrusive := NewChecksGroup("intrusive")
+	intrusive.Add(NewCheck("intrusive-check", []string{"test"}).
+		WithIntrusive().
+		WithCheckFn(func(c *Check) error {
+			othersActiveDuringIntrusive.Store(tracker.active.Load())
+			return tracker.checkFn(50 * time.Millisecond)(c)
+		}))
+
+	readOnly2 := NewChecksGroup("read-only-2")
+	readOnly2.Add(NewCheck("read-only-check-3", []string{"test"}).WithCheckFn(tracker.checkFn(50 * time.Millisecond)))
+
+	_, errs := runGroups(executionPlan{groups: []*ChecksGroup{readOnly1, intrusive, readOnly2}}, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, int32(0), othersActiveDuringIntrusive.Load())
+	assert.Equal(t, CheckResultPassed, intrusive.checks[0].Result.String())
+}
+
+func TestRunGroupsTimeout(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setGroupsConcurrencyForTest(t, 2)
+	setAbortGracePeriodForTest(t, 50*time.Millisecond)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	blockingCheckFn := func(*Check) error {
+		<-release
+		return nil
+	}
+
+	running1 := NewChecksGroup("running-1")
+	running1.Add(NewCheck("running-check-1", []string{"test"}).WithCheckFn(blockingCheckFn))
+	running2 := NewChecksGroup("running-2")
+	running2.Add(NewCheck("running-check-2", []string{"test"}).WithCheckFn(blockingCheckFn))
+	notStarted := NewChecksGroup("not-started")
+	notStarted.Add(NewCheck("not-started-check", []string{"test"}).WithCheckFn(blockingCheckFn))
+
+	timeOutChan := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		timeOutChan <- time.Now()
+	}()
+
+	_, errs := runGroups(executionPlan{groups: []*ChecksGroup{running1, running2, notStarted}}, timeOutChan, make(chan os.Signal))
+	assert.Empty(t, errs)
+	assert.Equal(t, CheckResultAborted, running1.checks[0].Result.String())
+	assert.Equal(t, CheckResultAborted, running2.checks[0].Result.String())
+	assert.Equal(t, CheckResultSkipped, notStarted.checks[0].Result.String())
+	assert.Equal(t, "global time-out", notStarted.checks[0].skipReason)
+}
+
+func TestRunGroupsAbortGracePeriod(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setAbortGracePeriodForTest(t, 5*time.Second)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	afterAllRan := false
+	running := NewChecksGroup("running").WithAfterAllFn(func([]*Check) error {
+		time.Sleep(200 * time.Millisecond)
+		afterAllRan = true
+		return nil
+	})
+	running.Add(NewCheck("running-check", []string{"test"}).WithCheckFn(func(*Check) error {
+		<-release
+		return nil
+	}))
+	running.Add(NewCheck("not-run-check", []string{"test"}))
+
+	timeOutChan := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		timeOutChan <- time.Now()
+	}()
+
+	_, errs := runGroups(executionPlan{groups: []*ChecksGroup{running}}, timeOutChan, nil)
+	assert.Empty(t, errs)
+	// The running check is abandoned, but the group's functions finish in the grace period.
+	assert.True(t, afterAllRan)
+	assert.Equal(t, CheckResultAborted, running.checks[0].Result.String())
+	assert.Equal(t, CheckResultSkipped, running.checks[1].Result.String())
+}
+
+func TestRunGroupsAbortStuckGroup(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setAbortGracePeriodForTest(t, 50*time.Millisecond)
+
+	releaseCheck := make(chan struct{})
+	t.Cleanup(func() { close(releaseCheck) })
+	releaseAfterEach := make(chan struct{})
+	afterAllDone := make(chan struct{})
+
+	stuck := NewChecksGroup("stuck").
+		WithAfterEachFn(func(*Check) error {
+			<-releaseAfterEach
+			return errors.New("cleanup failed")
+		}).
+		WithAfterAllFn(func([]*Check) error {
+			close(afterAllDone)
+			return nil
+		})
+	stuck.Add(NewCheck("running-check", []string{"test"}).WithCheckFn(func(*Check) error {
+		<-releaseCheck
+		return nil
+	}))
+	stuck.Add(NewCheck("not-run-check", []string{"test"}))
+
+	timeOutChan := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		timeOutChan <- time.Now()
+	}()
+
+	_, errs := runGroups(executionPlan{groups: []*ChecksGroup{stuck}}, timeOutChan, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, CheckResultAborted, stuck.checks[0].Result.String())
+	assert.Equal(t, CheckResultSkipped, stuck.checks[1].Result.String())
+
+	// Once unblocked, the aborted group doesn't change its checks.
+	close(releaseAfterEach)
+	<-afterAllDone
+	stuck.mutex.Lock()
+	defer stuck.mutex.Unlock()
+	assert.Equal(t, CheckResultAborted, stuck.checks[0].Result.String())
+	assert.Equal(t, CheckResultSkipped, stuck.checks[1].Result.String())
+}
+
+func TestRunGroupsAbortSkipsFinishedGroups(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setGroupsConcurrencyForTest(t, 2)
+	setAbortGracePeriodForTest(t, 5*time.Second)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	// Finishes normally in the grace period.
+	finishing := NewChecksGroup("finishing").WithAfterAllFn(func([]*Check) error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+	finishing.Add(NewCheck("finished-check", []string{"test"}).WithCheckFn(func(*Check) error { return nil }))
+	running := NewChecksGroup("running")
+	running.Add(NewCheck("running-check", []string{"test"}).WithCheckFn(func(*Check) error {
+		<-release
+		return nil
+	}))
+
+	timeOutChan := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		timeOutChan <- time.Now()
+	}()
+
+	_, errs := runGroups(executionPlan{groups: []*ChecksGroup{finishing, running}}, timeOutChan, nil)
+	assert.Empty(t, errs)
+	assert.False(t, finishing.aborted)
+	assert.Equal(t, CheckResultPassed, finishing.checks[0].Result.String())
+	assert.True(t, running.aborted)
+	assert.Equal(t, CheckResultAborted, running.checks[0].Result.String())
+}
+
+func TestRunGroupsIntrusiveCheckNotStartedAfterAbort(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setGroupsConcurrencyForTest(t, 2)
+	setAbortGracePeriodForTest(t, 5*time.Second)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	readOnly := NewChecksGroup("read-only")
+	readOnly.Add(NewCheck("read-only-check", []string{"test"}).WithCheckFn(func(*Check) error {
+		<-release
+		return nil
+	}))
+
+	// Waits for the intrusive checks lock until the abort releases it.
+	intrusive := NewChecksGroup("intrusive").WithBeforeAllFn(func([]*Check) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	intrusive.Add(NewCheck("intrusive-check", []string{"test"}).
+		WithIntrusive().
+		WithCheckFn(func(*Check) error { return nil }))
+
+	timeOutChan := make(chan time.Time, 1)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		timeOutChan <- time.Now()
+	}()
+
+	_, errs := runGroups(executionPlan{groups: []*ChecksGroup{readOnly, intrusive}}, timeOutChan, nil)
+	assert.Empty(t, errs)
+	// The check didn't start.
+	assert.True(t, intrusive.checks[0].StartTime.IsZero())
+	assert.Equal(t, CheckResultAborted, intrusive.checks[0].Result.String())
+}
+
+func TestRunGroupsCanceled(t *testing.T) {
+	saveAndResetDBState(t)
+	require.NoError(t, InitLabelsExprEvaluator("test"))
+	setAbortGracePeriodForTest(t, 50*time.Millisecond)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	running := NewChecksGroup("running")
+	running.Add(NewCheck("running-check", []string{"test"}).WithCheckFn(func(*Check) error {
+		<-release
+		return nil
+	}))
+	notStarted := NewChecksGroup("not-started")
+	notStarted.Add(NewCheck("not-started-check", []string{"test"}))
+
+	startCancelableRun()
+	defer endCancelableRun()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		CancelRun("job canceled")
+	}()
+
+	_, errs := runGroups(executionPlan{groups: []*ChecksGroup{running, notStarted}}, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, CheckResultAborted, running.checks[0].Result.String())
+	assert.Equal(t, CheckResultSkipped, notStarted.checks[0].Result.String())
+	assert.Equal(t, "job canceled", notStarted.checks[0].skipReason)
+}
+
+func TestCancelRunBeforeStart(t *testing.T) {
+	saveAndResetDBState(t)
+
+	// Canceled before the checks run, as during the discovery.
+	CancelRun("job canceled")
+	startCancelableRun()
+	assert.Equal(t, "job canceled", <-getCancelRunChan())
+	endCancelableRun()
+	assert.Nil(t, getCancelRunChan())
+
+	// Reset removes the pending cancellation.
+	CancelRun("job canceled")
+	ResetChecksDB()
+	startCancelableRun()
+	defer endCancelableRun()
+	assert.Empty(t, getCancelRunChan())
+}
@@ -0,0 +1,138 @@
+package checksdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ErrCheckTimedOut is returned when the check functions didn't finish before the check's timeout.
+var ErrCheckTimedOut = errors.New("check timed out")
+
+// errCheckStopped is returned when the check functions didn't finish before the run was aborted.
+var errCheckStopped = errors.New("check stopped")
+
+var (
+	defaultCheckTimeoutLock sync.Mutex
+	// Timeout of the checks that don't set their own. Zero means no timeout.
+	defaultCheckTimeout time.Duration
+)
+
+// SetDefaultCheckTimeout sets the timeout of the checks that don't set one with WithTimeout.
+// Zero disables it.
+func SetDefaultCheckTimeout(timeout time.Duration) {
+	defaultCheckTimeoutLock.Lock()
+	defer defaultCheckTimeoutLock.Unlock()
+
+	defaultCheckTimeout = timeout
+}
+
+func getCheckTimeout(check *Check) time.Duration {
+	if check.Timeout > 0 {
+		return check.Timeout
+	}
+
+	defaultCheckTimeoutLock.Lock()
+	defer defaultCheckTimeoutLock.Unlock()
+	return defaultCheckTimeout
+}
+
+// Context returns the check's context, which is cancelled when the check times out, when the run is
+// aborted and once the check functions return. Check functions must pass it to the calls that may
+// block, e.g. commands exec'ed in containers and requests to the API server, so they stop as soon
+// as the check is cancelled.
+func (check *Check) Context() context.Context {
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+
+	if check.ctx == nil {
+		return context.Background()
+	}
+	return check.ctx
+}
+
+// checkFnsPanic holds the value and stack trace of a panic in the check functions, recovered in
+// their goroutine so it can be raised again in the check's one.
+type checkFnsPanic struct {
+	value any
+	stack []byte
+}
+
+func (p checkFnsPanic) String() string {
+	return fmt.Sprintf("%v\n%s", p.value, p.stack)
+}
+
+// runWithTimeout calls fn in a goroutine with the check's context, cancelled once fn returns, the
+// check's timeout expires or the run is aborted. In the last two cases, ErrCheckTimedOut or
+// errCheckStopped is returned right away, so a check ignoring its context cannot block the rest of
+// them, and the check is set as finished: fn may keep running in the background, but the results
+// it sets and the aborts it issues are ignored. Panics in fn are propagated.
+func runWithTimeout(check *Check, fn func() error) error {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	timeout := getCheckTimeout(check)
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	defer cancel()
+
+	check.mutex.Lock()
+	check.ctx = ctx
+	check.mutex.Unlock()
+
+	type outcome struct {
+		err      error
+		panicked *checkFnsPanic
+	}
+	// Buffered so the goroutine never blocks when the check has already timed out.
+	done := make(chan outcome, 1)
+	fnsDone := make(chan struct{})
+	check.fnsDone = fnsDone
+	go func() {
+		defer close(fnsDone)
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{panicked: &checkFnsPanic{value: r, stack: debug.Stack()}}
+			}
+		}()
+		done <- outcome{err: fn()}
+	}()
+
+	select {
+	case result := <-done:
+		if result.panicked != nil {
+			// Manual aborts are expected to reach the group untouched.
+			if abortMsg, ok := result.panicked.value.(AbortPanicMsg); ok {
+				panic(abortMsg)
+			}
+			panic(result.panicked.String())
+		}
+		return result.err
+	case <-ctx.Done():
+		err := fmt.Errorf("%w after %v", ErrCheckTimedOut, timeout)
+		check.finish(CheckResultError, err.Error())
+		return err
+	case <-check.stopChan:
+		// The check is set as aborted by its group.
+		check.finish("", "")
+		return errCheckStopped
+	}
+}
+
+// finish sets the check as finished once its functions are abandoned, along with the given result
+// unless it's empty.
+func (check *Check) finish(result CheckResult, reason string) {
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+
+	check.finished = true
+	if result != "" {
+		check.Result = result
+		check.skipReason = reason
+	}
+}
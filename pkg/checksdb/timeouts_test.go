@@ -0,0 +1,182 @@
+package checksdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setDefaultCheckTimeoutForTest(t *testing.T, timeout time.Duration) {
+	t.Helper()
+	t.Cleanup(func() { SetDefaultCheckTimeout(0) })
+	SetDefaultCheckTimeout(timeout)
+}
+
+func TestGetCheckTimeout(t *testing.T) {
+	check := NewCheck("myID", nil)
+	assert.Equal(t, time.Duration(0), getCheckTimeout(check))
+
+	setDefaultCheckTimeoutForTest(t, time.Minute)
+	assert.Equal(t, time.Minute, getCheckTimeout(check))
+
+	check.WithTimeout(time.Second)
+	assert.Equal(t, time.Second, getCheckTimeout(check))
+}
+
+func TestCheckContext(t *testing.T) {
+	disableRunningCheckLineForTest(t)
+
+	// Checks without timeout are only cancelled once they return.
+	check := NewCheck("myID", nil).WithCheckFn(func(c *Check) error {
+		assert.NoError(t, c.Context().Err())
+		_, hasDeadline := c.Context().Deadline()
+		assert.False(t, hasDeadline)
+		return nil
+	})
+	require.NoError(t, check.Run())
+	assert.ErrorIs(t, check.Context().Err(), context.Canceled)
+
+	check = NewCheck("myID", nil).WithTimeout(time.Minute).WithCheckFn(func(c *Check) error {
+		_, hasDeadline := c.Context().Deadline()
+		assert.True(t, hasDeadline)
+		return nil
+	})
+	require.NoError(t, check.Run())
+}
+
+func TestRunWithTimeout(t *testing.T) {
+	disableRunningCheckLineForTest(t)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	testCases := []struct {
+		name    string
+		checkFn func(*Check) error
+	}{
+		{
+			name: "check function honoring the context",
+			checkFn: func(c *Check) error {
+				<-c.Context().Done()
+				return c.Context().Err()
+			},
+		},
+		{
+			name: "check function ignoring the context",
+			checkFn: func(*Check) error {
+				<-release
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			check := NewCheck("myID", nil).WithTimeout(50 * time.Millisecond).WithCheckFn(tc.checkFn)
+			err := check.Run()
+			assert.ErrorIs(t, err, ErrCheckTimedOut)
+			assert.EqualError(t, err, "check timed out after 50ms")
+			assert.ErrorIs(t, check.Context().Err(), context.DeadlineExceeded)
+		})
+	}
+}
+
+func TestRunWithTimeoutIgnoresAbandonedFunctions(t *testing.T) {
+	disableRunningCheckLineForTest(t)
+
+	release := make(chan struct{})
+	checkFnDone := make(chan struct{})
+	abortChan := make(chan string, 1)
+	check := NewCheck("myID", nil).WithTimeout(50 * time.Millisecond).WithCheckFn(func(c *Check) error {
+		defer close(checkFnDone)
+		<-release
+		_ = failingCheckFn(c)
+		c.Abort("too late")
+		return nil
+	})
+	check.SetAbortChan(abortChan)
+
+	assert.ErrorIs(t, check.Run(), ErrCheckTimedOut)
+	close(release)
+	<-checkFnDone
+
+	assert.Equal(t, CheckResultError, check.Result.String())
+	assert.Equal(t, "check timed out after 50ms", check.skipReason)
+	assert.Empty(t, check.details)
+	assert.Empty(t, abortChan)
+}
+
+func TestRunWithTimeoutStopsOnAbort(t *testing.T) {
+	disableRunningCheckLineForTest(t)
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	stopChan := make(chan bool)
+	check := NewCheck("myID", nil).WithCheckFn(func(*Check) error {
+		<-release
+		return nil
+	})
+	check.setStopChan(stopChan)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(stopChan)
+	}()
+
+	assert.ErrorIs(t, check.Run(), errCheckStopped)
+	assert.ErrorIs(t, check.Context().Err(), context.Canceled)
+}
+
+func TestRunWithTimeoutPropagatesPanics(t *testing.T) {
+	disableRunningCheckLineForTest(t)
+
+	check := NewCheck("myID", nil).WithTimeout(time.Minute).WithCheckFn(func(*Check) error {
+		panic("something crashed")
+	})
+	assert.PanicsWithValue(t, "something crashed", func() {
+		defer func() {
+			// Only the panic value is compared, the stack trace is appended to it.
+			if r := recover(); r != nil {
+				panic(r.(string)[:len("something crashed")])
+			}
+		}()
+		_ = check.Run()
+	})
+
+	abortCheck := NewCheck("myID", nil).WithTimeout(time.Minute).WithCheckFn(func(*Check) error {
+		panic(AbortPanicMsg("aborted"))
+	})
+	assert.PanicsWithValue(t, AbortPanicMsg("aborted"), func() { _ = abortCheck.Run() })
+}
+
+func TestRunChecksContinuesAfterTimedOutCheck(t *testing.T) {
+	hungID := resetDBStateForRun(t, identifiers.TestDeploymentScalingIdentifier)[0]
+	setDefaultCheckTimeoutForTest(t, 50*time.Millisecond)
+
+	group := NewChecksGroup("lifecycle")
+	group.Add(NewCheck(hungID, []string{"test"}).WithCheckFn(func(c *Check) error {
+		<-c.Context().Done()
+		return nil
+	}))
+	nextCheckRan := false
+	group.Add(NewCheck("next-check", []string{"test"}).WithTimeout(time.Minute).WithCheckFn(func(*Check) error {
+		nextCheckRan = true
+		return nil
+	}))
+
+	failedCtr, errs := runGroups(executionPlan{groups: dbGroups}, nil, nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, 0, failedCtr)
+
+	assert.Equal(t, CheckResultError, group.checks[0].Result.String())
+	assert.Equal(t, "check timed out after 50ms", group.checks[0].skipReason)
+	assert.True(t, nextCheckRan)
+	assert.Equal(t, CheckResultPassed, group.checks[1].Result.String())
+
+	require.Contains(t, resultsDB, hungID)
+	assert.Equal(t, CheckResultError, resultsDB[hungID].State)
+}
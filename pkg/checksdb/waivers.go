@@ -0,0 +1,246 @@
+package checksdb
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+)
+
+// Waiver accepts the non-compliant objects of a check that match all its fields, so they don't
+// make the check fail, until the waiver expires.
+type Waiver struct {
+	// ID of the check whose objects are waived.
+	ID string
+	// Type of the waived objects. Empty matches any type.
+	ObjectType string
+	// Report object fields the waived objects must have, by key. Values are glob patterns.
+	Fields        map[string]string
+	Justification string
+	// Time the waiver stops applying.
+	ExpiresAt time.Time
+
+	// Number of non-compliant objects the waiver would have covered, were it not expired.
+	expiredMatches int
+}
+
+var (
+	waiversLock sync.Mutex
+	// Waivers by check ID.
+	waivers = map[string][]*Waiver{}
+)
+
+// SetWaivers validates and sets the waivers applied to the non-compliant objects of the checks.
+// Expired waivers are reported, but never applied.
+func SetWaivers(newWaivers []Waiver) error {
+	var errs []error
+	byID := map[string][]*Waiver{}
+	for i := range newWaivers {
+		waiver := newWaivers[i]
+		if err := waiver.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("waiver %d (%s): %w", i, waiver.ID, err))
+			continue
+		}
+		if waiver.isExpired(time.Now()) {
+			log.Error("Waiver of check %s for objects %s expired on %s, its objects will not be waived (justification: %s)",
+				waiver.ID, waiver.objectsDescription(), waiver.expirationDate(), waiver.Justification)
+		}
+		byID[waiver.ID] = append(byID[waiver.ID], &waiver)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	waiversLock.Lock()
+	defer waiversLock.Unlock()
+	waivers = byID
+	return nil
+}
+
+func (waiver *Waiver) validate() error {
+	var errs []error
+	if waiver.ID == "" {
+		errs = append(errs, errors.New("missing check id"))
+	}
+	if waiver.ObjectType == "" && len(waiver.Fields) == 0 {
+		errs = append(errs, errors.New("missing object type or fields to match"))
+	}
+	for key, pattern := range waiver.Fields {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid pattern %q of field %q: %w", pattern, key, err))
+		}
+	}
+	if strings.TrimSpace(waiver.Justification) == "" {
+		errs = append(errs, errors.New("missing justification"))
+	}
+	if waiver.ExpiresAt.IsZero() {
+		errs = append(errs, errors.New("missing expiration date"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (waiver *Waiver) isExpired(now time.Time) bool {
+	return !now.Before(waiver.ExpiresAt)
+}
+
+// expirationDate returns the last day the waiver applies.
+func (waiver *Waiver) expirationDate() string {
+	return waiver.ExpiresAt.AddDate(0, 0, -1).Format(time.DateOnly)
+}
+
+// objectsDescription returns the type and fields of the waived objects, e.g.
+// Container{Namespace=tnf, Pod Name=xdp-*}.
+func (waiver *Waiver) objectsDescription() string {
+	fields := []string{}
+	for key, pattern := range waiver.Fields {
+		fields = append(fields, key+"="+pattern)
+	}
+	sort.Strings(fields)
+
+	objectType := waiver.ObjectType
+	if objectType == "" {
+		objectType = "*"
+	}
+	return objectType + "{" + strings.Join(fields, ", ") + "}"
+}
+
+// matches returns true if the object has the waiver's type and all its fields.
+func (waiver *Waiver) matches(obj *testhelper.ReportObject) bool {
+	if waiver.ObjectType != "" && waiver.ObjectType != obj.ObjectType {
+		return false
+	}
+
+	for key, pattern := range waiver.Fields {
+		found := false
+		for i := range obj.ObjectFieldsKeys {
+			if obj.ObjectFieldsKeys[i] != key || i >= len(obj.ObjectFieldsValues) {
+				continue
+			}
+			if matched, _ := path.Match(pattern, obj.ObjectFieldsValues[i]); matched {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyWaivers splits the non-compliant objects of the check into the ones not covered by any
+// active waiver and the waived ones, which get the waiver's justification and expiration date.
+func applyWaivers(check *Check, nonCompliantObjects []*testhelper.ReportObject) (remaining, waived []*testhelper.ReportObject) {
+	waiversLock.Lock()
+	defer waiversLock.Unlock()
+
+	checkWaivers := waivers[check.ID]
+	if len(checkWaivers) == 0 {
+		return nonCompliantObjects, nil
+	}
+
+	now := time.Now()
+	for _, obj := range nonCompliantObjects {
+		var activeWaiver, expiredWaiver *Waiver
+		for _, waiver := range checkWaivers {
+			if !waiver.matches(obj) {
+				continue
+			}
+			if !waiver.isExpired(now) {
+				activeWaiver = waiver
+				break
+			}
+			expiredWaiver = waiver
+		}
+
+		switch {
+		case activeWaiver != nil:
+			waived = append(waived, newWaivedObject(obj, activeWaiver))
+		case expiredWaiver != nil:
+			expiredWaiver.expiredMatches++
+			check.LogError("Non-compliant object %s is not waived: its waiver expired on %s", objectDescription(obj), expiredWaiver.expirationDate())
+			remaining = append(remaining, obj)
+		default:
+			remaining = append(remaining, obj)
+		}
+	}
+
+	if len(waived) > 0 {
+		check.LogInfo("%d non-compliant objects waived", len(waived))
+	}
+
+	return remaining, waived
+}
+
+// newWaivedObject returns a copy of the object with the waiver's justification and expiration date.
+func newWaivedObject(obj *testhelper.ReportObject, waiver *Waiver) *testhelper.ReportObject {
+	waived := &testhelper.ReportObject{
+		ObjectType:         obj.ObjectType,
+		ObjectFieldsKeys:   append([]string{}, obj.ObjectFieldsKeys...),
+		ObjectFieldsValues: append([]string{}, obj.ObjectFieldsValues...),
+	}
+
+	return waived.
+		AddField(testhelper.WaiverJustification, waiver.Justification).
+		AddField(testhelper.WaiverExpires, waiver.expirationDate())
+}
+
+func objectDescription(obj *testhelper.ReportObject) string {
+	fields := []string{}
+	for i := range obj.ObjectFieldsKeys {
+		if i < len(obj.ObjectFieldsValues) {
+			fields = append(fields, obj.ObjectFieldsKeys[i]+"="+obj.ObjectFieldsValues[i])
+		}
+	}
+	return obj.ObjectType + "{" + strings.Join(fields, ", ") + "}"
+}
+
+// getExpiredWaivers returns a copy of the expired waivers, sorted by check ID.
+func getExpiredWaivers() []Waiver {
+	waiversLock.Lock()
+	defer waiversLock.Unlock()
+
+	now := time.Now()
+	expired := []Waiver{}
+	for _, checkWaivers := range waivers {
+		for _, waiver := range checkWaivers {
+			if waiver.isExpired(now) {
+				expired = append(expired, *waiver)
+			}
+		}
+	}
+	sort.SliceStable(expired, func(i, j int) bool { return expired[i].ID < expired[j].ID })
+
+	return expired
+}
+
+func printExpiredWaivers() {
+	expired := getExpiredWaivers()
+	if len(expired) == 0 {
+		return
+	}
+
+	header := "| " + cli.Red + "EXPIRED WAIVERS" + cli.Reset + " |"
+	nbSymbols := utf8.RuneCountInString(header) - nbColorSymbols
+	fmt.Println(strings.Repeat("=", nbSymbols))
+	fmt.Println(header)
+	fmt.Println(strings.Repeat("=", nbSymbols))
+	fmt.Printf("%d waiver(s) expired and were not applied:\n", len(expired))
+	for _, waiver := range expired {
+		fmt.Printf("  - %s %s expired on %s, %d non-compliant object(s) not waived\n",
+			waiver.ID, waiver.objectsDescription(), waiver.expirationDate(), waiver.expiredMatches)
+	}
+	fmt.Println()
+	fmt.Println("Renew or remove them from the waivers in the configuration file.")
+	fmt.Println(strings.Repeat("=", nbSymbols))
+}
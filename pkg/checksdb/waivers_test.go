@@ -0,0 +1,179 @@
+package checksdb
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setWaiversForTest(t *testing.T, testWaivers []Waiver) {
+	t.Helper()
+	t.Cleanup(func() { _ = SetWaivers(nil) })
+	require.NoError(t, SetWaivers(testWaivers))
+}
+
+func netAdminWaiver(podNamePattern string, expiresAt time.Time) Waiver {
+	return Waiver{
+		ID:            "net-admin-check",
+		ObjectType:    testhelper.ContainerType,
+		Fields:        map[string]string{testhelper.Namespace: "tnf", testhelper.PodName: podNamePattern, testhelper.SCCCapability: "NET_ADMIN"},
+		Justification: "XDP data plane",
+		ExpiresAt:     expiresAt,
+	}
+}
+
+func netAdminContainer(podName string) *testhelper.ReportObject {
+	return testhelper.NewContainerReportObject("tnf", podName, "test", "Non compliant capability NET_ADMIN in container", false).
+		AddField(testhelper.SCCCapability, "NET_ADMIN")
+}
+
+func TestSetWaiversValidation(t *testing.T) {
+	t.Cleanup(func() { _ = SetWaivers(nil) })
+
+	err := SetWaivers([]Waiver{
+		{ID: "check-1", ObjectType: testhelper.PodType, Justification: "valid", ExpiresAt: time.Now()},
+		{},
+		{ID: "check-2", Fields: map[string]string{testhelper.PodName: "[xdp"}, Justification: "  ", ExpiresAt: time.Now()},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "waiver 1 (): missing check id\nmissing object type or fields to match\nmissing justification\nmissing expiration date")
+	assert.Contains(t, err.Error(), `waiver 2 (check-2): invalid pattern "[xdp" of field "Pod Name": syntax error in pattern`)
+	assert.Contains(t, err.Error(), "missing justification")
+	assert.NotContains(t, err.Error(), "check-1")
+}
+
+func TestWaiverMatches(t *testing.T) {
+	testCases := []struct {
+		name     string
+		waiver   Waiver
+		expected bool
+	}{
+		{
+			name:     "all fields match",
+			waiver:   netAdminWaiver("xdp-0", time.Time{}),
+			expected: true,
+		},
+		{
+			name:     "glob pattern",
+			waiver:   netAdminWaiver("xdp-*", time.Time{}),
+			expected: true,
+		},
+		{
+			name:     "field value does not match",
+			waiver:   netAdminWaiver("other-*", time.Time{}),
+			expected: false,
+		},
+		{
+			name:     "object type only",
+			waiver:   Waiver{ObjectType: testhelper.ContainerType},
+			expected: true,
+		},
+		{
+			name:     "object type does not match",
+			waiver:   Waiver{ObjectType: testhelper.PodType, Fields: map[string]string{testhelper.PodName: "xdp-0"}},
+			expected: false,
+		},
+		{
+			name:     "missing field",
+			waiver:   Waiver{Fields: map[string]string{testhelper.DeploymentName: "*"}},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.waiver.matches(netAdminContainer("xdp-0")))
+		})
+	}
+}
+
+func TestSetResultWithWaivers(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour)
+	yesterday := time.Now().Add(-24 * time.Hour)
+
+	testCases := []struct {
+		name             string
+		waivers          []Waiver
+		nonCompliant     []*testhelper.ReportObject
+		expectedResult   CheckResult
+		expectedRemained int
+		expectedWaived   int
+	}{
+		{
+			name:           "all non-compliant objects waived",
+			waivers:        []Waiver{netAdminWaiver("xdp-*", tomorrow)},
+			nonCompliant:   []*testhelper.ReportObject{netAdminContainer("xdp-0"), netAdminContainer("xdp-1")},
+			expectedResult: CheckResultPassedWithWaivers,
+			expectedWaived: 2,
+		},
+		{
+			name:             "some non-compliant objects waived",
+			waivers:          []Waiver{netAdminWaiver("xdp-*", tomorrow)},
+			nonCompliant:     []*testhelper.ReportObject{netAdminContainer("xdp-0"), netAdminContainer("other-0")},
+			expectedResult:   CheckResultFailed,
+			expectedRemained: 1,
+			expectedWaived:   1,
+		},
+		{
+			name:             "expired waiver",
+			waivers:          []Waiver{netAdminWaiver("xdp-*", yesterday)},
+			nonCompliant:     []*testhelper.ReportObject{netAdminContainer("xdp-0")},
+			expectedResult:   CheckResultFailed,
+			expectedRemained: 1,
+		},
+		{
+			name:             "waiver of another check",
+			waivers:          []Waiver{{ID: "other-check", ObjectType: testhelper.ContainerType, Justification: "other", ExpiresAt: tomorrow}},
+			nonCompliant:     []*testhelper.ReportObject{netAdminContainer("xdp-0")},
+			expectedResult:   CheckResultFailed,
+			expectedRemained: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setWaiversForTest(t, tc.waivers)
+
+			check := NewCheck("net-admin-check", nil)
+			check.SetResult(nil, tc.nonCompliant)
+			assert.Equal(t, tc.expectedResult, check.Result)
+
+			details := testhelper.FailureReasonOut{}
+			require.NoError(t, json.Unmarshal([]byte(check.details), &details))
+			assert.Len(t, details.NonCompliantObjectsOut, tc.expectedRemained)
+			assert.Len(t, details.WaivedObjectsOut, tc.expectedWaived)
+			for _, waived := range details.WaivedObjectsOut {
+				assert.Contains(t, waived.ObjectFieldsKeys, testhelper.WaiverJustification)
+				assert.Contains(t, waived.ObjectFieldsValues, "XDP data plane")
+				// The expiration date is the last day the waiver applies.
+				assert.Contains(t, waived.ObjectFieldsValues, tomorrow.AddDate(0, 0, -1).Format(time.DateOnly))
+			}
+		})
+	}
+
+	// The reported objects are never modified.
+	setWaiversForTest(t, []Waiver{netAdminWaiver("xdp-*", tomorrow)})
+	obj := netAdminContainer("xdp-0")
+	NewCheck("net-admin-check", nil).SetResult(nil, []*testhelper.ReportObject{obj})
+	assert.NotContains(t, obj.ObjectFieldsKeys, testhelper.WaiverJustification)
+}
+
+func TestGetExpiredWaivers(t *testing.T) {
+	setWaiversForTest(t, []Waiver{
+		netAdminWaiver("xdp-*", time.Now().Add(-time.Hour)),
+		netAdminWaiver("dpdk-*", time.Now().Add(time.Hour)),
+	})
+
+	check := NewCheck("net-admin-check", nil)
+	check.SetResult(nil, []*testhelper.ReportObject{netAdminContainer("xdp-0"), netAdminContainer("dpdk-0")})
+	assert.Equal(t, CheckResultFailed, check.Result.String())
+
+	expired := getExpiredWaivers()
+	require.Len(t, expired, 1)
+	assert.Equal(t, "Container{Namespace=tnf, Pod Name=xdp-*, SCC Capability=NET_ADMIN}", expired[0].objectsDescription())
+	assert.Equal(t, 1, expired[0].expiredMatches)
+}
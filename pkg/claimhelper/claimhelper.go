@@ -41,14 +41,18 @@ const (
 	claimFilePermissions                 = 0o644
 	CNFFeatureValidationJunitXMLFileName = "validation_junit.xml"
 	CNFFeatureValidationReportKey        = "cnf-feature-validation"
+	// ExecutionOrderKey is the claim configurations key with the order the suites and checks ran.
+	ExecutionOrderKey = "executionOrder"
 	// dateTimeFormatDirective is the directive used to format date/time according to ISO 8601.
 	DateTimeFormatDirective = "2006-01-02 15:04:05 -0700 MST"
 
 	unitTestEnvTrue = "true"
 
 	// States for test cases
+	TestStatePassed  = "passed"
 	TestStateFailed  = "failed"
 	TestStateSkipped = "skipped"
+	TestStateError   = "error"
 )
 
 type SkippedMessage struct {
@@ -71,6 +75,7 @@ type TestCase struct {
 	SystemErr string          `xml:"system-err,omitempty"`
 	Skipped   *SkippedMessage `xml:"skipped"`
 	Failure   *FailureMessage `xml:"failure"`
+	Error     *FailureMessage `xml:"error"`
 }
 
 type Testsuite struct {
@@ -108,6 +113,11 @@ type TestSuitesXML struct {
 
 type ClaimBuilder struct {
 	claimRoot *claim.Root
+	// Whether claimRoot is a previous claim whose results must be updated with the new ones.
+	mergeResults bool
+	// Category whose mandatory test cases failing make the verdict fail the run, if any.
+	failOn  string
+	verdict Verdict
 }
 
 func NewClaimBuilder(env *provider.TestEnvironment) (*ClaimBuilder, error) {
@@ -148,7 +158,18 @@ func (c *ClaimBuilder) Build(outputFile string) {
 	endTime := time.Now()
 
 	c.claimRoot.Claim.Metadata.EndTime = endTime.UTC().Format(DateTimeFormatDirective)
-	c.claimRoot.Claim.Results = checksdb.GetReconciledResults()
+	if c.mergeResults {
+		ranCheckIDs, _ := checksdb.FilterCheckIDs()
+		c.claimRoot.Claim.Results = MergeResults(c.claimRoot.Claim.Results, checksdb.GetReconciledResults(), ranCheckIDs)
+	} else {
+		c.claimRoot.Claim.Results = checksdb.GetReconciledResults()
+	}
+	if c.claimRoot.Claim.Configurations == nil {
+		c.claimRoot.Claim.Configurations = map[string]interface{}{}
+	}
+	c.claimRoot.Claim.Configurations[ExecutionOrderKey] = checksdb.GetExecutionOrder()
+	c.verdict = NewVerdict(c.claimRoot.Claim.Results, c.failOn)
+	c.claimRoot.Claim.Configurations[VerdictKey] = c.verdict
 
 	// Marshal the claim and output to file
 	payload := MarshalClaimOutput(c.claimRoot)
@@ -272,6 +293,46 @@ func (c *ClaimBuilder) ToJUnitXML(outputFile string, startTime, endTime time.Tim
 	}
 }
 
+// MergeIntoClaim makes the claim built a copy of the given claim file, with the results of the test
+// cases that ran replacing the previous ones. The rest of the previous results are kept.
+func (c *ClaimBuilder) MergeIntoClaim(claimFileName string) error {
+	root, err := LoadClaimFile(claimFileName)
+	if err != nil {
+		return err
+	}
+
+	c.claimRoot = root
+	c.mergeResults = true
+	return nil
+}
+
+// SetFailOn sets the category whose failed mandatory test cases make the verdict fail the run.
+func (c *ClaimBuilder) SetFailOn(category string) {
+	c.failOn = category
+}
+
+// GetVerdict returns the verdict of the results, once the claim is built.
+func (c *ClaimBuilder) GetVerdict() *Verdict {
+	return &c.verdict
+}
+
+// MergeResults returns a copy of the previous results with the latest results of the given test
+// IDs replacing them.
+func MergeResults(previous, latest map[string]claim.Result, testIDs []string) map[string]claim.Result {
+	merged := make(map[string]claim.Result, len(previous))
+	for id := range previous {
+		merged[id] = previous[id]
+	}
+
+	for _, id := range testIDs {
+		if result, found := latest[id]; found {
+			merged[id] = result
+		}
+	}
+
+	return merged
+}
+
 func (c *ClaimBuilder) Reset() {
 	c.claimRoot.Claim.Metadata.StartTime = time.Now().UTC().Format(DateTimeFormatDirective)
 }
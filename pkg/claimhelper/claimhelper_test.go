@@ -19,11 +19,13 @@ package claimhelper
 import (
 	j "encoding/json"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
 	"github.com/stretchr/testify/assert"
@@ -608,6 +610,97 @@ func TestNewClaimBuilderUnitTest(t *testing.T) {
 	assert.NotEmpty(t, builder.claimRoot.Claim.Metadata.StartTime)
 }
 
+func TestClaimBuilderBuildExecutionOrder(t *testing.T) {
+	t.Setenv("UNIT_TEST", unitTestEnvTrue)
+
+	checksdb.ResetChecksDB()
+	t.Cleanup(func() {
+		require.NoError(t, checksdb.SetGroupsOrder(nil))
+		checksdb.ResetChecksDB()
+	})
+	for _, groupName := range []string{"group-a", "group-b"} {
+		group := checksdb.NewChecksGroup(groupName)
+		for _, checkName := range []string{"check-1", "check-2"} {
+			group.Add(checksdb.NewCheck(groupName+"-"+checkName, []string{"test"}).WithCheckFn(func(*checksdb.Check) error { return nil }))
+		}
+	}
+	require.NoError(t, checksdb.SetGroupsOrder([]string{"group-b", checksdb.GroupsOrderWildcard}))
+	require.NoError(t, checksdb.InitLabelsExprEvaluator("test"))
+	_, err := checksdb.RunChecks(time.Minute)
+	require.NoError(t, err)
+
+	builder, err := NewClaimBuilder(&provider.TestEnvironment{})
+	require.NoError(t, err)
+	// Required by the claim schema, not set in unit test mode.
+	builder.claimRoot.Claim.Versions = &claim.Versions{}
+
+	claimFile := filepath.Join(t.TempDir(), "claim.json")
+	builder.Build(claimFile)
+
+	payload, err := ReadClaimFile(claimFile)
+	require.NoError(t, err)
+	var root claim.Root
+	UnmarshalClaim(payload, &root)
+	assert.Equal(t, map[string]interface{}{
+		"groups": []interface{}{"group-b", "group-a"},
+		"checks": []interface{}{"group-b-check-1", "group-b-check-2", "group-a-check-1", "group-a-check-2"},
+	}, root.Claim.Configurations[ExecutionOrderKey])
+	assert.Contains(t, root.Claim.Configurations, VerdictKey)
+}
+
+func TestMergeResults(t *testing.T) {
+	previous := map[string]claim.Result{
+		"test-1": {State: TestStateFailed},
+		"test-2": {State: "passed"},
+		"test-3": {State: TestStateFailed},
+	}
+	latest := map[string]claim.Result{
+		"test-1": {State: "passed"},
+		"test-2": {State: TestStateSkipped, SkipReason: "no matching labels"},
+		"test-3": {State: TestStateFailed},
+	}
+
+	merged := MergeResults(previous, latest, []string{"test-1", "test-3", "test-4"})
+	assert.Equal(t, map[string]claim.Result{
+		"test-1": {State: "passed"},
+		"test-2": {State: "passed"},
+		"test-3": {State: TestStateFailed},
+	}, merged)
+	// The previous results are not modified.
+	assert.Equal(t, TestStateFailed, previous["test-1"].State)
+}
+
+func TestMergeIntoClaim(t *testing.T) {
+	t.Setenv("UNIT_TEST", unitTestEnvTrue)
+
+	builder, err := NewClaimBuilder(&provider.TestEnvironment{})
+	require.NoError(t, err)
+
+	previousRoot := CreateClaimRoot()
+	previousRoot.Claim.Metadata.StartTime = "2024-01-15 10:00:00 +0000 UTC"
+	previousRoot.Claim.Configurations = map[string]interface{}{"Config": "previous"}
+	previousRoot.Claim.Versions = &claim.Versions{CertSuite: "v5.5.0"}
+	previousRoot.Claim.Results = map[string]claim.Result{"test-1": {
+		State:                  TestStateFailed,
+		TestID:                 &claim.Identifier{Id: "test-1", Suite: "suite"},
+		CatalogInfo:            &claim.CatalogInfo{},
+		CategoryClassification: &claim.CategoryClassification{},
+	}}
+	previousClaim := filepath.Join(t.TempDir(), "claim.json")
+	WriteClaimOutput(previousClaim, MarshalClaimOutput(previousRoot))
+
+	require.NoError(t, builder.MergeIntoClaim(previousClaim))
+	assert.True(t, builder.mergeResults)
+	assert.Equal(t, "previous", builder.claimRoot.Claim.Configurations["Config"])
+	assert.Equal(t, "2024-01-15 10:00:00 +0000 UTC", builder.claimRoot.Claim.Metadata.StartTime)
+	assert.Equal(t, "v5.5.0", builder.claimRoot.Claim.Versions.CertSuite)
+
+	require.NoError(t, os.WriteFile(previousClaim, []byte(`{"results": {}}`), 0o600))
+	assert.Error(t, builder.MergeIntoClaim(previousClaim))
+
+	assert.Error(t, builder.MergeIntoClaim(filepath.Join(t.TempDir(), "missing.json")))
+}
+
 func TestPopulateXMLFromClaimSkipped(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,253 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package claimhelper
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+)
+
+const (
+	junitStatusPassed = "passed"
+	junitStatusWaived = "waived"
+)
+
+// TestSuitesPerObjectXML is the JUnit XML with a test suite per certsuite suite, and a test case per
+// report object of each check, so CI trend tools can track individual workloads.
+type TestSuitesPerObjectXML struct {
+	XMLName    xml.Name    `xml:"testsuites"`
+	Tests      string      `xml:"tests,attr,omitempty"`
+	Disabled   string      `xml:"disabled,attr,omitempty"`
+	Errors     string      `xml:"errors,attr,omitempty"`
+	Failures   string      `xml:"failures,attr,omitempty"`
+	Time       string      `xml:"time,attr,omitempty"`
+	Testsuites []Testsuite `xml:"testsuite"`
+}
+
+type junitCounters struct {
+	tests, failures, errors, skipped int
+}
+
+func (counters *junitCounters) add(testCase *TestCase) {
+	counters.tests++
+	switch {
+	case testCase.Failure != nil:
+		counters.failures++
+	case testCase.Error != nil:
+		counters.errors++
+	case testCase.Skipped != nil:
+		counters.skipped++
+	}
+}
+
+// resultDuration returns the time the test case took, from its start and end times.
+func resultDuration(result *claim.Result) time.Duration {
+	// Clean the time strings to remove the " m=" suffix
+	start, err := time.Parse(DateTimeFormatDirective, strings.Split(result.StartTime, " m=")[0])
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse(DateTimeFormatDirective, strings.Split(result.EndTime, " m=")[0])
+	if err != nil {
+		return 0
+	}
+
+	return end.Sub(start)
+}
+
+// ReportObjectName returns the name identifying the object from a run to another, used for its test
+// case, made of its type and its fields but the reasons and waiver ones, which may change, e.g.
+// Container{Namespace=tnf, Pod Name=test-0, Container Name=test}.
+func ReportObjectName(obj *testhelper.ReportObject) string {
+	fields := []string{}
+	for i, key := range obj.ObjectFieldsKeys {
+		switch key {
+		case testhelper.ReasonForCompliance, testhelper.ReasonForNonCompliance, testhelper.WaiverJustification, testhelper.WaiverExpires:
+			continue
+		}
+		if i < len(obj.ObjectFieldsValues) {
+			fields = append(fields, key+"="+obj.ObjectFieldsValues[i])
+		}
+	}
+
+	return obj.ObjectType + "{" + strings.Join(fields, ", ") + "}"
+}
+
+// objectFieldsText returns the object's fields, one per line.
+func objectFieldsText(obj *testhelper.ReportObject) string {
+	lines := []string{}
+	for i, key := range obj.ObjectFieldsKeys {
+		if i < len(obj.ObjectFieldsValues) {
+			lines = append(lines, key+": "+obj.ObjectFieldsValues[i])
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// newCheckTestCase returns the test case of a check whose objects are not reported.
+func newCheckTestCase(testID string, result *claim.Result) TestCase {
+	testCase := TestCase{
+		Name:      testID,
+		Classname: testID,
+		Status:    result.State,
+		Time:      strconv.FormatFloat(resultDuration(result).Seconds(), 'f', 5, 64),
+	}
+
+	switch {
+	case result.State == TestStateSkipped:
+		testCase.Skipped = &SkippedMessage{Text: result.SkipReason}
+	case result.State == TestStateFailed:
+		testCase.Failure = &FailureMessage{Message: "Test case " + testID + " failed", Text: result.CheckDetails}
+	case result.State == TestStateError:
+		testCase.Error = &FailureMessage{Message: result.SkipReason, Text: result.CapturedTestOutput}
+	}
+
+	return testCase
+}
+
+// newObjectTestCases returns a test case per report object of the check, whose name is unique in
+// the check. Returns an empty slice if the check did not report any.
+func newObjectTestCases(testID string, details *testhelper.FailureReasonOut) []TestCase {
+	testCases := []TestCase{}
+	names := map[string]int{}
+	newTestCase := func(obj *testhelper.ReportObject, status string) TestCase {
+		name := ReportObjectName(obj)
+		names[name]++
+		if names[name] > 1 {
+			name += " #" + strconv.Itoa(names[name])
+		}
+
+		return TestCase{Name: name, Classname: testID, Status: status}
+	}
+
+	for _, obj := range details.NonCompliantObjectsOut {
+		if obj == nil {
+			continue
+		}
+		testCase := newTestCase(obj, TestStateFailed)
+		testCase.Failure = &FailureMessage{Message: reportObjectFields(obj)[testhelper.ReasonForNonCompliance], Text: objectFieldsText(obj)}
+		testCases = append(testCases, testCase)
+	}
+	for _, obj := range details.WaivedObjectsOut {
+		if obj == nil {
+			continue
+		}
+		fields := reportObjectFields(obj)
+		testCase := newTestCase(obj, junitStatusWaived)
+		testCase.Skipped = &SkippedMessage{
+			Messages: "Waived until " + fields[testhelper.WaiverExpires] + ": " + fields[testhelper.WaiverJustification],
+			Text:     objectFieldsText(obj),
+		}
+		testCases = append(testCases, testCase)
+	}
+	for _, obj := range details.CompliantObjectsOut {
+		if obj != nil {
+			testCases = append(testCases, newTestCase(obj, junitStatusPassed))
+		}
+	}
+
+	return testCases
+}
+
+// newResultTestCases returns the test cases of a check result: a test case per report object of the
+// checks that reported them, and a single test case for the rest, or if the check failed without
+// reporting any non-compliant object.
+func newResultTestCases(testID string, result *claim.Result) []TestCase {
+	if result.State != TestStateFailed && !strings.HasPrefix(result.State, junitStatusPassed) {
+		return []TestCase{newCheckTestCase(testID, result)}
+	}
+
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(result.CheckDetails), &details); err != nil {
+		return []TestCase{newCheckTestCase(testID, result)}
+	}
+
+	testCases := newObjectTestCases(testID, &details)
+	if len(testCases) == 0 || (result.State == TestStateFailed && len(details.NonCompliantObjectsOut) == 0) {
+		testCases = append([]TestCase{newCheckTestCase(testID, result)}, testCases...)
+	}
+
+	return testCases
+}
+
+// populatePerObjectXMLFromClaim returns the JUnit XML of the claim with a test suite per certsuite
+// suite, and a test case per report object of each check.
+func populatePerObjectXMLFromClaim(c *claim.Claim, startTime, endTime time.Time) TestSuitesPerObjectXML {
+	testIDsBySuite := map[string][]string{}
+	for testID := range c.Results {
+		suite := ""
+		if c.Results[testID].TestID != nil {
+			suite = c.Results[testID].TestID.Suite
+		}
+		testIDsBySuite[suite] = append(testIDsBySuite[suite], testID)
+	}
+
+	suites := []string{}
+	for suite := range testIDsBySuite {
+		suites = append(suites, suite)
+	}
+	sort.Strings(suites)
+
+	xmlOutput := TestSuitesPerObjectXML{
+		Time: strconv.FormatFloat(endTime.Sub(startTime).Seconds(), 'f', 5, 64),
+	}
+	totalCounters := junitCounters{}
+	timestamp := time.Now().UTC().Format(DateTimeFormatDirective)
+	for _, suite := range suites {
+		testIDs := testIDsBySuite[suite]
+		sort.Strings(testIDs)
+
+		testSuite := Testsuite{Name: suite, Timestamp: timestamp}
+		counters := junitCounters{}
+		var suiteDuration time.Duration
+		for _, testID := range testIDs {
+			result := c.Results[testID]
+			suiteDuration += resultDuration(&result)
+			for _, testCase := range newResultTestCases(testID, &result) {
+				counters.add(&testCase)
+				totalCounters.add(&testCase)
+				testSuite.Testcase = append(testSuite.Testcase, testCase)
+			}
+		}
+
+		testSuite.Tests = strconv.Itoa(counters.tests)
+		testSuite.Failures = strconv.Itoa(counters.failures)
+		testSuite.Errors = strconv.Itoa(counters.errors)
+		testSuite.Skipped = strconv.Itoa(counters.skipped)
+		testSuite.Time = strconv.FormatFloat(suiteDuration.Seconds(), 'f', 5, 64)
+		xmlOutput.Testsuites = append(xmlOutput.Testsuites, testSuite)
+	}
+
+	xmlOutput.Tests = strconv.Itoa(totalCounters.tests)
+	xmlOutput.Failures = strconv.Itoa(totalCounters.failures)
+	xmlOutput.Errors = strconv.Itoa(totalCounters.errors)
+	xmlOutput.Disabled = strconv.Itoa(totalCounters.skipped)
+
+	return xmlOutput
+}
+
+// ToJUnitXMLPerObject writes the JUnit XML file with a test suite per certsuite suite, and a test
+// case per report object of each check, named after the object's type and fields.
+func (c *ClaimBuilder) ToJUnitXMLPerObject(outputFile string, startTime, endTime time.Time) {
+	xmlOutput := populatePerObjectXMLFromClaim(c.claimRoot.Claim, startTime, endTime)
+
+	payload, err := xml.MarshalIndent(xmlOutput, "", "  ")
+	if err != nil {
+		log.Fatal("Failed to generate the xml: %v", err)
+	}
+
+	log.Info("Writing JUnit XML file: %s", outputFile)
+	err = os.WriteFile(outputFile, payload, claimFilePermissions)
+	if err != nil {
+		log.Fatal("Failed to write the xml file")
+	}
+}
@@ -0,0 +1,142 @@
+package claimhelper
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func junitTestClaim(t *testing.T) *claim.Claim {
+	t.Helper()
+
+	netAdminDetails, err := testhelper.ResultObjectsToString(
+		[]*testhelper.ReportObject{testhelper.NewContainerReportObject("tnf", "test-0", "test", "No NET_ADMIN", true)},
+		[]*testhelper.ReportObject{
+			testhelper.NewContainerReportObject("tnf", "test-1", "test", "NET_ADMIN found", false),
+			// Same fields, reported twice.
+			testhelper.NewContainerReportObject("tnf", "test-1", "test", "NET_ADMIN found", false),
+		},
+		[]*testhelper.ReportObject{
+			testhelper.NewContainerReportObject("tnf", "xdp-0", "test", "NET_ADMIN found", false).
+				AddField(testhelper.WaiverJustification, "XDP data plane").
+				AddField(testhelper.WaiverExpires, "2026-12-31"),
+		})
+	require.NoError(t, err)
+
+	return &claim.Claim{Results: map[string]claim.Result{
+		"access-control-net-admin-capability-check": {
+			TestID:       &claim.Identifier{Id: "access-control-net-admin-capability-check", Suite: "access-control"},
+			State:        TestStateFailed,
+			StartTime:    "2023-12-20 14:51:33 -0600 MST",
+			EndTime:      "2023-12-20 14:51:35 -0600 MST",
+			CheckDetails: netAdminDetails,
+		},
+		"access-control-ssh-daemons": {
+			TestID:       &claim.Identifier{Id: "access-control-ssh-daemons", Suite: "access-control"},
+			State:        TestStateFailed,
+			CheckDetails: `{"CompliantObjectsOut":null,"NonCompliantObjectsOut":null}`,
+		},
+		"lifecycle-pod-scheduling": {
+			TestID:     &claim.Identifier{Id: "lifecycle-pod-scheduling", Suite: "lifecycle"},
+			State:      TestStateSkipped,
+			SkipReason: "no pods",
+		},
+		"lifecycle-pod-owner-type": {
+			TestID:     &claim.Identifier{Id: "lifecycle-pod-owner-type", Suite: "lifecycle"},
+			State:      TestStateError,
+			SkipReason: "check timed out after 1m0s",
+		},
+	}}
+}
+
+func TestReportObjectName(t *testing.T) {
+	obj := testhelper.NewContainerReportObject("tnf", "test-0", "test", "reason", false).
+		AddField(testhelper.SCCCapability, "NET_ADMIN").
+		AddField(testhelper.WaiverJustification, "justification")
+
+	assert.Equal(t, "Container{Namespace=tnf, Pod Name=test-0, Container Name=test, SCC Capability=NET_ADMIN}", ReportObjectName(obj))
+	assert.Equal(t, "Cluster{}", ReportObjectName(testhelper.NewReportObject("reason", "Cluster", true)))
+}
+
+func TestPopulatePerObjectXMLFromClaim(t *testing.T) {
+	startTime := time.Now()
+	xmlOutput := populatePerObjectXMLFromClaim(junitTestClaim(t), startTime, startTime.Add(time.Minute))
+
+	assert.Equal(t, "7", xmlOutput.Tests)
+	assert.Equal(t, "3", xmlOutput.Failures)
+	assert.Equal(t, "1", xmlOutput.Errors)
+	assert.Equal(t, "2", xmlOutput.Disabled)
+	assert.Equal(t, "60.00000", xmlOutput.Time)
+
+	// A test suite per certsuite suite.
+	require.Len(t, xmlOutput.Testsuites, 2)
+	accessControl := xmlOutput.Testsuites[0]
+	assert.Equal(t, "access-control", accessControl.Name)
+	assert.Equal(t, "5", accessControl.Tests)
+	assert.Equal(t, "3", accessControl.Failures)
+	assert.Equal(t, "1", accessControl.Skipped)
+	assert.Equal(t, "2.00000", accessControl.Time)
+
+	// A test case per object, with unique names.
+	require.Len(t, accessControl.Testcase, 5)
+	nonCompliant := accessControl.Testcase[0]
+	assert.Equal(t, "Container{Namespace=tnf, Pod Name=test-1, Container Name=test}", nonCompliant.Name)
+	assert.Equal(t, "access-control-net-admin-capability-check", nonCompliant.Classname)
+	assert.Equal(t, TestStateFailed, nonCompliant.Status)
+	require.NotNil(t, nonCompliant.Failure)
+	assert.Equal(t, "NET_ADMIN found", nonCompliant.Failure.Message)
+	assert.Contains(t, nonCompliant.Failure.Text, "Pod Name: test-1")
+	assert.Equal(t, "Container{Namespace=tnf, Pod Name=test-1, Container Name=test} #2", accessControl.Testcase[1].Name)
+
+	waived := accessControl.Testcase[2]
+	assert.Equal(t, "Container{Namespace=tnf, Pod Name=xdp-0, Container Name=test}", waived.Name)
+	assert.Equal(t, "waived", waived.Status)
+	require.NotNil(t, waived.Skipped)
+	assert.Equal(t, "Waived until 2026-12-31: XDP data plane", waived.Skipped.Messages)
+
+	compliant := accessControl.Testcase[3]
+	assert.Equal(t, "Container{Namespace=tnf, Pod Name=test-0, Container Name=test}", compliant.Name)
+	assert.Equal(t, "passed", compliant.Status)
+	assert.Nil(t, compliant.Failure)
+	assert.Nil(t, compliant.Skipped)
+
+	// Failed without non-compliant objects.
+	sshDaemons := accessControl.Testcase[4]
+	assert.Equal(t, "access-control-ssh-daemons", sshDaemons.Name)
+	require.NotNil(t, sshDaemons.Failure)
+
+	lifecycle := xmlOutput.Testsuites[1]
+	assert.Equal(t, "lifecycle", lifecycle.Name)
+	require.Len(t, lifecycle.Testcase, 2)
+	require.NotNil(t, lifecycle.Testcase[0].Error)
+	assert.Equal(t, "check timed out after 1m0s", lifecycle.Testcase[0].Error.Message)
+	require.NotNil(t, lifecycle.Testcase[1].Skipped)
+	assert.Equal(t, "no pods", lifecycle.Testcase[1].Skipped.Text)
+}
+
+func TestToJUnitXMLPerObject(t *testing.T) {
+	t.Setenv("UNIT_TEST", unitTestEnvTrue)
+
+	claimBuilder, err := NewClaimBuilder(&provider.TestEnvironment{})
+	require.NoError(t, err)
+	claimBuilder.claimRoot.Claim = junitTestClaim(t)
+
+	outputFile := filepath.Join(t.TempDir(), "junit.xml")
+	claimBuilder.ToJUnitXMLPerObject(outputFile, time.Now(), time.Now())
+
+	payload, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), `<testsuite name="access-control" tests="5" skipped="1" errors="0" failures="3"`)
+
+	xmlOutput := TestSuitesPerObjectXML{}
+	require.NoError(t, xml.Unmarshal(payload, &xmlOutput))
+	assert.Len(t, xmlOutput.Testsuites, 2)
+}
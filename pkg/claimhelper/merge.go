@@ -0,0 +1,302 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package claimhelper
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+)
+
+const (
+	// MergeStrategyWorstState keeps the worst result of each test case, with the objects reported
+	// by all the merged results.
+	MergeStrategyWorstState = "worst-state-wins"
+	// MergeStrategyLatest keeps the result of each test case from the claim file that ended last.
+	MergeStrategyLatest = "latest-wins"
+
+	// MergeProvenanceKey is the claim configurations key with the claim files merged into the claim,
+	// and the ones each result comes from.
+	MergeProvenanceKey = "mergeProvenance"
+)
+
+// MergeStrategies are the rules to solve the conflicts between the results of a test case in the
+// merged claim files.
+var MergeStrategies = []string{MergeStrategyWorstState, MergeStrategyLatest}
+
+// Severity of the test case states with the worst-state-wins strategy, unknown ones being as bad
+// as errors. Skipped results never win over the rest, as the test case likely ran in another claim.
+var stateSeverity = map[string]int{
+	TestStateSkipped:      0,
+	"passed":              1,
+	"passed-after-retry":  2,
+	"passed-with-waivers": 3,
+	TestStateError:        4,
+	TestStateFailed:       5,
+}
+
+// ClaimFile is a claim file to be merged.
+type ClaimFile struct {
+	Name string
+	Root *claim.Root
+}
+
+// MergeSource is a claim file merged into the claim.
+type MergeSource struct {
+	File      string `json:"file"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	CertSuite string `json:"certSuite"`
+}
+
+// MergeProvenance records where the merged claim comes from, in its configurations.
+type MergeProvenance struct {
+	Strategy string        `json:"strategy"`
+	Sources  []MergeSource `json:"sources"`
+	// Claim files with a result of each test case, the one the merged result comes from first.
+	Results map[string][]string `json:"results"`
+}
+
+// LoadClaimFile reads and unmarshals the claim file.
+func LoadClaimFile(claimFileName string) (*claim.Root, error) {
+	payload, err := ReadClaimFile(claimFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var root claim.Root
+	if err := json.Unmarshal(payload, &root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal claim file %s: %w", claimFileName, err)
+	}
+	if root.Claim == nil || root.Claim.Metadata == nil {
+		return nil, fmt.Errorf("claim file %s has no claim", claimFileName)
+	}
+
+	return &root, nil
+}
+
+// GetMergeStrategy returns the merge strategy with the given name.
+func GetMergeStrategy(name string) (string, error) {
+	for _, strategy := range MergeStrategies {
+		if strategy == name {
+			return strategy, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid merge strategy %q, valid strategies are %s", name, strings.Join(MergeStrategies, ", "))
+}
+
+// parseClaimTime returns the claim metadata time, or the zero time if it can't be parsed.
+func parseClaimTime(value string) time.Time {
+	t, err := time.Parse(DateTimeFormatDirective, strings.Split(value, " m=")[0])
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+// mergeJSONValues returns the union of both unmarshaled json values: objects are merged field by
+// field, arrays have the items of both without repetitions, and the second value wins otherwise.
+func mergeJSONValues(value1, value2 interface{}) interface{} {
+	switch typedValue2 := value2.(type) {
+	case nil:
+		return value1
+	case map[string]interface{}:
+		typedValue1, ok := value1.(map[string]interface{})
+		if !ok {
+			return value2
+		}
+		merged := map[string]interface{}{}
+		for key := range typedValue1 {
+			merged[key] = typedValue1[key]
+		}
+		for key := range typedValue2 {
+			merged[key] = mergeJSONValues(merged[key], typedValue2[key])
+		}
+		return merged
+	case []interface{}:
+		typedValue1, ok := value1.([]interface{})
+		if !ok {
+			return value2
+		}
+		merged := []interface{}{}
+		items := map[string]bool{}
+		for _, item := range append(append([]interface{}{}, typedValue1...), typedValue2...) {
+			key, err := json.Marshal(item)
+			if err != nil || !items[string(key)] {
+				items[string(key)] = true
+				merged = append(merged, item)
+			}
+		}
+		return merged
+	}
+
+	return value2
+}
+
+// mergeReportObjects returns the objects of both lists, without repeating the objects of the
+// second list with the same name and reasons as objects of the first one.
+func mergeReportObjects(objects1, objects2 []*testhelper.ReportObject) []*testhelper.ReportObject {
+	objectKey := func(obj *testhelper.ReportObject) string {
+		fields := reportObjectFields(obj)
+		return ReportObjectName(obj) + fields[testhelper.ReasonForCompliance] + fields[testhelper.ReasonForNonCompliance]
+	}
+
+	merged := []*testhelper.ReportObject{}
+	keys := map[string]bool{}
+	for _, obj := range append(append([]*testhelper.ReportObject{}, objects1...), objects2...) {
+		if obj == nil || keys[objectKey(obj)] {
+			continue
+		}
+		keys[objectKey(obj)] = true
+		merged = append(merged, obj)
+	}
+
+	return merged
+}
+
+// mergeCheckDetails returns the check details with the report objects of all the results, or the
+// details of the first one if any of them can't be parsed.
+func mergeCheckDetails(results []*claim.Result) string {
+	merged := testhelper.FailureReasonOut{}
+	for _, result := range results {
+		details := testhelper.FailureReasonOut{}
+		if err := json.Unmarshal([]byte(result.CheckDetails), &details); err != nil {
+			return results[0].CheckDetails
+		}
+		merged.CompliantObjectsOut = mergeReportObjects(merged.CompliantObjectsOut, details.CompliantObjectsOut)
+		merged.NonCompliantObjectsOut = mergeReportObjects(merged.NonCompliantObjectsOut, details.NonCompliantObjectsOut)
+		merged.WaivedObjectsOut = mergeReportObjects(merged.WaivedObjectsOut, details.WaivedObjectsOut)
+	}
+
+	checkDetails, err := testhelper.ResultObjectsToString(merged.CompliantObjectsOut, merged.NonCompliantObjectsOut, merged.WaivedObjectsOut)
+	if err != nil {
+		return results[0].CheckDetails
+	}
+
+	return checkDetails
+}
+
+// mergeResult returns the index of the result that wins with the strategy among the results of a
+// test case, sorted from the claim file that ended first to the one that ended last, and the merged
+// result.
+func mergeResult(results []*claim.Result, strategy string) (int, claim.Result) {
+	selected := len(results) - 1
+	for i := len(results) - 1; i >= 0; i-- {
+		if strategy == MergeStrategyLatest {
+			if results[i].State != TestStateSkipped {
+				selected = i
+				break
+			}
+			continue
+		}
+
+		severity, found := stateSeverity[results[i].State]
+		if !found {
+			severity = stateSeverity[TestStateError]
+		}
+		selectedSeverity, found := stateSeverity[results[selected].State]
+		if !found {
+			selectedSeverity = stateSeverity[TestStateError]
+		}
+		if severity > selectedSeverity {
+			selected = i
+		}
+	}
+
+	merged := *results[selected]
+	if strategy == MergeStrategyWorstState && merged.State != TestStateSkipped {
+		ranResults := []*claim.Result{results[selected]}
+		for i, result := range results {
+			if i != selected && result.State != TestStateSkipped {
+				ranResults = append(ranResults, result)
+			}
+		}
+		if len(ranResults) > 1 {
+			merged.CheckDetails = mergeCheckDetails(ranResults)
+		}
+	}
+
+	return selected, merged
+}
+
+// MergeClaims returns the claim with the results of all the claim files, the conflicts between the
+// results of a test case being solved with the strategy, and their configurations and nodes
+// combined. The versions are the ones of the claim file that ended last. The configurations record
+// the provenance of every result, and the verdict of the merged results.
+func MergeClaims(claimFiles []ClaimFile, strategy string) (*claim.Root, error) {
+	if len(claimFiles) == 0 {
+		return nil, fmt.Errorf("no claim files to merge")
+	}
+	if _, err := GetMergeStrategy(strategy); err != nil {
+		return nil, err
+	}
+
+	sortedFiles := append([]ClaimFile{}, claimFiles...)
+	sort.SliceStable(sortedFiles, func(i, j int) bool {
+		return parseClaimTime(sortedFiles[i].Root.Claim.Metadata.EndTime).Before(parseClaimTime(sortedFiles[j].Root.Claim.Metadata.EndTime))
+	})
+
+	latest := sortedFiles[len(sortedFiles)-1].Root.Claim
+	merged := &claim.Claim{
+		Metadata:       &claim.Metadata{StartTime: latest.Metadata.StartTime, EndTime: latest.Metadata.EndTime},
+		Versions:       latest.Versions,
+		Configurations: map[string]interface{}{},
+		Nodes:          map[string]interface{}{},
+		Results:        map[string]claim.Result{},
+	}
+	provenance := MergeProvenance{Strategy: strategy, Sources: []MergeSource{}, Results: map[string][]string{}}
+
+	testResults := map[string][]*claim.Result{}
+	testFiles := map[string][]string{}
+	for _, claimFile := range sortedFiles {
+		c := claimFile.Root.Claim
+		source := MergeSource{File: claimFile.Name, StartTime: c.Metadata.StartTime, EndTime: c.Metadata.EndTime}
+		if c.Versions != nil {
+			source.CertSuite = c.Versions.CertSuite
+		}
+		provenance.Sources = append(provenance.Sources, source)
+
+		if parseClaimTime(c.Metadata.StartTime).Before(parseClaimTime(merged.Metadata.StartTime)) {
+			merged.Metadata.StartTime = c.Metadata.StartTime
+		}
+
+		for key := range c.Configurations {
+			merged.Configurations[key] = mergeJSONValues(merged.Configurations[key], c.Configurations[key])
+		}
+		for key := range c.Nodes {
+			merged.Nodes[key] = mergeJSONValues(merged.Nodes[key], c.Nodes[key])
+		}
+
+		for testID := range c.Results {
+			result := c.Results[testID]
+			testResults[testID] = append(testResults[testID], &result)
+			testFiles[testID] = append(testFiles[testID], claimFile.Name)
+		}
+	}
+
+	for testID, results := range testResults {
+		selected, result := mergeResult(results, strategy)
+		merged.Results[testID] = result
+
+		files := []string{testFiles[testID][selected]}
+		for i, file := range testFiles[testID] {
+			if i != selected {
+				files = append(files, file)
+			}
+		}
+		provenance.Results[testID] = files
+	}
+
+	// The order the checks ran in each claim file can't be combined.
+	delete(merged.Configurations, ExecutionOrderKey)
+	merged.Configurations[VerdictKey] = NewVerdict(merged.Results, "")
+	merged.Configurations[MergeProvenanceKey] = provenance
+
+	return &claim.Root{Claim: merged}, nil
+}
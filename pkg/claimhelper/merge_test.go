@@ -0,0 +1,140 @@
+package claimhelper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeJSONValues(t *testing.T) {
+	value1 := map[string]interface{}{
+		"namespaces": []interface{}{"ns1", "common"},
+		"probe":      map[string]interface{}{"image": "probe:v1", "namespace": "certsuite"},
+		"onlyIn1":    true,
+	}
+	value2 := map[string]interface{}{
+		"namespaces": []interface{}{"common", "ns2"},
+		"probe":      map[string]interface{}{"image": "probe:v2"},
+		"onlyIn2":    nil,
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"namespaces": []interface{}{"ns1", "common", "ns2"},
+		"probe":      map[string]interface{}{"image": "probe:v2", "namespace": "certsuite"},
+		"onlyIn1":    true,
+		"onlyIn2":    nil,
+	}, mergeJSONValues(value1, value2))
+
+	assert.Equal(t, "value2", mergeJSONValues([]interface{}{"value1"}, "value2"))
+	assert.Equal(t, "value1", mergeJSONValues("value1", nil))
+}
+
+func TestMergeResult(t *testing.T) {
+	podDetails := func(ok bool, pods ...string) string {
+		objects := []*testhelper.ReportObject{}
+		for _, pod := range pods {
+			objects = append(objects, testhelper.NewPodReportObject("tnf", pod, "reason", ok))
+		}
+		var details string
+		var err error
+		if ok {
+			details, err = testhelper.ResultObjectsToString(objects, nil, nil)
+		} else {
+			details, err = testhelper.ResultObjectsToString(nil, objects, nil)
+		}
+		require.NoError(t, err)
+		return details
+	}
+
+	// From the claim file that ended first to the one that ended last.
+	results := []*claim.Result{
+		{State: TestStateFailed, CheckDetails: podDetails(false, "pod-a")},
+		{State: "passed-with-waivers", CheckDetails: podDetails(true, "pod-b")},
+		{State: TestStateFailed, CheckDetails: podDetails(false, "pod-a", "pod-c")},
+		{State: TestStateSkipped, SkipReason: "no matching labels"},
+	}
+
+	selected, merged := mergeResult(results, MergeStrategyWorstState)
+	assert.Equal(t, 2, selected)
+	assert.Equal(t, TestStateFailed, merged.State)
+	details := testhelper.FailureReasonOut{}
+	require.NoError(t, json.Unmarshal([]byte(merged.CheckDetails), &details))
+	assert.Len(t, details.NonCompliantObjectsOut, 2)
+	assert.Len(t, details.CompliantObjectsOut, 1)
+	// The results are not modified.
+	assert.Equal(t, podDetails(false, "pod-a", "pod-c"), results[2].CheckDetails)
+
+	selected, merged = mergeResult(results, MergeStrategyLatest)
+	assert.Equal(t, 2, selected)
+	assert.Equal(t, *results[2], merged)
+
+	selected, merged = mergeResult(results[:2], MergeStrategyLatest)
+	assert.Equal(t, 1, selected)
+	assert.Equal(t, *results[1], merged)
+
+	skipped := []*claim.Result{{State: TestStateSkipped, SkipReason: "first"}, {State: TestStateSkipped, SkipReason: "last"}}
+	for _, strategy := range MergeStrategies {
+		selected, merged = mergeResult(skipped, strategy)
+		assert.Equal(t, 1, selected)
+		assert.Equal(t, "last", merged.SkipReason)
+	}
+}
+
+func TestMergeClaims(t *testing.T) {
+	newClaimFile := func(name, endTime, namespace string, results map[string]claim.Result) ClaimFile {
+		return ClaimFile{Name: name, Root: &claim.Root{Claim: &claim.Claim{
+			Metadata: &claim.Metadata{StartTime: "2026-10-17 00:00:00 +0000 UTC", EndTime: endTime},
+			Versions: &claim.Versions{CertSuite: name, ClaimFormat: "v0.5.0"},
+			Configurations: map[string]interface{}{
+				"Config":          map[string]interface{}{"targetNameSpaces": []interface{}{namespace}},
+				ExecutionOrderKey: []interface{}{namespace},
+			},
+			Nodes:   map[string]interface{}{"nodeSummary": map[string]interface{}{namespace + "-node": "summary"}},
+			Results: results,
+		}}}
+	}
+
+	claimFiles := []ClaimFile{
+		newClaimFile("latest.json", "2026-10-17 00:30:00 +0000 UTC", "ns2", map[string]claim.Result{
+			"access-control-ssh-daemons": {State: "passed"},
+			"lifecycle-pod-scheduling":   {State: TestStateSkipped},
+		}),
+		newClaimFile("first.json", "2026-10-17 00:10:00 +0000 UTC", "ns1", map[string]claim.Result{
+			"access-control-ssh-daemons": {State: TestStateFailed},
+		}),
+	}
+
+	root, err := MergeClaims(claimFiles, MergeStrategyWorstState)
+	require.NoError(t, err)
+	merged := root.Claim
+	assert.Equal(t, "latest.json", merged.Versions.CertSuite)
+	assert.Equal(t, "2026-10-17 00:30:00 +0000 UTC", merged.Metadata.EndTime)
+	assert.Equal(t, TestStateFailed, merged.Results["access-control-ssh-daemons"].State)
+	assert.Equal(t, TestStateSkipped, merged.Results["lifecycle-pod-scheduling"].State)
+	assert.Equal(t, map[string]interface{}{"targetNameSpaces": []interface{}{"ns1", "ns2"}}, merged.Configurations["Config"])
+	assert.Equal(t, map[string]interface{}{"ns1-node": "summary", "ns2-node": "summary"}, merged.Nodes["nodeSummary"])
+	assert.NotContains(t, merged.Configurations, ExecutionOrderKey)
+
+	provenance, ok := merged.Configurations[MergeProvenanceKey].(MergeProvenance)
+	require.True(t, ok)
+	assert.Equal(t, MergeStrategyWorstState, provenance.Strategy)
+	require.Len(t, provenance.Sources, 2)
+	assert.Equal(t, "first.json", provenance.Sources[0].File)
+	assert.Equal(t, []string{"first.json", "latest.json"}, provenance.Results["access-control-ssh-daemons"])
+	assert.Equal(t, []string{"latest.json"}, provenance.Results["lifecycle-pod-scheduling"])
+
+	verdict, ok := merged.Configurations[VerdictKey].(Verdict)
+	require.True(t, ok)
+	assert.Equal(t, []string{"access-control-ssh-daemons"}, verdict.GetCategory("Telco").FailedMandatoryChecks)
+
+	root, err = MergeClaims(claimFiles, MergeStrategyLatest)
+	require.NoError(t, err)
+	assert.Equal(t, "passed", root.Claim.Results["access-control-ssh-daemons"].State)
+
+	_, err = MergeClaims(claimFiles, "first-wins")
+	assert.EqualError(t, err, `invalid merge strategy "first-wins", valid strategies are worst-state-wins, latest-wins`)
+}
@@ -0,0 +1,550 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package claimhelper
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// RedactionActionHash replaces the redacted values with a pseudonym, the rule name and the
+	// start of their salted hash, so equal values have equal pseudonyms in every claim file.
+	RedactionActionHash = "hash"
+	// RedactionActionMask replaces the redacted values with the rule mask.
+	RedactionActionMask = "mask"
+
+	// RedactionTargetValues redacts the string values at the rule paths.
+	RedactionTargetValues = "values"
+	// RedactionTargetKeys redacts the keys of the objects at the rule paths.
+	RedactionTargetKeys = "keys"
+
+	// DefaultRedactionProfile is the name of the built-in redaction profile.
+	DefaultRedactionProfile = "default"
+
+	defaultRedactionMask = "REDACTED"
+	pseudonymHashLength  = 12
+	randomSaltLength     = 32
+
+	// Segments of the rule paths matching any key or array index, and any number of them.
+	anySegment  = "*"
+	anySegments = "**"
+)
+
+//go:embed redaction/*.yaml
+var redactionProfiles embed.FS
+
+// RedactionRule redacts the claim values at some paths, or the parts of them matching a regex.
+type RedactionRule struct {
+	// Name of the rule, which is the prefix of its pseudonyms.
+	Name string `yaml:"name"`
+	// Paths of the redacted values, all the values if empty. The segments are separated by dots,
+	// ["key"] stands for keys with dots, * for any key or array index and ** for any number of them,
+	// e.g. **.metadata.annotations.* or claim.nodes.nodeSummary.*.metadata.labels["kubernetes.io/hostname"].
+	Paths []string `yaml:"paths"`
+	// Regex of the redacted parts of the values, the whole values if empty. When it has groups,
+	// only the first one is redacted.
+	Regex string `yaml:"regex"`
+	// Target is values (default) or keys.
+	Target string `yaml:"target"`
+	// Action is hash (default) or mask.
+	Action string `yaml:"action"`
+	// Mask of the redacted values with the mask action, REDACTED by default.
+	Mask string `yaml:"mask"`
+	// Propagate redacts the values found at the rule paths everywhere else in the claim, e.g. the
+	// node names in the test cases results.
+	Propagate bool `yaml:"propagate"`
+
+	paths [][]string
+	regex *regexp.Regexp
+}
+
+// RedactionProfile is a set of rules applied in order to redact a claim file before sharing it.
+type RedactionProfile struct {
+	// Salt of the pseudonyms hashes. Claim files must be redacted with the same salt to be compared.
+	Salt  string          `yaml:"salt"`
+	Rules []RedactionRule `yaml:"rules"`
+}
+
+// parseRedactionPath splits the path in its segments.
+func parseRedactionPath(path string) ([]string, error) {
+	segments := []string{}
+	for rest := path; rest != ""; {
+		var segment string
+		if strings.HasPrefix(rest, `["`) {
+			end := strings.Index(rest, `"]`)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unterminated [\"", path)
+			}
+			segment, rest = rest[2:end], rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			segment, rest = rest[:end], rest[end:]
+		}
+
+		if segment == "" {
+			return nil, fmt.Errorf("invalid path %q: empty segment", path)
+		}
+		segments = append(segments, segment)
+
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if rest == "" {
+				return nil, fmt.Errorf("invalid path %q: empty segment", path)
+			}
+		} else if rest != "" && !strings.HasPrefix(rest, "[") {
+			return nil, fmt.Errorf("invalid path %q: expected . or [ after %q", path, segment)
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, errors.New("empty path")
+	}
+
+	return segments, nil
+}
+
+// matchRedactionPath returns whether the path of a value matches the rule path segments.
+func matchRedactionPath(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == anySegments {
+		for i := 0; i <= len(path); i++ {
+			if matchRedactionPath(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 || (pattern[0] != anySegment && pattern[0] != path[0]) {
+		return false
+	}
+
+	return matchRedactionPath(pattern[1:], path[1:])
+}
+
+func (rule *RedactionRule) init() error {
+	if rule.Name == "" {
+		return errors.New("rule has no name")
+	}
+
+	switch rule.Action {
+	case "":
+		rule.Action = RedactionActionHash
+	case RedactionActionHash, RedactionActionMask:
+	default:
+		return fmt.Errorf("rule %s: invalid action %q, valid actions are %s and %s", rule.Name, rule.Action, RedactionActionHash, RedactionActionMask)
+	}
+
+	switch rule.Target {
+	case "":
+		rule.Target = RedactionTargetValues
+	case RedactionTargetValues, RedactionTargetKeys:
+	default:
+		return fmt.Errorf("rule %s: invalid target %q, valid targets are %s and %s", rule.Name, rule.Target, RedactionTargetValues, RedactionTargetKeys)
+	}
+
+	// Masked keys would overwrite each other.
+	if rule.Target == RedactionTargetKeys && rule.Action == RedactionActionMask {
+		return fmt.Errorf("rule %s: keys can only be hashed", rule.Name)
+	}
+
+	if rule.Mask == "" {
+		rule.Mask = defaultRedactionMask
+	}
+
+	rule.paths = [][]string{}
+	for _, path := range rule.Paths {
+		segments, err := parseRedactionPath(path)
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+		rule.paths = append(rule.paths, segments)
+	}
+
+	if rule.Regex != "" {
+		regex, err := regexp.Compile(rule.Regex)
+		if err != nil {
+			return fmt.Errorf("rule %s: invalid regex: %w", rule.Name, err)
+		}
+		rule.regex = regex
+	}
+
+	return nil
+}
+
+func (rule *RedactionRule) matchPath(path []string) bool {
+	if len(rule.paths) == 0 {
+		return true
+	}
+
+	for _, pattern := range rule.paths {
+		if matchRedactionPath(pattern, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches returns the start and end indexes of the redacted parts of the value.
+func (rule *RedactionRule) matches(value string) [][2]int {
+	if value == "" {
+		return nil
+	}
+
+	if rule.regex == nil {
+		return [][2]int{{0, len(value)}}
+	}
+
+	matches := [][2]int{}
+	for _, match := range rule.regex.FindAllStringSubmatchIndex(value, -1) {
+		if len(match) > 2 {
+			// Redact the first group only, if it matched.
+			if match[2] >= 0 && match[3] > match[2] {
+				matches = append(matches, [2]int{match[2], match[3]})
+			}
+		} else if match[1] > match[0] {
+			matches = append(matches, [2]int{match[0], match[1]})
+		}
+	}
+
+	return matches
+}
+
+// ParseRedactionProfile parses and validates a YAML redaction profile.
+func ParseRedactionProfile(content []byte) (*RedactionProfile, error) {
+	profile := RedactionProfile{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&profile); err != nil {
+		return nil, fmt.Errorf("failed to parse the redaction profile: %w", err)
+	}
+
+	if len(profile.Rules) == 0 {
+		return nil, errors.New("the redaction profile has no rules")
+	}
+
+	for i := range profile.Rules {
+		if err := profile.Rules[i].init(); err != nil {
+			return nil, fmt.Errorf("invalid redaction profile: %w", err)
+		}
+	}
+
+	return &profile, nil
+}
+
+// LoadRedactionProfile returns the built-in redaction profile with the given name, or the one in
+// the given YAML file.
+func LoadRedactionProfile(nameOrFile string) (*RedactionProfile, error) {
+	content, err := redactionProfiles.ReadFile("redaction/" + nameOrFile + ".yaml")
+	if err != nil {
+		content, err = os.ReadFile(nameOrFile)
+		if err != nil {
+			return nil, fmt.Errorf("no built-in redaction profile %q, and failed to read it as a file: %w", nameOrFile, err)
+		}
+	}
+
+	profile, err := ParseRedactionProfile(content)
+	if err != nil {
+		return nil, fmt.Errorf("redaction profile %s: %w", nameOrFile, err)
+	}
+
+	return profile, nil
+}
+
+// SetSalt sets the salt of the pseudonyms hashes, if not empty. Without a salt, neither given nor in
+// the profile, a random one is set, as values like IP addresses can be guessed from their unsalted
+// hash, so the claim files redacted with the profile can't be compared.
+func (profile *RedactionProfile) SetSalt(salt string) error {
+	if salt != "" {
+		profile.Salt = salt
+		return nil
+	}
+	if profile.Salt != "" {
+		return nil
+	}
+
+	randomSalt := make([]byte, randomSaltLength)
+	if _, err := rand.Read(randomSalt); err != nil {
+		return fmt.Errorf("failed to generate a random salt: %w", err)
+	}
+
+	log.Warn("The redaction profile has no salt, a random one is used, so the redacted claim file can't be compared with others")
+	profile.Salt = hex.EncodeToString(randomSalt)
+	return nil
+}
+
+// redactor applies a redaction profile to an unmarshaled claim.
+type redactor struct {
+	profile *RedactionProfile
+	// Pseudonyms of the values found at the paths of the rules that propagate.
+	propagated map[string]string
+	// The propagated values, longest first, so the values containing others are replaced first.
+	propagatedValues []string
+	// Values that are already redacted, which are not redacted again.
+	pseudonyms map[string]bool
+}
+
+func (r *redactor) pseudonym(rule *RedactionRule, value string) string {
+	if rule.Action == RedactionActionMask {
+		r.pseudonyms[rule.Mask] = true
+		return rule.Mask
+	}
+
+	hash := hmac.New(sha256.New, []byte(r.profile.Salt))
+	hash.Write([]byte(value))
+	pseudonym := rule.Name + "-" + hex.EncodeToString(hash.Sum(nil))[:pseudonymHashLength]
+	r.pseudonyms[pseudonym] = true
+
+	return pseudonym
+}
+
+// redactString redacts the parts of the value matching the rule.
+func (r *redactor) redactString(rule *RedactionRule, value string) string {
+	if r.pseudonyms[value] {
+		return value
+	}
+
+	matches := rule.matches(value)
+	if len(matches) == 0 {
+		return value
+	}
+
+	redacted := strings.Builder{}
+	last := 0
+	for _, match := range matches {
+		redacted.WriteString(value[last:match[0]])
+		redacted.WriteString(r.pseudonym(rule, value[match[0]:match[1]]))
+		last = match[1]
+	}
+	redacted.WriteString(value[last:])
+
+	return redacted.String()
+}
+
+// collect finds the values to be propagated at the paths of the rules.
+func (r *redactor) collect(value interface{}, path []string) {
+	for i := range r.profile.Rules {
+		rule := &r.profile.Rules[i]
+		if !rule.Propagate || len(rule.paths) == 0 || !rule.matchPath(path) {
+			continue
+		}
+
+		found := []string{}
+		switch typedValue := value.(type) {
+		case map[string]interface{}:
+			if rule.Target == RedactionTargetKeys {
+				for key := range typedValue {
+					found = append(found, key)
+				}
+			}
+		case string:
+			if rule.Target == RedactionTargetValues {
+				found = append(found, typedValue)
+			}
+		}
+
+		for _, s := range found {
+			for _, match := range rule.matches(s) {
+				original := s[match[0]:match[1]]
+				if _, exists := r.propagated[original]; !exists {
+					r.propagated[original] = r.pseudonym(rule, original)
+				}
+			}
+		}
+	}
+
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typedValue {
+			r.collect(item, append(path, key))
+		}
+	case []interface{}:
+		for i, item := range typedValue {
+			r.collect(item, append(path, strconv.Itoa(i)))
+		}
+	}
+}
+
+// isWordByte returns whether the byte is part of names, so that a propagated value is not
+// replaced inside a longer name.
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '_' || b == '-'
+}
+
+// replacePropagated replaces the propagated values in the string, when they are whole words. A
+// dot is part of a word when it's followed by another word byte, as in hostnames.
+func (r *redactor) replacePropagated(s string) string {
+	for _, value := range r.propagatedValues {
+		if !strings.Contains(s, value) {
+			continue
+		}
+
+		replaced := strings.Builder{}
+		last := 0
+		for start := 0; start < len(s); {
+			index := strings.Index(s[start:], value)
+			if index < 0 {
+				break
+			}
+			index += start
+			end := index + len(value)
+
+			boundaryBefore := index == 0 || (!isWordByte(s[index-1]) && s[index-1] != '.')
+			boundaryAfter := end == len(s) || (!isWordByte(s[end]) && (s[end] != '.' || end+1 == len(s) || !isWordByte(s[end+1])))
+			if boundaryBefore && boundaryAfter {
+				replaced.WriteString(s[last:index])
+				replaced.WriteString(r.propagated[value])
+				last = end
+				start = end
+			} else {
+				start = index + 1
+			}
+		}
+		replaced.WriteString(s[last:])
+		s = replaced.String()
+	}
+
+	return s
+}
+
+// redact returns the redacted copy of the value, found at the path.
+func (r *redactor) redact(value interface{}, path []string) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(typedValue))
+		for key, item := range typedValue {
+			// The paths of the rules refer to the original keys.
+			redactedItem := r.redact(item, append(path, key))
+
+			if pseudonym, found := r.propagated[key]; found {
+				key = pseudonym
+			}
+			for i := range r.profile.Rules {
+				rule := &r.profile.Rules[i]
+				if rule.Target == RedactionTargetKeys && rule.matchPath(path) {
+					key = r.redactString(rule, key)
+				}
+			}
+			redacted[key] = redactedItem
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(typedValue))
+		for i, item := range typedValue {
+			redacted[i] = r.redact(item, append(path, strconv.Itoa(i)))
+		}
+		return redacted
+	case string:
+		redacted := r.replacePropagated(typedValue)
+		for i := range r.profile.Rules {
+			rule := &r.profile.Rules[i]
+			if rule.Target == RedactionTargetValues && rule.matchPath(path) {
+				redacted = r.redactString(rule, redacted)
+			}
+		}
+		return redacted
+	}
+
+	return value
+}
+
+// RedactClaim returns the claim file payload redacted with the profile. The values found at the
+// paths of the rules that propagate are redacted everywhere, including in the test cases results,
+// so the same value has the same pseudonym in the whole claim.
+func RedactClaim(payload []byte, profile *RedactionProfile) (*claim.Root, error) {
+	var unmarshaled interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&unmarshaled); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the claim: %w", err)
+	}
+
+	r := redactor{profile: profile, propagated: map[string]string{}, pseudonyms: map[string]bool{}}
+	r.collect(unmarshaled, []string{})
+	for value := range r.propagated {
+		r.propagatedValues = append(r.propagatedValues, value)
+	}
+	sort.Slice(r.propagatedValues, func(i, j int) bool {
+		if len(r.propagatedValues[i]) != len(r.propagatedValues[j]) {
+			return len(r.propagatedValues[i]) > len(r.propagatedValues[j])
+		}
+		return r.propagatedValues[i] < r.propagatedValues[j]
+	})
+
+	redactedPayload, err := json.Marshal(r.redact(unmarshaled, []string{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the redacted claim: %w", err)
+	}
+
+	var root claim.Root
+	if err := json.Unmarshal(redactedPayload, &root); err != nil {
+		return nil, fmt.Errorf("the redacted claim is not valid, check the rules targeting keys: %w", err)
+	}
+
+	return &root, nil
+}
+
+// RedactClaimFile saves the claim file redacted with the profile in the output file, which can be
+// the claim file itself.
+func RedactClaimFile(claimFileName, outputFileName string, profile *RedactionProfile) error {
+	log.Info("Redacting claim file %s into %s", claimFileName, outputFileName)
+	payload, err := ReadClaimFile(claimFileName)
+	if err != nil {
+		return err
+	}
+
+	root, err := RedactClaim(payload, profile)
+	if err != nil {
+		return fmt.Errorf("failed to redact claim file %s: %w", claimFileName, err)
+	}
+
+	redactedPayload, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the redacted claim: %w", err)
+	}
+
+	if err := os.WriteFile(outputFileName, redactedPayload, claimFilePermissions); err != nil {
+		return fmt.Errorf("failed to write the redacted claim file %s: %w", outputFileName, err)
+	}
+
+	return nil
+}
+
+// Redact redacts the claim file built with the profile, in place, and makes the claim built the
+// redacted one, so the artifacts created from it afterwards are redacted too.
+func (c *ClaimBuilder) Redact(claimFileName string, profile *RedactionProfile) error {
+	if err := RedactClaimFile(claimFileName, claimFileName, profile); err != nil {
+		return err
+	}
+
+	root, err := LoadClaimFile(claimFileName)
+	if err != nil {
+		return err
+	}
+
+	c.claimRoot = root
+	return nil
+}
@@ -0,0 +1,189 @@
+package claimhelper
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redactTestClaim = `{
+  "claim": {
+    "configurations": {
+      "testPods": [
+        {
+          "metadata": {"name": "pod-a", "annotations": {"k8s.v1.cni.cncf.io/network-status": "[{\"ips\": [\"10.128.0.12\"]}]"}},
+          "spec": {
+            "nodeName": "worker-0",
+            "containers": [{"image": "registry.example.com:5000/team/app:v1", "env": [{"name": "PASSWORD", "value": "secret"}]}]
+          },
+          "status": {"hostIP": "192.168.1.10", "podIP": "10.128.0.12"}
+        }
+      ]
+    },
+    "nodes": {
+      "nodeSummary": {
+        "worker-0": {"metadata": {"name": "worker-0", "labels": {"kubernetes.io/hostname": "worker-0.example.com"}}}
+      },
+      "nodesHwInfo": {"worker-0": {"Lscpu": []}}
+    },
+    "metadata": {"startTime": "2026-10-17 00:00:00 +0000 UTC", "endTime": "2026-10-17 00:10:00 +0000 UTC"},
+    "versions": {"certSuite": "v5.6.0", "claimFormat": "v0.5.0", "k8s": "v1.30.0", "ocClient": "", "ocp": ""},
+    "results": {
+      "lifecycle-pod-scheduling": {
+        "testID": {"id": "lifecycle-pod-scheduling", "suite": "lifecycle", "tags": "common"},
+        "state": "failed",
+        "checkDetails": "{\"NonCompliantObjectsOut\": [{\"ObjectType\": \"Pod\", \"ObjectFieldsKeys\": [\"Node Name\", \"Pod Name\"], \"ObjectFieldsValues\": [\"worker-0\", \"worker-0-pod\"]}]}",
+        "skipReason": "",
+        "capturedTestOutput": "",
+        "startTime": "",
+        "endTime": "",
+        "duration": 1,
+        "failureLineContent": "",
+        "failureLocation": "",
+        "catalogInfo": {"bestPracticeReference": "", "description": "", "exceptionProcess": "", "remediation": ""},
+        "categoryClassification": {"Extended": "Mandatory", "FarEdge": "Mandatory", "NonTelco": "Mandatory", "Telco": "Mandatory"}
+      }
+    }
+  }
+}`
+
+func TestParseRedactionPath(t *testing.T) {
+	segments, err := parseRedactionPath(`**.metadata.labels["kubernetes.io/hostname"].*`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"**", "metadata", "labels", "kubernetes.io/hostname", "*"}, segments)
+
+	for _, path := range []string{"", "a..b", "a.", `a["b`, `a["b"]c`} {
+		_, err := parseRedactionPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestMatchRedactionPath(t *testing.T) {
+	path := []string{"claim", "configurations", "testPods", "0", "metadata", "annotations", "key"}
+
+	assert.True(t, matchRedactionPath([]string{"**", "metadata", "annotations", "*"}, path))
+	assert.True(t, matchRedactionPath([]string{"claim", "**", "key"}, path))
+	assert.True(t, matchRedactionPath([]string{"**"}, path))
+	assert.False(t, matchRedactionPath([]string{"**", "metadata", "annotations"}, path))
+	assert.False(t, matchRedactionPath([]string{"claim", "*", "testPods"}, path))
+}
+
+func TestParseRedactionProfile(t *testing.T) {
+	profile, err := ParseRedactionProfile([]byte("rules:\n  - name: ip\n    regex: '\\d+'\n"))
+	require.NoError(t, err)
+	assert.Equal(t, RedactionActionHash, profile.Rules[0].Action)
+	assert.Equal(t, RedactionTargetValues, profile.Rules[0].Target)
+
+	for content, expectedErr := range map[string]string{
+		"rules: []":                             "the redaction profile has no rules",
+		"rules:\n  - paths: [a]":                "invalid redaction profile: rule has no name",
+		"rules:\n  - name: a\n    action: drop": `invalid redaction profile: rule a: invalid action "drop", valid actions are hash and mask`,
+		"rules:\n  - name: a\n    target: keys\n    action: mask": "invalid redaction profile: rule a: keys can only be hashed",
+		"rules:\n  - name: a\n    regex: '('":                     "invalid redaction profile: rule a: invalid regex: error parsing regexp: missing closing ): `(`",
+	} {
+		_, err := ParseRedactionProfile([]byte(content))
+		assert.EqualError(t, err, expectedErr, content)
+	}
+
+	_, err = ParseRedactionProfile([]byte("rules:\n  - name: a\n    path: [a]\n"))
+	assert.ErrorContains(t, err, "field path not found")
+}
+
+func TestLoadRedactionProfile(t *testing.T) {
+	profile, err := LoadRedactionProfile(DefaultRedactionProfile)
+	require.NoError(t, err)
+	assert.NotEmpty(t, profile.Rules)
+
+	profileFile := filepath.Join(t.TempDir(), "profile.yaml")
+	require.NoError(t, os.WriteFile(profileFile, []byte("salt: s\nrules:\n  - name: a\n"), 0o600))
+	profile, err = LoadRedactionProfile(profileFile)
+	require.NoError(t, err)
+	assert.Equal(t, "s", profile.Salt)
+
+	_, err = LoadRedactionProfile("vendor")
+	assert.ErrorContains(t, err, `no built-in redaction profile "vendor"`)
+}
+
+func TestRedactionProfileSetSalt(t *testing.T) {
+	profile := RedactionProfile{Salt: "s"}
+	require.NoError(t, profile.SetSalt(""))
+	assert.Equal(t, "s", profile.Salt)
+	require.NoError(t, profile.SetSalt("other"))
+	assert.Equal(t, "other", profile.Salt)
+
+	// Without a salt, a random one is set for every profile.
+	profile = RedactionProfile{}
+	require.NoError(t, profile.SetSalt(""))
+	assert.Len(t, profile.Salt, 2*randomSaltLength)
+	otherProfile := RedactionProfile{}
+	require.NoError(t, otherProfile.SetSalt(""))
+	assert.NotEqual(t, profile.Salt, otherProfile.Salt)
+}
+
+func TestRedactClaim(t *testing.T) {
+	profile, err := LoadRedactionProfile(DefaultRedactionProfile)
+	require.NoError(t, err)
+	profile.Salt = "salt"
+
+	root, err := RedactClaim([]byte(redactTestClaim), profile)
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(root)
+	require.NoError(t, err)
+	for _, value := range []string{`"worker-0"`, "example.com", "10.128.0.12", "192.168.1.10", "secret"} {
+		assert.NotContains(t, string(payload), value)
+	}
+
+	r := redactor{profile: profile, pseudonyms: map[string]bool{}}
+	node := r.pseudonym(&profile.Rules[0], "worker-0")
+	assert.Regexp(t, "^node-[0-9a-f]{12}$", node)
+
+	// The node name has the same pseudonym as keys, values and in the results, but not in longer names.
+	assert.Contains(t, root.Claim.Nodes["nodeSummary"], node)
+	assert.Contains(t, root.Claim.Nodes["nodesHwInfo"], node)
+	assert.Contains(t, root.Claim.Results["lifecycle-pod-scheduling"].CheckDetails, `"`+node+`", "worker-0-pod"`)
+
+	pods := root.Claim.Configurations["testPods"].([]interface{})
+	pod := pods[0].(map[string]interface{})
+	spec := pod["spec"].(map[string]interface{})
+	assert.Equal(t, node, spec["nodeName"])
+	container := spec["containers"].([]interface{})[0].(map[string]interface{})
+	assert.Regexp(t, "^registry-[0-9a-f]{12}/team/app:v1$", container["image"])
+	assert.Equal(t, "REDACTED", container["env"].([]interface{})[0].(map[string]interface{})["value"])
+	assert.Equal(t, map[string]interface{}{"k8s.v1.cni.cncf.io/network-status": "REDACTED"}, pod["metadata"].(map[string]interface{})["annotations"])
+	ip := pod["status"].(map[string]interface{})["podIP"]
+	assert.Regexp(t, "^ip-[0-9a-f]{12}$", ip)
+
+	// Same salt, same pseudonyms.
+	again, err := RedactClaim([]byte(redactTestClaim), profile)
+	require.NoError(t, err)
+	assert.Equal(t, root, again)
+
+	profile.Salt = "other"
+	other, err := RedactClaim([]byte(redactTestClaim), profile)
+	require.NoError(t, err)
+	assert.NotContains(t, other.Claim.Nodes["nodeSummary"], node)
+}
+
+func TestClaimBuilderRedact(t *testing.T) {
+	profile, err := LoadRedactionProfile(DefaultRedactionProfile)
+	require.NoError(t, err)
+	profile.Salt = "salt"
+
+	claimFile := filepath.Join(t.TempDir(), "claim.json")
+	require.NoError(t, os.WriteFile(claimFile, []byte(redactTestClaim), 0o600))
+
+	// The claim built is the redacted one, for the artifacts created from it.
+	builder := ClaimBuilder{claimRoot: CreateClaimRoot()}
+	require.NoError(t, builder.Redact(claimFile, profile))
+	assert.NotContains(t, builder.claimRoot.Claim.Nodes["nodeSummary"], "worker-0")
+	assert.NotContains(t, builder.claimRoot.Claim.Results["lifecycle-pod-scheduling"].CheckDetails, `"worker-0"`)
+
+	payload, err := os.ReadFile(claimFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), `"worker-0"`)
+}
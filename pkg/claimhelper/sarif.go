@@ -0,0 +1,287 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package claimhelper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+)
+
+const (
+	SarifVersion   = "2.1.0"
+	SarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	sarifToolName           = "certsuite"
+	sarifToolInformationURI = "https://github.com/redhat-best-practices-for-k8s/certsuite"
+	sarifLevelError         = "error"
+)
+
+// SarifLog is the root object of a SARIF 2.1.0 file, with the subset of properties filled by certsuite.
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+// SarifRule describes a failed test case.
+type SarifRule struct {
+	ID               string              `json:"id"`
+	ShortDescription SarifMessage        `json:"shortDescription"`
+	FullDescription  SarifMessage        `json:"fullDescription"`
+	Help             SarifMessage        `json:"help"`
+	HelpURI          string              `json:"helpUri,omitempty"`
+	Properties       SarifRuleProperties `json:"properties"`
+}
+
+type SarifRuleProperties struct {
+	Suite       string   `json:"suite"`
+	Impact      string   `json:"impact,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// SarifResult describes a non-compliant object of a failed test case.
+type SarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    SarifMessage      `json:"message"`
+	Locations  []SarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type SarifLocation struct {
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations"`
+}
+
+type SarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// NewSarifLog returns the SARIF log of the claim's failed test cases. Each failed test case is a
+// rule and each of its non-compliant objects is a result located in its namespace, pod and
+// container, if any.
+func NewSarifLog(c *claim.Claim) SarifLog {
+	catalog := CatalogByID()
+
+	failedTestIDs := []string{}
+	for testID := range c.Results {
+		if c.Results[testID].State == TestStateFailed {
+			failedTestIDs = append(failedTestIDs, testID)
+		}
+	}
+	sort.Strings(failedTestIDs)
+
+	run := SarifRun{
+		Tool: SarifTool{Driver: SarifDriver{
+			Name:           sarifToolName,
+			InformationURI: sarifToolInformationURI,
+			Rules:          []SarifRule{},
+		}},
+		Results: []SarifResult{},
+	}
+	if c.Versions != nil {
+		run.Tool.Driver.Version = c.Versions.CertSuite
+	}
+
+	for ruleIndex, testID := range failedTestIDs {
+		result := c.Results[testID]
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSarifRule(testID, &result, catalog))
+		run.Results = append(run.Results, newSarifResults(testID, ruleIndex, &result)...)
+	}
+
+	return SarifLog{
+		Version: SarifVersion,
+		Schema:  SarifSchemaURI,
+		Runs:    []SarifRun{run},
+	}
+}
+
+func newSarifRule(testID string, result *claim.Result, catalog map[string]claim.TestCaseDescription) SarifRule {
+	catalogInfo := GetCatalogInfo(testID, result, catalog)
+	description, remediation, reference := catalogInfo.Description, catalogInfo.Remediation, catalogInfo.BestPracticeReference
+
+	rule := SarifRule{
+		ID:               testID,
+		ShortDescription: SarifMessage{Text: firstSentence(description)},
+		FullDescription:  SarifMessage{Text: description},
+		Help:             SarifMessage{Text: remediation},
+		Properties: SarifRuleProperties{
+			Impact:      identifiers.ImpactMap[testID],
+			Remediation: remediation,
+		},
+	}
+	if result.TestID != nil {
+		rule.Properties.Suite = result.TestID.Suite
+		if result.TestID.Tags != "" {
+			rule.Properties.Tags = strings.Split(result.TestID.Tags, ",")
+		}
+	}
+	if strings.HasPrefix(reference, "http://") || strings.HasPrefix(reference, "https://") {
+		rule.HelpURI = reference
+	} else if reference != "" {
+		rule.Help.Text = strings.TrimSpace(remediation + "\nReference: " + reference)
+	}
+
+	return rule
+}
+
+// newSarifResults returns a result for each non-compliant object of the test case, or a single
+// result without location if the test case didn't report any.
+func newSarifResults(testID string, ruleIndex int, result *claim.Result) []SarifResult {
+	details := testhelper.FailureReasonOut{}
+	if err := json.Unmarshal([]byte(result.CheckDetails), &details); err != nil {
+		log.Debug("Could not parse the check details of test case %s: %v", testID, err)
+	}
+
+	if len(details.NonCompliantObjectsOut) == 0 {
+		return []SarifResult{{
+			RuleID:    testID,
+			RuleIndex: ruleIndex,
+			Level:     sarifLevelError,
+			Message:   SarifMessage{Text: "Test case " + testID + " failed"},
+		}}
+	}
+
+	results := []SarifResult{}
+	for _, obj := range details.NonCompliantObjectsOut {
+		if obj == nil {
+			continue
+		}
+
+		fields := reportObjectFields(obj)
+		message := fields[testhelper.ReasonForNonCompliance]
+		if message == "" {
+			message = obj.ObjectType + " is not compliant"
+		}
+
+		sarifResult := SarifResult{
+			RuleID:     testID,
+			RuleIndex:  ruleIndex,
+			Level:      sarifLevelError,
+			Message:    SarifMessage{Text: message},
+			Properties: fields,
+		}
+		sarifResult.Properties["Object Type"] = obj.ObjectType
+		if location, found := newSarifLogicalLocation(obj, fields); found {
+			sarifResult.Locations = []SarifLocation{{LogicalLocations: []SarifLogicalLocation{location}}}
+		}
+		results = append(results, sarifResult)
+	}
+
+	return results
+}
+
+// reportObjectFields returns the fields of the report object by key.
+func reportObjectFields(obj *testhelper.ReportObject) map[string]string {
+	fields := map[string]string{}
+	for i := range obj.ObjectFieldsKeys {
+		if i < len(obj.ObjectFieldsValues) {
+			fields[obj.ObjectFieldsKeys[i]] = obj.ObjectFieldsValues[i]
+		}
+	}
+
+	return fields
+}
+
+// newSarifLogicalLocation returns the location of the object as namespace/pod/container, with
+// as many of them as the object has. Objects not in a pod are located by their namespace, if any,
+// and their name.
+func newSarifLogicalLocation(obj *testhelper.ReportObject, fields map[string]string) (SarifLogicalLocation, bool) {
+	path := []string{}
+	kind := ""
+	for _, level := range []struct{ field, kind string }{
+		{testhelper.Namespace, "namespace"},
+		{testhelper.PodName, "pod"},
+		{testhelper.ContainerName, "container"},
+	} {
+		if value := fields[level.field]; value != "" {
+			path = append(path, value)
+			kind = level.kind
+		}
+	}
+
+	if kind != "pod" && kind != "container" {
+		for _, key := range obj.ObjectFieldsKeys {
+			if key != testhelper.Name && !strings.HasSuffix(key, " "+testhelper.Name) {
+				continue
+			}
+			if name := fields[key]; name != "" {
+				path = append(path, name)
+				kind = strings.ToLower(obj.ObjectType)
+				break
+			}
+		}
+	}
+
+	if len(path) == 0 {
+		return SarifLogicalLocation{}, false
+	}
+
+	return SarifLogicalLocation{
+		Name:               path[len(path)-1],
+		FullyQualifiedName: strings.Join(path, "/"),
+		Kind:               kind,
+	}, true
+}
+
+// firstSentence returns the text up to the first period, which SARIF viewers show as summary.
+func firstSentence(text string) string {
+	if idx := strings.Index(text, ". "); idx >= 0 {
+		return text[:idx+1]
+	}
+	return text
+}
+
+// MarshalSarifLog returns the SARIF file contents of the claim's failed test cases.
+func MarshalSarifLog(c *claim.Claim) ([]byte, error) {
+	payload, err := json.MarshalIndent(NewSarifLog(c), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the SARIF log: %w", err)
+	}
+
+	return payload, nil
+}
+
+// ToSARIF writes the SARIF file of the claim's failed test cases.
+func (c *ClaimBuilder) ToSARIF(outputFile string) {
+	payload, err := MarshalSarifLog(c.claimRoot.Claim)
+	if err != nil {
+		log.Fatal("Failed to generate the SARIF file: %v", err)
+	}
+
+	log.Info("Writing SARIF file: %s", outputFile)
+	err = os.WriteFile(outputFile, payload, claimFilePermissions)
+	if err != nil {
+		log.Fatal("Failed to write the SARIF file: %v", err)
+	}
+}
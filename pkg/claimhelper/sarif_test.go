@@ -0,0 +1,139 @@
+package claimhelper
+
+import (
+	j "encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sarifTestClaim(t *testing.T) *claim.Claim {
+	t.Helper()
+
+	netAdminID, _ := identifiers.GetTestIDAndLabels(identifiers.TestNetAdminIdentifier)
+	podOwnerID, _ := identifiers.GetTestIDAndLabels(identifiers.TestPodDeploymentBestPracticesIdentifier)
+
+	details, err := testhelper.ResultObjectsToString(
+		[]*testhelper.ReportObject{testhelper.NewContainerReportObject("tnf", "test-0", "test", "No NET_ADMIN", true)},
+		[]*testhelper.ReportObject{
+			testhelper.NewContainerReportObject("tnf", "test-1", "test", "Non compliant capability detected in container", false).
+				AddField(testhelper.SCCCapability, "NET_ADMIN"),
+			testhelper.NewDeploymentReportObject("tnf", "test", "Deployment has less than two replicas", false),
+			testhelper.NewReportObject("Cluster-wide issue", testhelper.OCPClusterType, false),
+		},
+		nil)
+	require.NoError(t, err)
+
+	return &claim.Claim{
+		Versions: &claim.Versions{CertSuite: "v5.6.0"},
+		Results: map[string]claim.Result{
+			netAdminID: {
+				TestID:       &claim.Identifier{Id: netAdminID, Suite: "access-control", Tags: "telco,extended"},
+				State:        TestStateFailed,
+				CheckDetails: details,
+			},
+			podOwnerID: {
+				TestID: &claim.Identifier{Id: podOwnerID, Suite: "lifecycle"},
+				State:  TestStateFailed,
+			},
+			"removed-test-case": {
+				TestID: &claim.Identifier{Id: "removed-test-case", Suite: "lifecycle"},
+				State:  TestStateFailed,
+				CatalogInfo: &claim.CatalogInfo{
+					Description:           "Removed test case. It is not in the catalog anymore.",
+					Remediation:           "Upgrade certsuite.",
+					BestPracticeReference: "Some document",
+				},
+			},
+			"observability-container-logging": {
+				TestID: &claim.Identifier{Id: "observability-container-logging", Suite: "observability"},
+				State:  "passed",
+			},
+		},
+	}
+}
+
+func TestNewSarifLog(t *testing.T) {
+	sarifLog := NewSarifLog(sarifTestClaim(t))
+
+	assert.Equal(t, SarifVersion, sarifLog.Version)
+	assert.Equal(t, SarifSchemaURI, sarifLog.Schema)
+	require.Len(t, sarifLog.Runs, 1)
+	run := sarifLog.Runs[0]
+	assert.Equal(t, "certsuite", run.Tool.Driver.Name)
+	assert.Equal(t, "v5.6.0", run.Tool.Driver.Version)
+
+	// Only the failed test cases are rules, sorted by ID.
+	require.Len(t, run.Tool.Driver.Rules, 3)
+	netAdminRule := run.Tool.Driver.Rules[0]
+	assert.Equal(t, "access-control-net-admin-capability-check", netAdminRule.ID)
+	assert.Equal(t, "Ensures that containers do not use NET_ADMIN capability.", netAdminRule.ShortDescription.Text)
+	assert.Equal(t, identifiers.ImpactMap[netAdminRule.ID], netAdminRule.Properties.Impact)
+	assert.NotEmpty(t, netAdminRule.Properties.Impact)
+	assert.Equal(t, netAdminRule.Help.Text, netAdminRule.Properties.Remediation)
+	assert.Contains(t, netAdminRule.HelpURI, "https://")
+	assert.Equal(t, []string{"telco", "extended"}, netAdminRule.Properties.Tags)
+	assert.Equal(t, "lifecycle-pod-owner-type", run.Tool.Driver.Rules[1].ID)
+
+	removedRule := run.Tool.Driver.Rules[2]
+	assert.Equal(t, "removed-test-case", removedRule.ID)
+	assert.Equal(t, "Removed test case.", removedRule.ShortDescription.Text)
+	assert.Equal(t, "Upgrade certsuite.\nReference: Some document", removedRule.Help.Text)
+	assert.Empty(t, removedRule.HelpURI)
+
+	// A result per non-compliant object, or one per test case without them.
+	require.Len(t, run.Results, 5)
+	container := run.Results[0]
+	assert.Equal(t, "access-control-net-admin-capability-check", container.RuleID)
+	assert.Equal(t, 0, container.RuleIndex)
+	assert.Equal(t, "error", container.Level)
+	assert.Equal(t, "Non compliant capability detected in container", container.Message.Text)
+	assert.Equal(t, []SarifLocation{{LogicalLocations: []SarifLogicalLocation{
+		{Name: "test", FullyQualifiedName: "tnf/test-1/test", Kind: "container"},
+	}}}, container.Locations)
+	assert.Equal(t, "NET_ADMIN", container.Properties[testhelper.SCCCapability])
+	assert.Equal(t, testhelper.ContainerType, container.Properties["Object Type"])
+
+	assert.Equal(t, []SarifLocation{{LogicalLocations: []SarifLogicalLocation{
+		{Name: "test", FullyQualifiedName: "tnf/test", Kind: "deployment"},
+	}}}, run.Results[1].Locations)
+	assert.Empty(t, run.Results[2].Locations)
+	assert.Equal(t, "Cluster-wide issue", run.Results[2].Message.Text)
+
+	assert.Equal(t, 1, run.Results[3].RuleIndex)
+	assert.Equal(t, "Test case lifecycle-pod-owner-type failed", run.Results[3].Message.Text)
+	assert.Equal(t, 2, run.Results[4].RuleIndex)
+}
+
+func TestNewSarifLogWithoutFailures(t *testing.T) {
+	payload, err := MarshalSarifLog(&claim.Claim{Results: map[string]claim.Result{}})
+	require.NoError(t, err)
+
+	// Empty lists are required by the schema.
+	assert.Contains(t, string(payload), `"rules": []`)
+	assert.Contains(t, string(payload), `"results": []`)
+}
+
+func TestToSARIF(t *testing.T) {
+	t.Setenv("UNIT_TEST", unitTestEnvTrue)
+
+	claimBuilder, err := NewClaimBuilder(&provider.TestEnvironment{})
+	require.NoError(t, err)
+	claimBuilder.claimRoot.Claim = sarifTestClaim(t)
+
+	outputFile := filepath.Join(t.TempDir(), "certsuite.sarif")
+	claimBuilder.ToSARIF(outputFile)
+
+	payload, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	sarifLog := SarifLog{}
+	require.NoError(t, j.Unmarshal(payload, &sarifLog))
+	assert.Len(t, sarifLog.Runs[0].Results, 5)
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package claimhelper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+)
+
+const (
+	// DefaultSummaryMaxSize is the size limit, in bytes, of GitHub comments.
+	DefaultSummaryMaxSize  = 65536
+	DefaultSummaryCategory = identifiers.Telco
+
+	// Non-compliant objects shown per failed test case.
+	summaryMaxObjects      = 5
+	summaryTruncatedNotice = "\n_Summary truncated, see the claim file for the full results._\n"
+)
+
+type summaryCounts struct {
+	total, passed, failed, skipped, errored int
+}
+
+func (counts *summaryCounts) add(state string) {
+	counts.total++
+	switch {
+	case strings.HasPrefix(state, "passed"):
+		counts.passed++
+	case state == TestStateFailed:
+		counts.failed++
+	case state == TestStateSkipped:
+		counts.skipped++
+	default:
+		counts.errored++
+	}
+}
+
+func (counts *summaryCounts) row(name string) string {
+	return fmt.Sprintf("| %s | %d | %d | %d | %d | %d |\n", name, counts.total, counts.passed, counts.failed, counts.skipped, counts.errored)
+}
+
+// markdownCell returns the text in a single line, with the characters breaking markdown tables escaped.
+func markdownCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
+
+// NewMarkdownSummary returns a markdown summary of the claim results, to be pasted in merge requests
+// and chat: the results per suite, the failed mandatory test cases of the category with their
+// remediation, and the non-compliant objects of the failed test cases with most of them. The
+// summary is truncated to maxSize bytes, if positive.
+func NewMarkdownSummary(c *claim.Claim, category string, maxSize int) string {
+	catalog := CatalogByID()
+
+	testIDs := []string{}
+	for testID := range c.Results {
+		testIDs = append(testIDs, testID)
+	}
+	sort.Strings(testIDs)
+
+	suites := []string{}
+	suiteCounts := map[string]*summaryCounts{}
+	totalCounts := summaryCounts{}
+	failedMandatory := []string{}
+	for _, testID := range testIDs {
+		result := c.Results[testID]
+		suite := ""
+		if result.TestID != nil {
+			suite = result.TestID.Suite
+		}
+		if suiteCounts[suite] == nil {
+			suites = append(suites, suite)
+			suiteCounts[suite] = &summaryCounts{}
+		}
+		suiteCounts[suite].add(result.State)
+		totalCounts.add(result.State)
+
+		if (result.State == TestStateFailed || result.State == TestStateError) && testCaseClassification(testID, &result, category, catalog) == identifiers.Mandatory {
+			failedMandatory = append(failedMandatory, testID)
+		}
+	}
+	sort.Strings(suites)
+
+	summary := strings.Builder{}
+	summary.WriteString("## Certsuite results\n\n")
+	if c.Versions != nil {
+		fmt.Fprintf(&summary, "Certsuite %s, claim format %s", c.Versions.CertSuite, c.Versions.ClaimFormat)
+		if c.Versions.Ocp != "" {
+			fmt.Fprintf(&summary, ", OCP %s", c.Versions.Ocp)
+		}
+		summary.WriteString(".\n\n")
+	}
+
+	summary.WriteString("| Suite | Total | Passed | Failed | Skipped | Error |\n")
+	summary.WriteString("|---|---:|---:|---:|---:|---:|\n")
+	for _, suite := range suites {
+		summary.WriteString(suiteCounts[suite].row(suite))
+	}
+	summary.WriteString(totalCounts.row("**Total**"))
+
+	fmt.Fprintf(&summary, "\n### Failed mandatory test cases (%s)\n\n", category)
+	if len(failedMandatory) == 0 {
+		fmt.Fprintf(&summary, "No mandatory test case of the %s category failed.\n", category)
+	} else {
+		summary.WriteString("| Test case | Result | Remediation |\n")
+		summary.WriteString("|---|---|---|\n")
+		for _, testID := range failedMandatory {
+			result := c.Results[testID]
+			remediation := GetCatalogInfo(testID, &result, catalog).Remediation
+			fmt.Fprintf(&summary, "| `%s` | %s | %s |\n", testID, result.State, markdownCell(remediation))
+		}
+	}
+
+	writeTopNonCompliantObjects(&summary, c, testIDs)
+
+	return truncateSummary(summary.String(), maxSize)
+}
+
+// writeTopNonCompliantObjects writes the non-compliant objects of the failed test cases, those with
+// most of them first.
+func writeTopNonCompliantObjects(summary *strings.Builder, c *claim.Claim, testIDs []string) {
+	type failedTestCase struct {
+		id      string
+		objects []*testhelper.ReportObject
+	}
+
+	failedTestCases := []failedTestCase{}
+	for _, testID := range testIDs {
+		result := c.Results[testID]
+		if result.State != TestStateFailed {
+			continue
+		}
+
+		details := testhelper.FailureReasonOut{}
+		if err := json.Unmarshal([]byte(result.CheckDetails), &details); err != nil || len(details.NonCompliantObjectsOut) == 0 {
+			continue
+		}
+		failedTestCases = append(failedTestCases, failedTestCase{id: testID, objects: details.NonCompliantObjectsOut})
+	}
+	sort.SliceStable(failedTestCases, func(i, j int) bool { return len(failedTestCases[i].objects) > len(failedTestCases[j].objects) })
+
+	summary.WriteString("\n### Top non-compliant objects\n\n")
+	if len(failedTestCases) == 0 {
+		summary.WriteString("No non-compliant objects.\n")
+		return
+	}
+
+	for _, testCase := range failedTestCases {
+		fmt.Fprintf(summary, "- `%s`: %d non-compliant object(s)\n", testCase.id, len(testCase.objects))
+		for i, obj := range testCase.objects {
+			if i == summaryMaxObjects {
+				fmt.Fprintf(summary, "  - ... and %d more\n", len(testCase.objects)-summaryMaxObjects)
+				break
+			}
+			if obj == nil {
+				continue
+			}
+
+			fields := reportObjectFields(obj)
+			name := obj.ObjectType
+			if location, found := newSarifLogicalLocation(obj, fields); found {
+				name += " " + location.FullyQualifiedName
+			}
+			fmt.Fprintf(summary, "  - %s: %s\n", markdownCell(name), markdownCell(fields[testhelper.ReasonForNonCompliance]))
+		}
+	}
+}
+
+// truncateSummary returns the whole lines of the summary that fit in maxSize bytes, with a notice
+// that it was truncated.
+func truncateSummary(summary string, maxSize int) string {
+	if maxSize <= 0 || len(summary) <= maxSize {
+		return summary
+	}
+
+	truncated := strings.Builder{}
+	for _, line := range strings.SplitAfter(summary, "\n") {
+		if truncated.Len()+len(line)+len(summaryTruncatedNotice) > maxSize {
+			break
+		}
+		truncated.WriteString(line)
+	}
+	truncated.WriteString(summaryTruncatedNotice)
+
+	return truncated.String()
+}
+
+// ToMarkdownSummary writes the markdown summary of the claim results.
+func (c *ClaimBuilder) ToMarkdownSummary(outputFile, category string, maxSize int) {
+	log.Info("Writing markdown summary file: %s", outputFile)
+	err := os.WriteFile(outputFile, []byte(NewMarkdownSummary(c.claimRoot.Claim, category, maxSize)), claimFilePermissions)
+	if err != nil {
+		log.Fatal("Failed to write the markdown summary file: %v", err)
+	}
+}
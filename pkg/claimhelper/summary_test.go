@@ -0,0 +1,119 @@
+package claimhelper
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func summaryTestClaim(t *testing.T) *claim.Claim {
+	t.Helper()
+
+	containers := []*testhelper.ReportObject{}
+	for i := range 7 {
+		containers = append(containers, testhelper.NewContainerReportObject("tnf", fmt.Sprintf("test-%d", i), "test", "Non compliant | capability", false))
+	}
+	netAdminDetails, err := testhelper.ResultObjectsToString(nil, containers, nil)
+	require.NoError(t, err)
+	podOwnerDetails, err := testhelper.ResultObjectsToString(nil,
+		[]*testhelper.ReportObject{testhelper.NewPodReportObject("tnf", "test-0", "Pod has no owner", false)}, nil)
+	require.NoError(t, err)
+
+	return &claim.Claim{
+		Versions: &claim.Versions{CertSuite: "v5.6.0", ClaimFormat: "v0.5.0", Ocp: "4.18.0"},
+		Results: map[string]claim.Result{
+			"access-control-net-admin-capability-check": {
+				TestID:                 &claim.Identifier{Id: "access-control-net-admin-capability-check", Suite: "access-control"},
+				State:                  TestStateFailed,
+				CheckDetails:           netAdminDetails,
+				CategoryClassification: &claim.CategoryClassification{Telco: identifiers.Mandatory, NonTelco: identifiers.Optional},
+			},
+			"lifecycle-pod-owner-type": {
+				TestID:                 &claim.Identifier{Id: "lifecycle-pod-owner-type", Suite: "lifecycle"},
+				State:                  TestStateFailed,
+				CheckDetails:           podOwnerDetails,
+				CategoryClassification: &claim.CategoryClassification{Telco: identifiers.Mandatory, NonTelco: identifiers.Optional},
+			},
+			"lifecycle-pod-scheduling": {
+				TestID: &claim.Identifier{Id: "lifecycle-pod-scheduling", Suite: "lifecycle"},
+				State:  TestStateError,
+				// The catalog classification prevails over the claim's one.
+				CategoryClassification: &claim.CategoryClassification{Telco: identifiers.Mandatory},
+				CatalogInfo:            &claim.CatalogInfo{Remediation: "Not used, the catalog remediation is"},
+			},
+			"removed-test-case": {
+				TestID:                 &claim.Identifier{Id: "removed-test-case", Suite: "lifecycle"},
+				State:                  TestStateFailed,
+				CategoryClassification: &claim.CategoryClassification{Telco: identifiers.Mandatory},
+				CatalogInfo:            &claim.CatalogInfo{Remediation: "Remediation\nin two lines"},
+			},
+			"observability-container-logging": {
+				TestID: &claim.Identifier{Id: "observability-container-logging", Suite: "observability"},
+				State:  "passed-after-retry",
+			},
+			"observability-crd-status": {
+				TestID: &claim.Identifier{Id: "observability-crd-status", Suite: "observability"},
+				State:  TestStateSkipped,
+			},
+		},
+	}
+}
+
+func TestNewMarkdownSummary(t *testing.T) {
+	summary := NewMarkdownSummary(summaryTestClaim(t), identifiers.Telco, 0)
+
+	assert.Contains(t, summary, "Certsuite v5.6.0, claim format v0.5.0, OCP 4.18.0.\n")
+	assert.Contains(t, summary, "| access-control | 1 | 0 | 1 | 0 | 0 |\n"+
+		"| lifecycle | 3 | 0 | 2 | 0 | 1 |\n"+
+		"| observability | 2 | 1 | 0 | 1 | 0 |\n"+
+		"| **Total** | 6 | 1 | 3 | 1 | 1 |\n")
+
+	// Failed and errored mandatory test cases of the category, with their remediation.
+	assert.Contains(t, summary, "### Failed mandatory test cases (Telco)\n")
+	netAdminRemediation := ""
+	for id := range identifiers.Catalog {
+		if id.Id == "access-control-net-admin-capability-check" {
+			netAdminRemediation = identifiers.Catalog[id].Remediation
+		}
+	}
+	assert.Contains(t, summary, "| `access-control-net-admin-capability-check` | failed | "+markdownCell(netAdminRemediation)+" |\n")
+	assert.Contains(t, summary, "| `lifecycle-pod-owner-type` | failed |")
+	assert.Contains(t, summary, "| `removed-test-case` | failed | Remediation in two lines |\n")
+	assert.NotContains(t, summary, "| `lifecycle-pod-scheduling`")
+
+	// Test cases with most non-compliant objects first, with up to five of them.
+	objects := summary[strings.Index(summary, "### Top non-compliant objects"):]
+	assert.Contains(t, objects, "- `access-control-net-admin-capability-check`: 7 non-compliant object(s)\n"+
+		"  - Container tnf/test-0/test: Non compliant \\| capability\n")
+	assert.Contains(t, objects, "  - ... and 2 more\n- `lifecycle-pod-owner-type`: 1 non-compliant object(s)\n"+
+		"  - Pod tnf/test-0: Pod has no owner\n")
+	assert.NotContains(t, objects, "test-5")
+
+	nonTelcoSummary := NewMarkdownSummary(summaryTestClaim(t), identifiers.NonTelco, 0)
+	assert.Contains(t, nonTelcoSummary, "| `lifecycle-pod-scheduling` | error |")
+	assert.NotContains(t, nonTelcoSummary, "| `access-control-net-admin-capability-check`")
+	assert.NotContains(t, nonTelcoSummary, "| `removed-test-case`")
+
+	passedClaim := summaryTestClaim(t)
+	passedClaim.Results = map[string]claim.Result{"observability-container-logging": passedClaim.Results["observability-container-logging"]}
+	assert.Contains(t, NewMarkdownSummary(passedClaim, identifiers.Extended, 0), "No mandatory test case of the Extended category failed.\n")
+}
+
+func TestNewMarkdownSummaryMaxSize(t *testing.T) {
+	full := NewMarkdownSummary(summaryTestClaim(t), identifiers.Telco, 0)
+
+	summary := NewMarkdownSummary(summaryTestClaim(t), identifiers.Telco, 500)
+	assert.LessOrEqual(t, len(summary), 500)
+	assert.True(t, strings.HasSuffix(summary, summaryTruncatedNotice))
+	// Only whole lines are kept.
+	assert.True(t, strings.HasPrefix(full, strings.TrimSuffix(summary, summaryTruncatedNotice)))
+	assert.True(t, strings.HasSuffix(strings.TrimSuffix(summary, summaryTruncatedNotice), "\n"))
+
+	assert.Equal(t, full, NewMarkdownSummary(summaryTestClaim(t), identifiers.Telco, len(full)))
+}
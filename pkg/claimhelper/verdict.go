@@ -0,0 +1,190 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package claimhelper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/cli"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+)
+
+const (
+	// VerdictKey is the claim configurations key with the verdict of the run per category.
+	VerdictKey = "verdict"
+
+	VerdictPass = "pass"
+	VerdictFail = "fail"
+)
+
+// Categories are the categories the test cases are classified as mandatory or optional in.
+var Categories = []string{identifiers.Telco, identifiers.NonTelco, identifiers.FarEdge, identifiers.Extended}
+
+// GetCategory returns the category with the given name, case insensitively.
+func GetCategory(name string) (string, error) {
+	for _, category := range Categories {
+		if strings.EqualFold(category, name) {
+			return category, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid category %q, valid categories are %s", name, strings.Join(Categories, ", "))
+}
+
+// CatalogByID returns the catalog entries by test case ID.
+func CatalogByID() map[string]claim.TestCaseDescription {
+	catalog := map[string]claim.TestCaseDescription{}
+	for id := range identifiers.Catalog {
+		catalog[id.Id] = identifiers.Catalog[id]
+	}
+
+	return catalog
+}
+
+// GetCatalogInfo returns the description, remediation, best practice reference and exception
+// process of the test case, from the catalog. The catalog info saved in the claim is used for test
+// cases no longer in the catalog.
+func GetCatalogInfo(testID string, result *claim.Result, catalog map[string]claim.TestCaseDescription) claim.CatalogInfo {
+	if catalogEntry, found := catalog[testID]; found {
+		return claim.CatalogInfo{
+			Description:           catalogEntry.Description,
+			Remediation:           catalogEntry.Remediation,
+			BestPracticeReference: catalogEntry.BestPracticeReference,
+			ExceptionProcess:      catalogEntry.ExceptionProcess,
+		}
+	}
+
+	if result.CatalogInfo == nil {
+		return claim.CatalogInfo{}
+	}
+
+	return *result.CatalogInfo
+}
+
+// testCaseClassification returns whether the test case is mandatory or optional in the category,
+// from the catalog, or from the claim result for test cases no longer in it.
+func testCaseClassification(testID string, result *claim.Result, category string, catalog map[string]claim.TestCaseDescription) string {
+	if catalogEntry, found := catalog[testID]; found {
+		return catalogEntry.CategoryClassification[category]
+	}
+
+	if result.CategoryClassification == nil {
+		return ""
+	}
+
+	switch category {
+	case identifiers.Telco:
+		return result.CategoryClassification.Telco
+	case identifiers.NonTelco:
+		return result.CategoryClassification.NonTelco
+	case identifiers.FarEdge:
+		return result.CategoryClassification.FarEdge
+	case identifiers.Extended:
+		return result.CategoryClassification.Extended
+	}
+
+	return ""
+}
+
+// CategoryVerdict is the verdict of the run for a category: it passes if none of the test cases
+// mandatory in the category failed or errored.
+type CategoryVerdict struct {
+	Category              string   `json:"category"`
+	Verdict               string   `json:"verdict"`
+	FailedMandatoryChecks []string `json:"failedMandatoryChecks"`
+	FailedOptionalChecks  []string `json:"failedOptionalChecks"`
+}
+
+// Verdict is the verdict of the run for each category, recorded in the claim configurations.
+type Verdict struct {
+	Categories []CategoryVerdict `json:"categories"`
+	// Category whose verdict makes the run fail, if any.
+	FailOn string `json:"failOn,omitempty"`
+}
+
+// NewVerdict returns the verdict of the claim results for each category. The failed (or errored)
+// test cases are mandatory or optional in each category as classified in the catalog.
+func NewVerdict(results map[string]claim.Result, failOn string) Verdict {
+	catalog := CatalogByID()
+
+	failedTestIDs := []string{}
+	for testID := range results {
+		if results[testID].State == TestStateFailed || results[testID].State == TestStateError {
+			failedTestIDs = append(failedTestIDs, testID)
+		}
+	}
+	sort.Strings(failedTestIDs)
+
+	verdict := Verdict{FailOn: failOn}
+	for _, category := range Categories {
+		categoryVerdict := CategoryVerdict{
+			Category:              category,
+			Verdict:               VerdictPass,
+			FailedMandatoryChecks: []string{},
+			FailedOptionalChecks:  []string{},
+		}
+		for _, testID := range failedTestIDs {
+			result := results[testID]
+			if testCaseClassification(testID, &result, category, catalog) == identifiers.Mandatory {
+				categoryVerdict.FailedMandatoryChecks = append(categoryVerdict.FailedMandatoryChecks, testID)
+			} else {
+				categoryVerdict.FailedOptionalChecks = append(categoryVerdict.FailedOptionalChecks, testID)
+			}
+		}
+		if len(categoryVerdict.FailedMandatoryChecks) > 0 {
+			categoryVerdict.Verdict = VerdictFail
+		}
+		verdict.Categories = append(verdict.Categories, categoryVerdict)
+	}
+
+	return verdict
+}
+
+// GetCategory returns the verdict of the category, or nil if there's none.
+func (verdict *Verdict) GetCategory(category string) *CategoryVerdict {
+	for i := range verdict.Categories {
+		if verdict.Categories[i].Category == category {
+			return &verdict.Categories[i]
+		}
+	}
+
+	return nil
+}
+
+// Failed returns true if mandatory test cases of the FailOn category failed.
+func (verdict *Verdict) Failed() bool {
+	if verdict.FailOn == "" {
+		return false
+	}
+
+	categoryVerdict := verdict.GetCategory(verdict.FailOn)
+	return categoryVerdict != nil && categoryVerdict.Verdict == VerdictFail
+}
+
+// PrintVerdict prints the verdict of each category in the CLI, and the failed mandatory test cases
+// of the FailOn category.
+func PrintVerdict(verdict *Verdict) {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Printf("| %-15s %-9s %-16s %-12s |\n", "CATEGORY", "VERDICT", "FAILED MANDATORY", "FAILED OPTIONAL")
+	fmt.Println("-----------------------------------------------------------")
+	for _, categoryVerdict := range verdict.Categories {
+		result := cli.Green + "PASS" + cli.Reset
+		if categoryVerdict.Verdict == VerdictFail {
+			result = cli.Red + "FAIL" + cli.Reset
+		}
+		// The color codes don't take space in the terminal.
+		fmt.Printf("| %-15s %-18s %16d %15d |\n", categoryVerdict.Category, result,
+			len(categoryVerdict.FailedMandatoryChecks), len(categoryVerdict.FailedOptionalChecks))
+	}
+	fmt.Println("-----------------------------------------------------------")
+
+	if categoryVerdict := verdict.GetCategory(verdict.FailOn); categoryVerdict != nil && len(categoryVerdict.FailedMandatoryChecks) > 0 {
+		fmt.Printf("Mandatory test cases of the %s category that failed:\n", categoryVerdict.Category)
+		for _, testID := range categoryVerdict.FailedMandatoryChecks {
+			fmt.Printf("  - %s\n", testID)
+		}
+	}
+	fmt.Printf("\n")
+}
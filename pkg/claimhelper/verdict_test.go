@@ -0,0 +1,78 @@
+package claimhelper
+
+import (
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCategory(t *testing.T) {
+	category, err := GetCategory("nontelco")
+	require.NoError(t, err)
+	assert.Equal(t, identifiers.NonTelco, category)
+
+	category, err = GetCategory("FarEdge")
+	require.NoError(t, err)
+	assert.Equal(t, identifiers.FarEdge, category)
+
+	_, err = GetCategory("edge")
+	assert.EqualError(t, err, `invalid category "edge", valid categories are Telco, NonTelco, FarEdge, Extended`)
+}
+
+func TestNewVerdict(t *testing.T) {
+	results := map[string]claim.Result{
+		// Mandatory in all the categories but NonTelco.
+		"access-control-ssh-daemons": {State: TestStateFailed},
+		// Mandatory in NonTelco only.
+		"lifecycle-pod-scheduling": {State: TestStateError},
+		"lifecycle-pod-owner-type": {State: "passed"},
+		// Not in the catalog, classified as in the claim.
+		"removed-test-case": {
+			State:                  TestStateFailed,
+			CategoryClassification: &claim.CategoryClassification{Extended: identifiers.Mandatory, Telco: identifiers.Optional},
+		},
+	}
+
+	verdict := NewVerdict(results, identifiers.Telco)
+	require.Len(t, verdict.Categories, len(Categories))
+
+	telco := verdict.GetCategory(identifiers.Telco)
+	require.NotNil(t, telco)
+	assert.Equal(t, VerdictFail, telco.Verdict)
+	assert.Equal(t, []string{"access-control-ssh-daemons"}, telco.FailedMandatoryChecks)
+	assert.Equal(t, []string{"lifecycle-pod-scheduling", "removed-test-case"}, telco.FailedOptionalChecks)
+
+	nonTelco := verdict.GetCategory(identifiers.NonTelco)
+	require.NotNil(t, nonTelco)
+	assert.Equal(t, VerdictFail, nonTelco.Verdict)
+	assert.Equal(t, []string{"lifecycle-pod-scheduling"}, nonTelco.FailedMandatoryChecks)
+
+	extended := verdict.GetCategory(identifiers.Extended)
+	require.NotNil(t, extended)
+	assert.Equal(t, []string{"access-control-ssh-daemons", "removed-test-case"}, extended.FailedMandatoryChecks)
+
+	assert.True(t, verdict.Failed())
+}
+
+func TestVerdictFailed(t *testing.T) {
+	results := map[string]claim.Result{
+		"access-control-ssh-daemons": {State: "passed"},
+		"lifecycle-pod-scheduling":   {State: TestStateFailed},
+	}
+
+	// Optional in Telco.
+	verdict := NewVerdict(results, identifiers.Telco)
+	assert.Equal(t, VerdictPass, verdict.GetCategory(identifiers.Telco).Verdict)
+	assert.False(t, verdict.Failed())
+
+	verdict = NewVerdict(results, identifiers.NonTelco)
+	assert.True(t, verdict.Failed())
+
+	// Without a category to fail on, the run never fails.
+	verdict = NewVerdict(results, "")
+	assert.Equal(t, VerdictFail, verdict.GetCategory(identifiers.NonTelco).Verdict)
+	assert.False(t, verdict.Failed())
+}
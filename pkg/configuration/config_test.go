@@ -40,4 +40,10 @@ func TestLoadConfiguration(t *testing.T) {
 	assert.Equal(t, "tnf", env.SkipScalingTestStatefulSets[0].Namespace)
 	assert.Contains(t, env.ServicesIgnoreList, "hazelcast-platform-controller-manager-service")
 	assert.Contains(t, env.ServicesIgnoreList, "hazelcast-platform-webhook-service")
+	// check if waivers section is parsed properly
+	assert.Equal(t, 1, len(env.Waivers))
+	assert.Equal(t, "access-control-net-admin-capability-check", env.Waivers[0].ID)
+	assert.Equal(t, "Container", env.Waivers[0].ObjectType)
+	assert.Equal(t, map[string]string{"Namespace": "tnf", "Pod Name": "xdp-*", "SCC Capability": "NET_ADMIN"}, env.Waivers[0].Fields)
+	assert.Equal(t, "2026-12-31", env.Waivers[0].Expires)
 }
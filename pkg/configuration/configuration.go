@@ -16,10 +16,16 @@
 
 package configuration
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	defaultProbeDaemonSetNamespace = "cnf-suite"
+
+	// WaiverDateLayout is the layout of the waivers' expiration dates.
+	WaiverDateLayout = "2006-01-02"
 )
 
 type SkipHelmChartList struct {
@@ -78,6 +84,40 @@ type ConnectAPIConfig struct {
 	ProxyPort string `yaml:"proxyPort" json:"proxyPort"`
 }
 
+// CheckRetryPolicy sets how many times a check runs again when it fails, overriding the check's own policy
+type CheckRetryPolicy struct {
+	// ID is the check's identifier, e.g. networking-icmpv4-connectivity
+	ID string `yaml:"id" json:"id"`
+	// Retries is the maximum number of times the failed check runs again
+	Retries int `yaml:"retries" json:"retries"`
+	// Backoff is the time to wait before each new attempt, e.g. 30s
+	Backoff time.Duration `yaml:"backoff" json:"backoff"`
+}
+
+// Waiver accepts the non-compliant objects of a check that match all its fields, until it expires
+type Waiver struct {
+	// ID is the check's identifier, e.g. access-control-net-admin-capability-check
+	ID string `yaml:"id" json:"id"`
+	// ObjectType restricts the waiver to the report objects of this type, e.g. Container
+	ObjectType string `yaml:"objectType,omitempty" json:"objectType,omitempty"`
+	// Fields are the report object fields to match, e.g. "Pod Name": "xdp-*". Values can be glob patterns
+	Fields map[string]string `yaml:"fields,omitempty" json:"fields,omitempty"`
+	// Justification is the reason why the non-compliant objects are accepted
+	Justification string `yaml:"justification" json:"justification"`
+	// Expires is the last day the waiver applies, e.g. 2026-12-31
+	Expires string `yaml:"expires" json:"expires"`
+}
+
+// ExpiresAt returns the time the waiver stops applying: the end of its expiration date, in UTC.
+func (waiver *Waiver) ExpiresAt() (time.Time, error) {
+	date, err := time.Parse(WaiverDateLayout, waiver.Expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiration date %q, expected format is YYYY-MM-DD", waiver.Expires)
+	}
+
+	return date.AddDate(0, 0, 1), nil
+}
+
 // TestConfiguration provides test related configuration
 type TestConfiguration struct {
 	// targetNameSpaces to be used in
@@ -108,6 +148,10 @@ type TestConfiguration struct {
 	CollectorAppEndpoint string `yaml:"collectorAppEndpoint,omitempty" json:"collectorAppEndpoint,omitempty"`
 	// ConnectAPIConfig contains the configuration for the Red Hat Connect API
 	ConnectAPIConfig ConnectAPIConfig `yaml:"connectAPIConfig,omitempty" json:"connectAPIConfig,omitempty"`
+	// CheckRetries overrides the retry policy of the given checks
+	CheckRetries []CheckRetryPolicy `yaml:"checkRetries,omitempty" json:"checkRetries,omitempty"`
+	// Waivers accept known non-compliant objects of the checks
+	Waivers []Waiver `yaml:"waivers,omitempty" json:"waivers,omitempty"`
 }
 
 type TestParameters struct {
@@ -131,6 +175,8 @@ type TestParameters struct {
 	OmitArtifactsZipFile          bool
 	EnableDataCollection          bool
 	EnableXMLCreation             bool
+	EnableSARIFCreation           bool
+	JUnitPerObject                bool
 	ServerMode                    bool
 	Timeout                       time.Duration
 	ConnectAPIKey                 string
@@ -144,4 +190,53 @@ type TestParameters struct {
 	CleanupProbe bool
 	// RequireProbe aborts the test run if the probe daemonset fails to deploy
 	RequireProbe bool
+	// ManifestsDir is the file or directory with the rendered manifests used instead of a live cluster
+	ManifestsDir string
+	// FromSnapshot is the discovery snapshot archive replayed instead of querying a live cluster
+	FromSnapshot string
+	// CreateSnapshot saves a discovery snapshot archive in the output dir at the end of the run
+	CreateSnapshot bool
+	// GroupsConcurrency is the maximum number of check groups (suites) running at the same time
+	GroupsConcurrency int
+	// GroupsOrder is the comma separated list of check groups (suites) in the order they must run
+	GroupsOrder string
+	// CheckTimeout is the time allowed for each check that does not set its own timeout
+	CheckTimeout time.Duration
+	// Resume runs only the checks that did not complete in the previous run in the output dir
+	Resume bool
+	// RerunFailedClaim is the previous claim file whose failed test cases are run again
+	RerunFailedClaim string
+	// MergeResults saves a copy of the RerunFailedClaim updated with the new results as claim file
+	MergeResults bool
+	// EnableMarkdownSummary saves a markdown summary of the results in the output dir
+	EnableMarkdownSummary bool
+	// SummaryCategory is the category whose failed mandatory test cases are listed in the summary
+	SummaryCategory string
+	// SummaryMaxSize is the maximum size of the summary in bytes, zero means no limit
+	SummaryMaxSize int
+	// FailOn is the category whose failed mandatory test cases make the run exit with an error
+	FailOn string
+	// RedactProfile is the built-in redaction profile name or file the claim file is redacted with, if set
+	RedactProfile string
+	// RedactSalt is the salt of the redaction pseudonyms hashes, instead of the one of the profile
+	RedactSalt string
+	// SignKeyFile is the private key file the claim and artifacts are signed with, if set
+	SignKeyFile string
+	// ServerTLSCertFile and ServerTLSKeyFile are the certificate and key the web server is served with over TLS, if set
+	ServerTLSCertFile string
+	ServerTLSKeyFile  string
+	// ServerTokensFile is the file with the bearer tokens of the web server users, if set
+	ServerTokensFile string
+	// ServerHtpasswdFile is the htpasswd file with the basic auth credentials of the web server users, if set
+	ServerHtpasswdFile string
+	// ServerOIDCIssuerURL is the OIDC issuer whose ID tokens authenticate the web server users, if set
+	ServerOIDCIssuerURL string
+	// ServerOIDCClientID is the client ID the OIDC ID tokens must be issued for
+	ServerOIDCClientID string
+	// ServerOIDCUsernameClaim is the OIDC ID token claim with the user name
+	ServerOIDCUsernameClaim string
+	// ServerHistoryMaxRuns is the number of latest runs kept in the web server run history, all if zero
+	ServerHistoryMaxRuns int
+	// ServerHistoryMaxAge is the time the runs are kept in the web server run history, forever if zero
+	ServerHistoryMaxAge time.Duration
 }
@@ -15,3 +15,23 @@
 // 51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
 
 package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaiverExpiresAt(t *testing.T) {
+	waiver := Waiver{ID: "test", Expires: "2026-12-31"}
+	expiresAt, err := waiver.ExpiresAt()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), expiresAt)
+
+	for _, expires := range []string{"", "31/12/2026", "2026-12-31T10:00:00Z"} {
+		waiver.Expires = expires
+		_, err = waiver.ExpiresAt()
+		assert.ErrorContains(t, err, "expected format is YYYY-MM-DD")
+	}
+}
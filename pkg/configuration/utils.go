@@ -61,6 +61,13 @@ func LoadConfiguration(filePath string) (TestConfiguration, error) {
 	return configuration, nil
 }
 
+// ResetConfiguration forgets the loaded configuration, so the next LoadConfiguration call reads
+// the file again.
+func ResetConfiguration() {
+	configuration = TestConfiguration{}
+	confLoaded = false
+}
+
 func GetTestParameters() *TestParameters {
 	return &parameters
 }
@@ -60,7 +60,7 @@ func GetCniPlugins() (out map[string][]interface{}) {
 	out = make(map[string][]interface{})
 	for _, probePod := range env.ProbePods {
 		ctx := clientsholder.NewContext(probePod.Namespace, probePod.Name, probePod.Spec.Containers[0].Name)
-		outStr, errStr, err := o.ExecCommandContainer(ctx, cniPluginsCommand)
+		outStr, errStr, err := o.ExecCommandContainer(context.TODO(), ctx, cniPluginsCommand)
 		if err != nil || errStr != "" {
 			log.Error("Failed to execute command %s in probe pod %s", cniPluginsCommand, probePod.String())
 			continue
@@ -115,7 +115,7 @@ func GetHwInfoAllNodes() (out map[string]NodeHwInfo) {
 // getHWJsonOutput performs a query via probe pod and returns the JSON blob
 func getHWJsonOutput(probePod *corev1.Pod, o clientsholder.Command, cmd string) (out interface{}, err error) {
 	ctx := clientsholder.NewContext(probePod.Namespace, probePod.Name, probePod.Spec.Containers[0].Name)
-	outStr, errStr, err := o.ExecCommandContainer(ctx, cmd)
+	outStr, errStr, err := o.ExecCommandContainer(context.TODO(), ctx, cmd)
 	if err != nil || errStr != "" {
 		return out, fmt.Errorf("command %s failed with error err: %v, stderr: %s", cmd, err, errStr)
 	}
@@ -129,7 +129,7 @@ func getHWJsonOutput(probePod *corev1.Pod, o clientsholder.Command, cmd string)
 // getHWTextOutput performs a query via debug and returns plaintext lines
 func getHWTextOutput(probePod *corev1.Pod, o clientsholder.Command, cmd string) (out []string, err error) {
 	ctx := clientsholder.NewContext(probePod.Namespace, probePod.Name, probePod.Spec.Containers[0].Name)
-	outStr, errStr, err := o.ExecCommandContainer(ctx, cmd)
+	outStr, errStr, err := o.ExecCommandContainer(context.TODO(), ctx, cmd)
 	if err != nil || errStr != "" {
 		return out, fmt.Errorf("command %s failed with error err: %v, stderr: %s", lspciCommand, err, errStr)
 	}
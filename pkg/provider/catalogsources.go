@@ -2,6 +2,7 @@
 package provider
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -62,7 +63,7 @@ func getCatalogSourceBundleCountFromProbeContainer(env *TestEnvironment, cs *olm
 		for _, probePod := range env.ProbePods {
 			ctx := clientsholder.NewContext(probePod.Namespace, probePod.Name, probePod.Spec.Containers[0].Name)
 			cmd := "grpcurl -plaintext " + svc.Spec.ClusterIP + ":50051 api.Registry.ListBundles | jq -s 'length'"
-			cmdValue, errStr, err := o.ExecCommandContainer(ctx, cmd)
+			cmdValue, errStr, err := o.ExecCommandContainer(context.TODO(), ctx, cmd)
 			if err != nil || errStr != "" {
 				log.Error("Failed to execute command %s in probe pod %s", cmd, probePod.String())
 				continue
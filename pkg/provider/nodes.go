@@ -17,6 +17,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -149,7 +150,7 @@ func (node *Node) IsHyperThreadNode(env *TestEnvironment) (bool, error) {
 	}
 
 	ctx := clientsholder.NewContext(probePod.Namespace, probePod.Name, probePod.Spec.Containers[0].Name)
-	cmdValue, errStr, err := o.ExecCommandContainer(ctx, isHyperThreadCommand)
+	cmdValue, errStr, err := o.ExecCommandContainer(context.TODO(), ctx, isHyperThreadCommand)
 	if err != nil || errStr != "" {
 		return false, fmt.Errorf("cannot execute %s on probe pod %s: %w, stderr=%s", isHyperThreadCommand, probePod.Name, err, errStr)
 	}
@@ -158,6 +158,10 @@ type TestEnvironment struct { // rename this with testTarget
 	ConnectAPIProxyURL           string
 	ConnectAPIProxyPort          string
 	SkipPreflight                bool
+	// ManifestMode is set when the objects under test were loaded from manifests instead of a live cluster.
+	ManifestMode bool
+	// ReplayMode is set when the objects under test and the exec outputs come from a discovery snapshot.
+	ReplayMode bool
 }
 
 type MachineConfig struct {
@@ -214,6 +218,10 @@ type PreflightResultsDB struct {
 var (
 	env    = TestEnvironment{}
 	loaded = false
+	// Guards env and loaded, as the environment can be got and refreshed from concurrent check groups.
+	envLock sync.Mutex
+	// Raw discovered data the test environment was built from, to be saved in snapshots.
+	discoveredData autodiscover.DiscoveredTestData
 )
 
 func deployDaemonSet(namespace string) error {
@@ -276,26 +284,23 @@ func buildTestEnvironment() { //nolint:funlen,gocyclo
 	}
 	log.Debug("CERTSUITE configuration: %+v", config)
 
-	// Wait for the probe pods to be ready before the autodiscovery starts.
-	if err := deployDaemonSet(config.ProbeDaemonSetNamespace); err != nil {
-		log.Error("The probe daemonset could not be deployed, err: %v", err)
-
-		testParams := configuration.GetTestParameters()
-		if testParams.RequireProbe {
-			log.Fatal("--require-probe is set: aborting because the probe daemonset failed to deploy")
+	var data autodiscover.DiscoveredTestData
+	switch {
+	case env.params.ManifestsDir != "":
+		// Manifest mode: no cluster, so no probe daemonset to deploy.
+		env.ManifestMode = true
+		data, err = autodiscover.DoManifestDiscover(&config, env.params.ManifestsDir)
+		if err != nil {
+			log.Fatal("Cannot discover the objects under test from the manifests: %v", err)
 		}
-
-		log.Warn("Probe daemonset failed to deploy. The following test categories will be SKIPPED: " +
-			"Platform (SELinux, hugepages, boot params, sysctl, kernel taints, base image, hyperthreading), " +
-			"Networking (ICMP connectivity, port usage), " +
-			"Access Control (process count, SSH daemon detection), " +
-			"Performance (CPU scheduling policy). " +
-			"To abort on probe failure instead, use --require-probe")
-
-		env.DaemonsetFailedToSpawn = true
+	case env.params.FromSnapshot != "":
+		env.ReplayMode = true
+		data = replaySnapshot(env.params.FromSnapshot)
+	default:
+		data = discoverCluster(&config)
 	}
+	discoveredData = data
 
-	data := autodiscover.DoAutoDiscover(&config)
 	// OpenshiftVersion needs to be set asap, as other helper functions will use it here.
 	env.OpenshiftVersion = data.OpenshiftVersion
 	env.Config = config
@@ -443,21 +448,72 @@ func buildTestEnvironment() { //nolint:funlen,gocyclo
 	env.AllSriovNetworks = data.AllSriovNetworks
 	env.AllSriovNetworkNodePolicies = data.AllSriovNetworkNodePolicies
 	env.NetworkAttachmentDefinitions = data.NetworkAttachmentDefinitions
-	for _, pod := range env.Pods {
-		isCreatedByDeploymentConfig, err := pod.CreatedByDeploymentConfig()
-		if err != nil {
-			log.Warn("Pod %q failed to get parent resource: %v", pod, err)
-			continue
-		}
+	// Without a live cluster, the pods' parent resources can't be looked up.
+	if env.IsLiveCluster() {
+		for _, pod := range env.Pods {
+			isCreatedByDeploymentConfig, err := pod.CreatedByDeploymentConfig()
+			if err != nil {
+				log.Warn("Pod %q failed to get parent resource: %v", pod, err)
+				continue
+			}
 
-		if isCreatedByDeploymentConfig {
-			log.Warn("Pod %q has been deployed using a DeploymentConfig, please use Deployment or StatefulSet instead.", pod.String())
+			if isCreatedByDeploymentConfig {
+				log.Warn("Pod %q has been deployed using a DeploymentConfig, please use Deployment or StatefulSet instead.", pod.String())
+			}
 		}
 	}
 
 	log.Info("Completed the test environment build process in %.2f seconds", time.Since(start).Seconds())
 }
 
+// discoverCluster deploys the probe daemonset and runs the autodiscovery against the live cluster.
+func discoverCluster(config *configuration.TestConfiguration) autodiscover.DiscoveredTestData {
+	// Wait for the probe pods to be ready before the autodiscovery starts.
+	if err := deployDaemonSet(config.ProbeDaemonSetNamespace); err != nil {
+		log.Error("The probe daemonset could not be deployed, err: %v", err)
+
+		testParams := configuration.GetTestParameters()
+		if testParams.RequireProbe {
+			log.Fatal("--require-probe is set: aborting because the probe daemonset failed to deploy")
+		}
+
+		log.Warn("Probe daemonset failed to deploy. The following test categories will be SKIPPED: " +
+			"Platform (SELinux, hugepages, boot params, sysctl, kernel taints, base image, hyperthreading), " +
+			"Networking (ICMP connectivity, port usage), " +
+			"Access Control (process count, SSH daemon detection), " +
+			"Performance (CPU scheduling policy). " +
+			"To abort on probe failure instead, use --require-probe")
+
+		env.DaemonsetFailedToSpawn = true
+	}
+
+	return autodiscover.DoAutoDiscover(config)
+}
+
+// replaySnapshot loads the discovery snapshot and sets up the clients to answer from its objects
+// and recorded exec outputs.
+func replaySnapshot(snapshotFile string) autodiscover.DiscoveredTestData {
+	snapshot, err := autodiscover.LoadSnapshot(snapshotFile)
+	if err != nil {
+		log.Fatal("Cannot load the discovery snapshot: %v", err)
+	}
+
+	log.Info("Replaying discovery snapshot %s (certsuite version %s, created at %s)", snapshotFile,
+		snapshot.Metadata.CertsuiteVersion, snapshot.Metadata.CreatedAt.Format(time.RFC3339))
+	clientsholder.SetReplayClientsHolder(snapshot.Objects(), snapshot.ExecRecords)
+
+	// The probe pods are only in the snapshot if the daemonset was deployed when it was taken.
+	env.DaemonsetFailedToSpawn = len(snapshot.Data.ProbePods) == 0
+
+	return snapshot.Data
+}
+
+// SaveDiscoverySnapshot saves the data discovered to build the test environment, along with the
+// outputs of the commands exec'ed so far, in a snapshot archive that can be replayed later.
+func SaveDiscoverySnapshot(snapshotFile string) error {
+	return autodiscover.SaveSnapshot(snapshotFile, &discoveredData, clientsholder.GetClientsHolder().GetExecRecords())
+}
+
 func updateCrUnderTest(scaleCrUnderTest []autodiscover.ScaleObject) []ScaleObject {
 	var scaleCrUndeTestTemp []ScaleObject
 	for i := range scaleCrUnderTest {
@@ -530,6 +586,9 @@ func isSkipHelmChart(helmName string, skipHelmChartList []configuration.SkipHelm
 }
 
 func GetTestEnvironment() TestEnvironment {
+	envLock.Lock()
+	defer envLock.Unlock()
+
 	if !loaded {
 		buildTestEnvironment()
 		loaded = true
@@ -643,9 +702,27 @@ func (env *TestEnvironment) NewPerNodeMutexMap() map[string]*sync.Mutex {
 }
 
 func (env *TestEnvironment) SetNeedsRefresh() {
+	envLock.Lock()
+	defer envLock.Unlock()
+
 	loaded = false
 }
 
+// ResetTestEnvironment forgets the test environment, so the next GetTestEnvironment call builds it
+// again, e.g. with another configuration.
+func ResetTestEnvironment() {
+	envLock.Lock()
+	defer envLock.Unlock()
+
+	env = TestEnvironment{}
+	loaded = false
+}
+
+// IsLiveCluster returns false when the test environment was built from manifests or a snapshot.
+func (env *TestEnvironment) IsLiveCluster() bool {
+	return !env.ManifestMode && !env.ReplayMode
+}
+
 func (env *TestEnvironment) IsIntrusive() bool {
 	return env.params.Intrusive
 }
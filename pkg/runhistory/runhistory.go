@@ -0,0 +1,419 @@
+// Copyright (C) 2026 Red Hat, Inc.
+
+// Package runhistory keeps the metadata, results summary and claim file of the certsuite runs in
+// an embedded database, so that they can be browsed, searched and compared after later runs.
+package runhistory
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	dbFilePerms = 0o600
+	// Time to wait for the lock of the database file, held by another certsuite process.
+	dbOpenTimeout = time.Second
+)
+
+var (
+	runsBucket   = []byte("runs")
+	claimsBucket = []byte("claims")
+)
+
+// ErrRunNotFound is returned when there's no run with the given ID in the history.
+var ErrRunNotFound = errors.New("run not found")
+
+// Workload is a workload under test of a run.
+type Workload struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Run is the metadata of a run in the history. Its claim file is kept apart.
+type Run struct {
+	ID string `json:"id"`
+	// Owner is the user who ran it, empty when the runs have no users.
+	Owner        string `json:"owner,omitempty"`
+	LabelsFilter string `json:"labelFilter"`
+	// State and Error of the job of the run.
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	StoredAt         time.Time `json:"storedAt"`
+	CertSuiteVersion string    `json:"certSuiteVersion"`
+
+	// Summary is the number of test cases by result state.
+	Summary    map[string]int `json:"summary"`
+	Namespaces []string       `json:"namespaces"`
+	Workloads  []Workload     `json:"workloads"`
+	// Results is the result state of each test case ID.
+	Results map[string]string `json:"results"`
+}
+
+// Query selects the runs of the history. Its empty fields select any run, but the Owner, as the
+// runs are only visible to their owner.
+type Query struct {
+	Owner string
+	// Workload is the name of a workload, or its namespace and name separated by a slash.
+	Workload  string
+	Namespace string
+	TestID    string
+	// State of the TestID result.
+	TestState string
+}
+
+// RetentionPolicy sets which runs are removed from the history. Zero values don't remove any.
+type RetentionPolicy struct {
+	// MaxRuns is the number of latest runs that are kept.
+	MaxRuns int
+	// MaxAge is the time the runs are kept since they were stored.
+	MaxAge time.Duration
+}
+
+// claimFile has the fields of the claim file kept in the run metadata.
+type claimFile struct {
+	Claim struct {
+		Configurations struct {
+			Namespaces   []string        `json:"testNamespaces"`
+			Pods         []claimObject   `json:"testPods"`
+			Deployments  []claimObject   `json:"testDeployments"`
+			StatefulSets []claimObject   `json:"testStatefulSets"`
+			Operators    []claimOperator `json:"testOperators"`
+		} `json:"configurations"`
+		Metadata struct {
+			StartTime string `json:"startTime"`
+			EndTime   string `json:"endTime"`
+		} `json:"metadata"`
+		Versions struct {
+			CertSuite string `json:"certSuite"`
+		} `json:"versions"`
+		Results map[string]struct {
+			State  string `json:"state"`
+			TestID struct {
+				ID string `json:"id"`
+			} `json:"testID"`
+		} `json:"results"`
+	} `json:"claim"`
+}
+
+type claimObject struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+type claimOperator struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// parseClaimTime parses the claim metadata times, written with the default format of time.Time
+// by the certsuite and in RFC 3339 by its older versions.
+func parseClaimTime(value string) time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05 -0700 MST", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC()
+		}
+	}
+
+	return time.Time{}
+}
+
+// NewRun returns the run metadata of the claim file contents.
+func NewRun(claimData []byte) (*Run, error) {
+	claim := claimFile{}
+	if err := json.Unmarshal(claimData, &claim); err != nil {
+		return nil, fmt.Errorf("failed to parse the claim file: %w", err)
+	}
+
+	run := Run{
+		StartTime:        parseClaimTime(claim.Claim.Metadata.StartTime),
+		EndTime:          parseClaimTime(claim.Claim.Metadata.EndTime),
+		CertSuiteVersion: claim.Claim.Versions.CertSuite,
+		Summary:          map[string]int{},
+		Namespaces:       claim.Claim.Configurations.Namespaces,
+		Workloads:        []Workload{},
+		Results:          map[string]string{},
+	}
+	if run.Namespaces == nil {
+		run.Namespaces = []string{}
+	}
+
+	configurations := &claim.Claim.Configurations
+	for kind, objects := range map[string][]claimObject{"Pod": configurations.Pods, "Deployment": configurations.Deployments, "StatefulSet": configurations.StatefulSets} {
+		for i := range objects {
+			run.Workloads = append(run.Workloads, Workload{Kind: kind, Namespace: objects[i].Metadata.Namespace, Name: objects[i].Metadata.Name})
+		}
+	}
+	for _, operator := range configurations.Operators {
+		run.Workloads = append(run.Workloads, Workload{Kind: "Operator", Namespace: operator.Namespace, Name: operator.Name})
+	}
+	sort.Slice(run.Workloads, func(i, j int) bool {
+		a, b := run.Workloads[i], run.Workloads[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	for _, result := range claim.Claim.Results {
+		run.Results[result.TestID.ID] = result.State
+		run.Summary[result.State]++
+	}
+
+	return &run, nil
+}
+
+func (w Workload) matches(workload string) bool {
+	if namespace, name, found := strings.Cut(workload, "/"); found {
+		return w.Namespace == namespace && w.Name == name
+	}
+
+	return w.Name == workload
+}
+
+// Matches returns whether the run is selected by the query.
+func (run *Run) Matches(q Query) bool {
+	if q.Owner != run.Owner {
+		return false
+	}
+
+	if q.Namespace != "" && !slices.ContainsFunc(run.Namespaces, func(namespace string) bool { return namespace == q.Namespace }) &&
+		!slices.ContainsFunc(run.Workloads, func(w Workload) bool { return w.Namespace == q.Namespace }) {
+		return false
+	}
+
+	if q.Workload != "" && !slices.ContainsFunc(run.Workloads, func(w Workload) bool { return w.matches(q.Workload) }) {
+		return false
+	}
+
+	if q.TestID != "" {
+		state, found := run.Results[q.TestID]
+		if !found || (q.TestState != "" && state != q.TestState) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Store is the run history, kept in a bbolt database file.
+type Store struct {
+	db        *bolt.DB
+	retention RetentionPolicy
+}
+
+// Open opens the run history in the database file, creating it if it doesn't exist, and removes
+// the runs out of the retention policy.
+func Open(fileName string, retention RetentionPolicy) (*Store, error) {
+	db, err := bolt.Open(fileName, dbFilePerms, &bolt.Options{Timeout: dbOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the run history database %s: %w", fileName, err)
+	}
+
+	s := &Store{db: db, retention: retention}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{runsBucket, claimsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return s.applyRetention(tx)
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize the run history database %s: %w", fileName, err)
+	}
+
+	return s, nil
+}
+
+// Close closes the database file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func compress(data []byte) ([]byte, error) {
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// Add stores the run and its claim file contents, replacing the run with the same ID, if any, and
+// removes the runs out of the retention policy.
+func (s *Store) Add(run *Run, claimData []byte) error {
+	if run.ID == "" {
+		return errors.New("the run has no id")
+	}
+	if run.StoredAt.IsZero() {
+		run.StoredAt = time.Now().UTC()
+	}
+
+	runData, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to encode run %s: %w", run.ID, err)
+	}
+	compressedClaim, err := compress(claimData)
+	if err != nil {
+		return fmt.Errorf("failed to compress the claim file of run %s: %w", run.ID, err)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(runsBucket).Put([]byte(run.ID), runData); err != nil {
+			return err
+		}
+		if err := tx.Bucket(claimsBucket).Put([]byte(run.ID), compressedClaim); err != nil {
+			return err
+		}
+		return s.applyRetention(tx)
+	})
+}
+
+// Get returns the run with the given ID.
+func (s *Store) Get(id string) (Run, error) {
+	run := Run{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(runsBucket).Get([]byte(id))
+		if data == nil {
+			return ErrRunNotFound
+		}
+		return json.Unmarshal(data, &run)
+	})
+
+	return run, err
+}
+
+// GetClaim returns the claim file contents of the run with the given ID.
+func (s *Store) GetClaim(id string) ([]byte, error) {
+	var compressedClaim []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(claimsBucket).Get([]byte(id))
+		if data == nil {
+			return ErrRunNotFound
+		}
+		// The data is only valid during the transaction.
+		compressedClaim = bytes.Clone(data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressedClaim))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the claim file of run %s: %w", id, err)
+	}
+	defer zr.Close()
+
+	return io.ReadAll(zr)
+}
+
+// readRuns returns the runs of the history, from the latest stored one.
+func readRuns(tx *bolt.Tx) ([]Run, error) {
+	runs := []Run{}
+	err := tx.Bucket(runsBucket).ForEach(func(_, data []byte) error {
+		run := Run{}
+		if err := json.Unmarshal(data, &run); err != nil {
+			return err
+		}
+		runs = append(runs, run)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the runs: %w", err)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StoredAt.After(runs[j].StoredAt)
+	})
+
+	return runs, nil
+}
+
+// List returns the runs selected by the query, from the latest stored one.
+func (s *Store) List(q Query) ([]Run, error) {
+	runs := []Run{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		allRuns, err := readRuns(tx)
+		if err != nil {
+			return err
+		}
+
+		for i := range allRuns {
+			if allRuns[i].Matches(q) {
+				runs = append(runs, allRuns[i])
+			}
+		}
+		return nil
+	})
+
+	return runs, err
+}
+
+func deleteRun(tx *bolt.Tx, id string) error {
+	if err := tx.Bucket(runsBucket).Delete([]byte(id)); err != nil {
+		return err
+	}
+
+	return tx.Bucket(claimsBucket).Delete([]byte(id))
+}
+
+// Delete removes the run with the given ID and its claim file.
+func (s *Store) Delete(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(runsBucket).Get([]byte(id)) == nil {
+			return ErrRunNotFound
+		}
+		return deleteRun(tx, id)
+	})
+}
+
+// applyRetention removes the runs out of the retention policy.
+func (s *Store) applyRetention(tx *bolt.Tx) error {
+	if s.retention.MaxRuns <= 0 && s.retention.MaxAge <= 0 {
+		return nil
+	}
+
+	runs, err := readRuns(tx)
+	if err != nil {
+		return err
+	}
+
+	for i := range runs {
+		tooMany := s.retention.MaxRuns > 0 && i >= s.retention.MaxRuns
+		tooOld := s.retention.MaxAge > 0 && time.Since(runs[i].StoredAt) > s.retention.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+
+		if err := deleteRun(tx, runs[i].ID); err != nil {
+			return fmt.Errorf("failed to remove run %s: %w", runs[i].ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package runhistory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClaim = `{"claim":{
+	"configurations":{
+		"testNamespaces":["tnf"],
+		"testPods":[{"metadata":{"name":"test-0","namespace":"tnf"}}],
+		"testDeployments":[{"metadata":{"name":"test","namespace":"tnf"}}],
+		"testStatefulSets":null,
+		"testOperators":[{"name":"etcd","namespace":"operators","version":"0.9.4"}]
+	},
+	"metadata":{"startTime":"2026-10-17 02:28:53 +0000 UTC","endTime":"2026-10-17 02:30:01 +0000 UTC"},
+	"versions":{"certSuite":"v5.6.0"},
+	"results":{
+		"access-control-ssh-daemons":{"state":"passed","testID":{"id":"access-control-ssh-daemons","suite":"access-control"}},
+		"observability-crd-status":{"state":"failed","testID":{"id":"observability-crd-status","suite":"observability"}},
+		"lifecycle-pod-scheduling":{"state":"skipped","testID":{"id":"lifecycle-pod-scheduling","suite":"lifecycle"}}
+	}
+}}`
+
+func openTestStore(t *testing.T, retention RetentionPolicy) *Store {
+	t.Helper()
+
+	s, err := Open(filepath.Join(t.TempDir(), "history.db"), retention)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func addTestRun(t *testing.T, s *Store, id, owner string, storedAt time.Time) {
+	t.Helper()
+
+	run, err := NewRun([]byte(testClaim))
+	require.NoError(t, err)
+	run.ID = id
+	run.Owner = owner
+	run.StoredAt = storedAt
+	require.NoError(t, s.Add(run, []byte(testClaim)))
+}
+
+func TestNewRun(t *testing.T) {
+	run, err := NewRun([]byte(testClaim))
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2026, 10, 17, 2, 28, 53, 0, time.UTC), run.StartTime)
+	assert.Equal(t, time.Date(2026, 10, 17, 2, 30, 1, 0, time.UTC), run.EndTime)
+	assert.Equal(t, "v5.6.0", run.CertSuiteVersion)
+	assert.Equal(t, map[string]int{"passed": 1, "failed": 1, "skipped": 1}, run.Summary)
+	assert.Equal(t, []string{"tnf"}, run.Namespaces)
+	assert.Equal(t, []Workload{
+		{Kind: "Deployment", Namespace: "tnf", Name: "test"},
+		{Kind: "Operator", Namespace: "operators", Name: "etcd"},
+		{Kind: "Pod", Namespace: "tnf", Name: "test-0"},
+	}, run.Workloads)
+	assert.Equal(t, "failed", run.Results["observability-crd-status"])
+
+	_, err = NewRun([]byte("not a claim"))
+	assert.Error(t, err)
+}
+
+func TestRunMatches(t *testing.T) {
+	run, err := NewRun([]byte(testClaim))
+	require.NoError(t, err)
+	run.Owner = "alice"
+
+	testCases := []struct {
+		query    Query
+		expected bool
+	}{
+		{query: Query{Owner: "alice"}, expected: true},
+		{query: Query{Owner: "bob"}, expected: false},
+		{query: Query{}, expected: false},
+		{query: Query{Owner: "alice", Namespace: "tnf"}, expected: true},
+		{query: Query{Owner: "alice", Namespace: "operators"}, expected: true},
+		{query: Query{Owner: "alice", Namespace: "default"}, expected: false},
+		{query: Query{Owner: "alice", Workload: "test"}, expected: true},
+		{query: Query{Owner: "alice", Workload: "tnf/test-0"}, expected: true},
+		{query: Query{Owner: "alice", Workload: "operators/test"}, expected: false},
+		{query: Query{Owner: "alice", TestID: "observability-crd-status"}, expected: true},
+		{query: Query{Owner: "alice", TestID: "observability-crd-status", TestState: "failed"}, expected: true},
+		{query: Query{Owner: "alice", TestID: "observability-crd-status", TestState: "passed"}, expected: false},
+		{query: Query{Owner: "alice", TestID: "lifecycle-pod-owner-type"}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, run.Matches(tc.query), "%+v", tc.query)
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := openTestStore(t, RetentionPolicy{})
+	now := time.Now().UTC()
+	addTestRun(t, s, "run1", "alice", now.Add(-time.Hour))
+	addTestRun(t, s, "run2", "alice", now)
+	addTestRun(t, s, "run3", "bob", now)
+
+	run, err := s.Get("run1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", run.Owner)
+	assert.Equal(t, 1, run.Summary["failed"])
+
+	claim, err := s.GetClaim("run1")
+	require.NoError(t, err)
+	assert.JSONEq(t, testClaim, string(claim))
+
+	runs, err := s.List(Query{Owner: "alice", Workload: "test"})
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	// The latest stored runs first.
+	assert.Equal(t, "run2", runs[0].ID)
+	assert.Equal(t, "run1", runs[1].ID)
+
+	require.NoError(t, s.Delete("run2"))
+	_, err = s.Get("run2")
+	assert.ErrorIs(t, err, ErrRunNotFound)
+	_, err = s.GetClaim("run2")
+	assert.ErrorIs(t, err, ErrRunNotFound)
+	assert.ErrorIs(t, s.Delete("run2"), ErrRunNotFound)
+}
+
+func TestStoreRetention(t *testing.T) {
+	s := openTestStore(t, RetentionPolicy{MaxRuns: 2, MaxAge: 24 * time.Hour})
+	now := time.Now().UTC()
+
+	addTestRun(t, s, "old", "", now.Add(-48*time.Hour))
+	_, err := s.Get("old")
+	assert.ErrorIs(t, err, ErrRunNotFound)
+
+	addTestRun(t, s, "run1", "", now.Add(-2*time.Hour))
+	addTestRun(t, s, "run2", "", now.Add(-time.Hour))
+	addTestRun(t, s, "run3", "", now)
+
+	runs, err := s.List(Query{})
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "run3", runs[0].ID)
+	assert.Equal(t, "run2", runs[1].ID)
+	_, err = s.GetClaim("run1")
+	assert.ErrorIs(t, err, ErrRunNotFound)
+}
@@ -17,6 +17,7 @@
 package scheduling
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -140,7 +141,7 @@ func GetProcessCPUScheduling(pid int, testContainer *provider.Container) (schedu
 
 	ch := clientsholder.GetClientsHolder()
 
-	stdout, stderr, err := ch.ExecCommandContainer(ctx, command)
+	stdout, stderr, err := ch.ExecCommandContainer(context.TODO(), ctx, command)
 	if err != nil || stderr != "" {
 		if strings.Contains(stderr, NoProcessFoundErrMsg) {
 			return schedulePolicy, InvalidPriority, fmt.Errorf("command %q in probe pod %s (node %s): %w",
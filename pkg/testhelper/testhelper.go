@@ -22,6 +22,7 @@ import (
 	"reflect"
 
 	goversion "github.com/hashicorp/go-version"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
 )
 
@@ -44,6 +45,16 @@ type ReportObject struct {
 type FailureReasonOut struct {
 	CompliantObjectsOut    []*ReportObject
 	NonCompliantObjectsOut []*ReportObject
+	// Non-compliant objects accepted by a waiver.
+	WaivedObjectsOut []*ReportObject `json:",omitempty"`
+	// Attempts of the checks that ran more than once.
+	Attempts []*CheckAttempt `json:",omitempty"`
+}
+
+// CheckAttempt is the result of an attempt of a check that is retried when it fails.
+type CheckAttempt struct {
+	Result     string
+	DurationMs int64
 }
 
 func Equal(p, other []*ReportObject) bool {
@@ -97,11 +108,12 @@ func ReportObjectTestString(p []*ReportObject) (out string) {
 }
 
 // Equal checks if the current FailureReasonOut is equal to the other FailureReasonOut.
-// It compares the CompliantObjectsOut and NonCompliantObjectsOut fields of both structs.
+// It compares the CompliantObjectsOut, NonCompliantObjectsOut and WaivedObjectsOut fields of both structs.
 // Returns true if they are equal, false otherwise.
 func (p FailureReasonOut) Equal(other FailureReasonOut) bool {
 	return Equal(p.CompliantObjectsOut, other.CompliantObjectsOut) &&
-		Equal(p.NonCompliantObjectsOut, other.NonCompliantObjectsOut)
+		Equal(p.NonCompliantObjectsOut, other.NonCompliantObjectsOut) &&
+		Equal(p.WaivedObjectsOut, other.WaivedObjectsOut)
 }
 
 // When adding new field types, please update the following:
@@ -157,6 +169,8 @@ const (
 	SysctlValue                     = "Sysctl Value"
 	OSImage                         = "OS Image"
 	ProbePodName                    = "Probe Pod Name"
+	WaiverJustification             = "Waiver Justification"
+	WaiverExpires                   = "Waiver Expires"
 
 	// ICMP tests
 	NetworkName              = "Network Name"
@@ -435,6 +449,15 @@ func GetNonOCPClusterSkipFn() func() (bool, string) {
 	}
 }
 
+func GetOfflineClusterSkipFn() func() (bool, string) {
+	return func() (bool, string) {
+		if clientsholder.GetClientsHolder().IsOffline() {
+			return true, "no live cluster to query"
+		}
+		return false, ""
+	}
+}
+
 func GetOCPVersionBelowSkipFn(env *provider.TestEnvironment, minVersion string) func() (bool, string) {
 	// Parse minVersion once at closure creation since it is constant.
 	minimum, minErr := goversion.NewVersion(minVersion)
@@ -562,6 +585,16 @@ func GetNoNamespacesSkipFn(env *provider.TestEnvironment) func() (bool, string)
 	}
 }
 
+func GetNoServiceAccountsUnderTestSkipFn(env *provider.TestEnvironment) func() (bool, string) {
+	return func() (bool, string) {
+		if len(env.ServiceAccounts) == 0 {
+			return true, "no service accounts to check found"
+		}
+
+		return false, ""
+	}
+}
+
 func GetNoRolesSkipFn(env *provider.TestEnvironment) func() (bool, string) {
 	return func() (bool, string) {
 		if len(env.Roles) == 0 {
@@ -739,10 +772,11 @@ func GetNoNodesWithRealtimeKernelSkipFn(env *provider.TestEnvironment) func() (b
 	}
 }
 
-func ResultObjectsToString(compliantObject, nonCompliantObject []*ReportObject) (string, error) {
+func ResultObjectsToString(compliantObject, nonCompliantObject, waivedObject []*ReportObject) (string, error) {
 	reason := FailureReasonOut{
 		CompliantObjectsOut:    compliantObject,
 		NonCompliantObjectsOut: nonCompliantObject,
+		WaivedObjectsOut:       waivedObject,
 	}
 
 	bytes, err := json.Marshal(reason)
@@ -716,6 +716,34 @@ func TestGetNoPodsUnderTestSkipFn(t *testing.T) {
 	}
 }
 
+func TestGetNoServiceAccountsUnderTestSkipFn(t *testing.T) {
+	testCases := []struct {
+		testEnv        *provider.TestEnvironment
+		expectedResult bool
+	}{
+		{
+			testEnv:        &provider.TestEnvironment{ServiceAccounts: nil},
+			expectedResult: true,
+		},
+		{
+			testEnv: &provider.TestEnvironment{ServiceAccounts: []*corev1.ServiceAccount{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test1",
+					},
+				},
+			}},
+			expectedResult: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testFunc := GetNoServiceAccountsUnderTestSkipFn(testCase.testEnv)
+		result, _ := testFunc()
+		assert.Equal(t, testCase.expectedResult, result)
+	}
+}
+
 func TestGetNoDeploymentsUnderTestSkipFn(t *testing.T) {
 	testCases := []struct {
 		testEnv        *provider.TestEnvironment
@@ -17,6 +17,7 @@
 package accesscontrol
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -33,7 +34,7 @@ const nbProcessesIndex = 2
 func getNbOfProcessesInPidNamespace(ctx clientsholder.Context, targetPid int, ch clientsholder.Command) (int, error) {
 	cmd := "lsns -p " + strconv.Itoa(targetPid) + " -t pid -n"
 
-	outStr, errStr, err := ch.ExecCommandContainer(ctx, cmd)
+	outStr, errStr, err := ch.ExecCommandContainer(context.TODO(), ctx, cmd)
 	if err != nil {
 		return 0, fmt.Errorf("can not execute command: \" %s \", err:%w", cmd, err)
 	}
@@ -135,6 +135,7 @@ const (
 	TestCrdsStatusSubresourceIdentifierDocLink              = "https://redhat-best-practices-for-k8s.github.io/guide/#k8s-best-practices-cnf-operator-requirements"
 	TestPodDisruptionBudgetIdentifierDocLink                = "https://redhat-best-practices-for-k8s.github.io/guide/#k8s-best-practices-upgrade-expectations"
 	TestAPICompatibilityWithNextOCPReleaseIdentifierDocLink = "https://redhat-best-practices-for-k8s.github.io/guide/#k8s-best-practices-k8s-api-versions"
+	TestAPIUsageFootprintIdentifierDocLink                  = "https://redhat-best-practices-for-k8s.github.io/guide/#k8s-best-practices-cnf-operator-requirements"
 
 	// Manageability Test Suite
 	TestContainersImageTagDocLink      = "https://redhat-best-practices-for-k8s.github.io/guide/#k8s-best-practices-image-tagging"
@@ -142,6 +142,7 @@ const (
 	TestCrdsStatusSubresourceIdentifierImpact              = `Missing status subresources prevent proper monitoring and automation based on custom resource states.`
 	TestPodDisruptionBudgetIdentifierImpact                = `Improper disruption budgets can prevent necessary maintenance operations or allow too many pods to be disrupted simultaneously. Non-zone-aware PDBs can block platform upgrades when all workers in a zone need to be drained.`
 	TestAPICompatibilityWithNextOCPReleaseIdentifierImpact = `Deprecated API usage can cause applications to break during OpenShift upgrades, requiring emergency fixes.`
+	TestAPIUsageFootprintIdentifierImpact                  = `Controllers that poll, watch large resources cluster-wide or update resources in a loop can overload the API server and etcd, slowing down or throttling every other client of the cluster.`
 
 	// Manageability Test Suite Impact Statements
 	TestContainersImageTagImpact      = `Missing image tags make it difficult to track versions, perform rollbacks, and maintain deployment consistency.`
@@ -288,6 +289,7 @@ var ImpactMap = map[string]string{
 	"observability-crd-status":                          TestCrdsStatusSubresourceIdentifierImpact,
 	"observability-pod-disruption-budget":               TestPodDisruptionBudgetIdentifierImpact,
 	"observability-compatibility-with-next-ocp-release": TestAPICompatibilityWithNextOCPReleaseIdentifierImpact,
+	"observability-api-usage-footprint":                 TestAPIUsageFootprintIdentifierImpact,
 
 	// Manageability Test Suite
 	"manageability-containers-image-tag":       TestContainersImageTagImpact,
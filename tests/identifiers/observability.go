@@ -23,6 +23,7 @@ import (
 
 var (
 	TestAPICompatibilityWithNextOCPReleaseIdentifier claim.Identifier
+	TestAPIUsageFootprintIdentifier                  claim.Identifier
 	TestCrdsStatusSubresourceIdentifier              claim.Identifier
 	TestLoggingIdentifier                            claim.Identifier
 	TestPodDisruptionBudgetIdentifier                claim.Identifier
@@ -46,6 +47,21 @@ func init() {
 			Extended: Optional,
 		},
 		TagCommon)
+	TestAPIUsageFootprintIdentifier = AddCatalogEntry(
+		"api-usage-footprint",
+		common.ObservabilityTestKey,
+		`Profiles the Kubernetes API usage of the service accounts under test, from the APIRequestCount objects of the last 24 hours, by resource and verb, and flags the ones likely to overload the API server: more than 10 requests per second, or 5 to a verb of a resource, LIST requests to a resource it never watches at least every 10 seconds, update and patch requests to a resource (e.g. status update loops) more than once per second, and watches of pods, secrets, configmaps or events it can watch in every namespace. On clusters without APIRequestCounts, e.g. vanilla Kubernetes, only the cluster-wide watch permissions of those resources are checked.`, //nolint:lll
+		APIUsageFootprintRemediation,
+		NoExceptions,
+		TestAPIUsageFootprintIdentifierDocLink,
+		true,
+		map[string]string{
+			FarEdge:  Optional,
+			Telco:    Optional,
+			NonTelco: Optional,
+			Extended: Optional,
+		},
+		TagCommon)
 	TestCrdsStatusSubresourceIdentifier = AddCatalogEntry(
 		"crd-status",
 		common.ObservabilityTestKey,
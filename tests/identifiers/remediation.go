@@ -177,6 +177,8 @@ const (
 
 	APICompatibilityWithNextOCPReleaseRemediation = `Ensure the APIs the workload uses are compatible with the next OCP version`
 
+	APIUsageFootprintRemediation = `Use shared informers to watch the resources instead of listing them periodically, restrict the watches of large resources to the namespaces of the workload with namespaced roles, only update the status of the resources when it changes, and rate limit the requests of the controllers`
+
 	//nolint:gosec
 	PodTolerationBypassRemediation = `Do not allow pods to bypass the NoExecute, PreferNoSchedule, or NoSchedule tolerations that are default applied by Kubernetes.`
 
@@ -45,13 +45,13 @@ const (
 	NoDelete                    = "noDelete"
 )
 
-func CordonHelper(name, operation string) error {
+func CordonHelper(ctx context.Context, name, operation string) error {
 	clients := clientsholder.GetClientsHolder()
 
 	log.Info("Performing %s operation on node %s", operation, name)
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Fetch node object
-		node, err := clients.K8sClient.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+		node, err := clients.K8sClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to get node %s: %w", name, err)
 		}
@@ -64,7 +64,7 @@ func CordonHelper(name, operation string) error {
 			return fmt.Errorf("cordonHelper: Unsupported operation:%s", operation)
 		}
 		// Update the node
-		_, err = clients.K8sClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		_, err = clients.K8sClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to update node %s: %w", name, err)
 		}
@@ -76,7 +76,7 @@ func CordonHelper(name, operation string) error {
 	return retryErr
 }
 
-func CountPodsWithDelete(pods []*provider.Pod, nodeName, mode string) (count int, err error) {
+func CountPodsWithDelete(ctx context.Context, pods []*provider.Pod, nodeName, mode string) (count int, err error) {
 	count = 0
 	var wg sync.WaitGroup
 	for _, put := range pods {
@@ -91,7 +91,7 @@ func CountPodsWithDelete(pods []*provider.Pod, nodeName, mode string) (count int
 			if mode == NoDelete {
 				continue
 			}
-			err := deletePod(put.Pod, mode, &wg)
+			err := deletePod(ctx, put.Pod, mode, &wg)
 			if err != nil {
 				log.Error("Error deleting %s", put)
 			}
@@ -111,19 +111,19 @@ func skipDaemonPod(pod *corev1.Pod) bool {
 	return false
 }
 
-func deletePod(pod *corev1.Pod, mode string, wg *sync.WaitGroup) error {
+func deletePod(ctx context.Context, pod *corev1.Pod, mode string, wg *sync.WaitGroup) error {
 	clients := clientsholder.GetClientsHolder()
 	log.Debug("deleting ns=%s pod=%s with %s mode", pod.Namespace, pod.Name, mode)
 	gracePeriodSeconds := *pod.Spec.TerminationGracePeriodSeconds
 	// Create watcher before deleting pod
-	watcher, err := clients.K8sClient.CoreV1().Pods(pod.Namespace).Watch(context.TODO(), metav1.ListOptions{
+	watcher, err := clients.K8sClient.CoreV1().Pods(pod.Namespace).Watch(ctx, metav1.ListOptions{
 		FieldSelector: "metadata.name=" + pod.Name + ",metadata.namespace=" + pod.Namespace,
 	})
 	if err != nil {
 		return fmt.Errorf("waitPodDeleted ns=%s pod=%s, err=%w", pod.Namespace, pod.Name, err)
 	}
 	// Actually deleting pod
-	err = clients.K8sClient.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{
+	err = clients.K8sClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{
 		GracePeriodSeconds: &gracePeriodSeconds,
 	})
 	if err != nil {
@@ -135,19 +135,20 @@ func deletePod(pod *corev1.Pod, mode string, wg *sync.WaitGroup) error {
 	podName := pod.Name
 	namespace := pod.Namespace
 	wg.Go(func() {
-		waitPodDeleted(namespace, podName, gracePeriodSeconds, watcher)
+		waitPodDeleted(ctx, namespace, podName, gracePeriodSeconds, watcher)
 	})
 	return nil
 }
 
 func CordonCleanup(node string, check *checksdb.Check) {
-	err := CordonHelper(node, Uncordon)
+	// Not using the check's context, as the node must be uncordoned even if the check was cancelled.
+	err := CordonHelper(context.Background(), node, Uncordon)
 	if err != nil {
 		check.Abort(fmt.Sprintf("cleanup: error uncordoning the node: %s, err=%s", node, err))
 	}
 }
 
-func waitPodDeleted(ns, podName string, timeout int64, watcher watch.Interface) {
+func waitPodDeleted(ctx context.Context, ns, podName string, timeout int64, watcher watch.Interface) {
 	log.Debug("Entering waitPodDeleted ns=%s pod=%s", ns, podName)
 	defer watcher.Stop()
 
@@ -161,6 +162,9 @@ func waitPodDeleted(ns, podName string, timeout int64, watcher watch.Interface)
 		case <-time.After(time.Duration(timeout) * time.Second):
 			log.Info("watch for pod deletion timedout after %d seconds", timeout)
 			return
+		case <-ctx.Done():
+			log.Info("watch for pod deletion cancelled: %v", ctx.Err())
+			return
 		}
 	}
 }
@@ -78,7 +78,7 @@ func TestCountPodsWithDelete(t *testing.T) {
 	// create the clientsHolder
 	_ = clientsholder.GetTestClientsHolder(testRuntimeObjects)
 	for _, tc := range testCases {
-		result, err := CountPodsWithDelete(tc.testPods, "node1", DeleteBackground)
+		result, err := CountPodsWithDelete(context.Background(), tc.testPods, "node1", DeleteBackground)
 		assert.Nil(t, err)
 		assert.Equal(t, tc.expectedCount, result)
 	}
@@ -124,7 +124,7 @@ func TestCordonHelper(t *testing.T) {
 		// Clean and recreate the clientsHolder
 		clientsholder.ClearTestClientsHolder()
 		client := clientsholder.GetTestClientsHolder(testRuntimeObjects)
-		err := CordonHelper("node1", tc.operation)
+		err := CordonHelper(context.Background(), "node1", tc.operation)
 		assert.Nil(t, err)
 
 		// Check that the node is actually cordoned or uncordoned
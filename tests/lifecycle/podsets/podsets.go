@@ -17,6 +17,7 @@
 package podsets
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -31,7 +32,17 @@ const (
 	StatefulsetString = "StatefulSet"
 )
 
-var WaitForDeploymentSetReady = func(ns, name string, timeout time.Duration, logger *log.Logger) bool {
+// sleepUnlessDone waits for the given duration. Returns false if ctx was cancelled before.
+func sleepUnlessDone(ctx context.Context, duration time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(duration):
+		return true
+	}
+}
+
+var WaitForDeploymentSetReady = func(ctx context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
 	logger.Info("Check if Deployment %s:%s is ready", ns, name)
 	clients := clientsholder.GetClientsHolder()
 	start := time.Now()
@@ -46,13 +57,16 @@ var WaitForDeploymentSetReady = func(ns, name string, timeout time.Duration, log
 			return true
 		}
 
-		time.Sleep(time.Second)
+		if !sleepUnlessDone(ctx, time.Second) {
+			logger.Error("Stopped waiting for Deployment %s:%s: %v", ns, name, ctx.Err())
+			return false
+		}
 	}
 	logger.Error("Deployment %s:%s is not ready", ns, name)
 	return false
 }
 
-var WaitForScalingToComplete = func(ns, name string, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
+var WaitForScalingToComplete = func(ctx context.Context, ns, name string, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
 	logger.Info("Check if scale object for CRs %s:%s is ready", ns, name)
 	clients := clientsholder.GetClientsHolder()
 	start := time.Now()
@@ -67,13 +81,16 @@ var WaitForScalingToComplete = func(ns, name string, timeout time.Duration, grou
 			return true
 		}
 
-		time.Sleep(time.Second)
+		if !sleepUnlessDone(ctx, time.Second) {
+			logger.Error("Stopped waiting for CR %s:%s scaling: %v", ns, name, ctx.Err())
+			return false
+		}
 	}
 	logger.Error("Timeout waiting for CR %s:%s scaling to be complete", ns, name)
 	return false
 }
 
-var WaitForStatefulSetReady = func(ns, name string, timeout time.Duration, logger *log.Logger) bool {
+var WaitForStatefulSetReady = func(ctx context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
 	logger.Debug("Check if statefulset %s:%s is ready", ns, name)
 	clients := clientsholder.GetClientsHolder()
 	start := time.Now()
@@ -85,7 +102,10 @@ var WaitForStatefulSetReady = func(ns, name string, timeout time.Duration, logge
 			logger.Info("%s is ready", ss.ToString())
 			return true
 		}
-		time.Sleep(time.Second)
+		if !sleepUnlessDone(ctx, time.Second) {
+			logger.Error("Stopped waiting for statefulset %s:%s: %v", ns, name, ctx.Err())
+			return false
+		}
 	}
 	logger.Error("Statefulset %s:%s is not ready", ns, name)
 	return false
@@ -180,7 +200,7 @@ func getNotReadyStatefulSets(statefulSets []*provider.StatefulSet) []*provider.S
 	return notReadyStatefulSets
 }
 
-func WaitForAllPodSetsReady(env *provider.TestEnvironment, timeout time.Duration, logger *log.Logger) (
+func WaitForAllPodSetsReady(ctx context.Context, env *provider.TestEnvironment, timeout time.Duration, logger *log.Logger) (
 	notReadyDeployments []*provider.Deployment,
 	notReadyStatefulSets []*provider.StatefulSet) {
 	const queryInterval = 15 * time.Second
@@ -207,7 +227,10 @@ func WaitForAllPodSetsReady(env *provider.TestEnvironment, timeout time.Duration
 			break
 		}
 
-		time.Sleep(queryInterval)
+		if !sleepUnlessDone(ctx, queryInterval) {
+			logger.Error("Stopped waiting for the podsets to be ready: %v", ctx.Err())
+			break
+		}
 	}
 
 	// Here, either we reached the timeout or there's no more not-ready deployments or statefulsets.
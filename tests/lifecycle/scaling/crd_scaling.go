@@ -37,7 +37,7 @@ import (
 	retry "k8s.io/client-go/util/retry"
 )
 
-func TestScaleCrd(crScale *provider.CrScale, groupResourceSchema schema.GroupResource, timeout time.Duration, logger *log.Logger) bool {
+func TestScaleCrd(ctx context.Context, crScale *provider.CrScale, groupResourceSchema schema.GroupResource, timeout time.Duration, logger *log.Logger) bool {
 	if crScale == nil {
 		logger.Error("CR object is nill")
 		return false
@@ -47,37 +47,26 @@ func TestScaleCrd(crScale *provider.CrScale, groupResourceSchema schema.GroupRes
 	name := crScale.GetName()
 	namespace := crScale.GetNamespace()
 
-	if replicas <= 1 {
-		// scale up
-		replicas++
-		if !scaleCrHelper(clients.ScalingClient, groupResourceSchema, crScale, replicas, true, timeout, logger) {
-			logger.Error("Cannot scale CR %q in namespace %q", name, namespace)
-			return false
-		}
-		// scale down
-		replicas--
-		if !scaleCrHelper(clients.ScalingClient, groupResourceSchema, crScale, replicas, false, timeout, logger) {
-			logger.Error("Cannot scale CR  %q in namespace %q", name, namespace)
-			return false
-		}
-	} else {
-		// scale down
-		replicas--
-		if !scaleCrHelper(clients.ScalingClient, groupResourceSchema, crScale, replicas, false, timeout, logger) {
-			logger.Error("Cannot scale CR %q in namespace %q", name, namespace)
-			return false
-		} // scale up
-		replicas++
-		if !scaleCrHelper(clients.ScalingClient, groupResourceSchema, crScale, replicas, true, timeout, logger) {
-			logger.Error("Cannot scale CR %q in namespace %q", name, namespace)
-			return false
-		}
+	// scale up, or down when there are several replicas
+	scaleUp := replicas <= 1
+	scaledReplicas := replicas + 1
+	if !scaleUp {
+		scaledReplicas = replicas - 1
+	}
+	scaled := scaleCrHelper(ctx, clients.ScalingClient, groupResourceSchema, crScale, scaledReplicas, scaleUp, timeout, logger)
+
+	// scale back, even if the check timed out or was aborted
+	restoreCtx, cancel := newRestoreContext(ctx, timeout)
+	defer cancel()
+	if !scaleCrHelper(restoreCtx, clients.ScalingClient, groupResourceSchema, crScale, replicas, !scaleUp, timeout, logger) || !scaled {
+		logger.Error("Cannot scale CR %q in namespace %q", name, namespace)
+		return false
 	}
 
 	return true
 }
 
-func scaleCrHelper(scalesGetter scale.ScalesGetter, rc schema.GroupResource, autoscalerpram *provider.CrScale, replicas int32, up bool, timeout time.Duration, logger *log.Logger) bool {
+func scaleCrHelper(ctx context.Context, scalesGetter scale.ScalesGetter, rc schema.GroupResource, autoscalerpram *provider.CrScale, replicas int32, up bool, timeout time.Duration, logger *log.Logger) bool {
 	if up {
 		logger.Debug("Scale UP CRS to %d replicas", replicas)
 	} else {
@@ -88,16 +77,16 @@ func scaleCrHelper(scalesGetter scale.ScalesGetter, rc schema.GroupResource, aut
 		// RetryOnConflict uses exponential backoff to avoid exhausting the apiserver
 		namespace := autoscalerpram.GetNamespace()
 		name := autoscalerpram.GetName()
-		scalingObject, err := scalesGetter.Scales(namespace).Get(context.TODO(), rc, name, metav1.GetOptions{})
+		scalingObject, err := scalesGetter.Scales(namespace).Get(ctx, rc, name, metav1.GetOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to get scale object for %s/%s: %w", namespace, name, err)
 		}
 		scalingObject.Spec.Replicas = replicas
-		_, err = scalesGetter.Scales(namespace).Update(context.TODO(), rc, scalingObject, metav1.UpdateOptions{})
+		_, err = scalesGetter.Scales(namespace).Update(ctx, rc, scalingObject, metav1.UpdateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to update scale object for %s/%s: %w", namespace, name, err)
 		}
-		if !podsets.WaitForScalingToComplete(namespace, name, timeout, rc, logger) {
+		if !podsets.WaitForScalingToComplete(ctx, namespace, name, timeout, rc, logger) {
 			logger.Error("Cannot update CR %s:%s", namespace, name)
 			return errors.New("can not update cr")
 		}
@@ -110,7 +99,7 @@ func scaleCrHelper(scalesGetter scale.ScalesGetter, rc schema.GroupResource, aut
 	return true
 }
 
-func TestScaleHPACrd(cr *provider.CrScale, hpa *scalingv1.HorizontalPodAutoscaler, groupResourceSchema schema.GroupResource, timeout time.Duration, logger *log.Logger) bool {
+func TestScaleHPACrd(ctx context.Context, cr *provider.CrScale, hpa *scalingv1.HorizontalPodAutoscaler, groupResourceSchema schema.GroupResource, timeout time.Duration, logger *log.Logger) bool {
 	if cr == nil {
 		logger.Error("CR object is nill")
 		return false
@@ -125,56 +114,41 @@ func TestScaleHPACrd(cr *provider.CrScale, hpa *scalingv1.HorizontalPodAutoscale
 	}
 	replicas := cr.Spec.Replicas
 	name := cr.GetName()
+	max := hpa.Spec.MaxReplicas
 
-	if replicas <= 1 {
-		// scale up
-		replicas++
-		logger.Debug("Scale UP HPA %s:%s to min=%d max=%d", namespace, hpa.Name, replicas, replicas)
-		pass := scaleHpaCRDHelper(hpscaler, hpa.Name, name, namespace, replicas, replicas, timeout, groupResourceSchema, logger)
-		if !pass {
-			return false
-		}
-		// scale down
-		replicas--
-		logger.Debug("Scale DOWN HPA %s:%s to min=%d max=%d", namespace, hpa.Name, replicas, replicas)
-		pass = scaleHpaCRDHelper(hpscaler, hpa.Name, name, namespace, min, hpa.Spec.MaxReplicas, timeout, groupResourceSchema, logger)
-		if !pass {
-			return false
-		}
-	} else {
-		// scale down
-		replicas--
-		logger.Debug("Scale DOWN HPA %s:%s to min=%d max=%d", namespace, hpa.Name, replicas, replicas)
-		pass := scaleHpaCRDHelper(hpscaler, hpa.Name, name, namespace, replicas, replicas, timeout, groupResourceSchema, logger)
-		if !pass {
-			return false
-		}
-		// scale up
-		replicas++
-		logger.Debug("Scale UP HPA %s:%s to min=%d max=%d", namespace, hpa.Name, replicas, replicas)
-		pass = scaleHpaCRDHelper(hpscaler, hpa.Name, name, namespace, replicas, replicas, timeout, groupResourceSchema, logger)
-		if !pass {
-			return false
-		}
+	// scale up, or down when there are several replicas
+	scaledReplicas := replicas + 1
+	if replicas > 1 {
+		scaledReplicas = replicas - 1
+	}
+	logger.Debug("Scale HPA %s:%s to min=%d max=%d", namespace, hpa.Name, scaledReplicas, scaledReplicas)
+	pass := scaleHpaCRDHelper(ctx, hpscaler, hpa.Name, name, namespace, scaledReplicas, scaledReplicas, timeout, groupResourceSchema, logger)
+
+	// scale back and restore the hpa, even if the check timed out or was aborted
+	restoreCtx, cancel := newRestoreContext(ctx, 2*timeout)
+	defer cancel()
+	if pass {
+		logger.Debug("Scale HPA %s:%s back to min=%d max=%d", namespace, hpa.Name, replicas, replicas)
+		pass = scaleHpaCRDHelper(restoreCtx, hpscaler, hpa.Name, name, namespace, replicas, replicas, timeout, groupResourceSchema, logger)
 	}
 	// back the min and the max value of the hpa
-	logger.Debug("Back HPA %s:%s to min=%d max=%d", namespace, hpa.Name, min, hpa.Spec.MaxReplicas)
-	return scaleHpaCRDHelper(hpscaler, hpa.Name, name, namespace, min, hpa.Spec.MaxReplicas, timeout, groupResourceSchema, logger)
+	logger.Debug("Back HPA %s:%s to min=%d max=%d", namespace, hpa.Name, min, max)
+	return scaleHpaCRDHelper(restoreCtx, hpscaler, hpa.Name, name, namespace, min, max, timeout, groupResourceSchema, logger) && pass
 }
 
-func scaleHpaCRDHelper(hpscaler hps.HorizontalPodAutoscalerInterface, hpaName, crName, namespace string, min, max int32, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
+func scaleHpaCRDHelper(ctx context.Context, hpscaler hps.HorizontalPodAutoscalerInterface, hpaName, crName, namespace string, min, max int32, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		hpa, err := hpscaler.Get(context.TODO(), hpaName, metav1.GetOptions{})
+		hpa, err := hpscaler.Get(ctx, hpaName, metav1.GetOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to get HPA %s in namespace %s: %w", hpaName, namespace, err)
 		}
 		hpa.Spec.MinReplicas = &min
 		hpa.Spec.MaxReplicas = max
-		_, err = hpscaler.Update(context.TODO(), hpa, metav1.UpdateOptions{})
+		_, err = hpscaler.Update(ctx, hpa, metav1.UpdateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to update HPA %s in namespace %s: %w", hpaName, namespace, err)
 		}
-		if !podsets.WaitForScalingToComplete(namespace, crName, timeout, groupResourceSchema, logger) {
+		if !podsets.WaitForScalingToComplete(ctx, namespace, crName, timeout, groupResourceSchema, logger) {
 			logger.Error("Cannot update CR %s:%s", namespace, crName)
 			return errors.New("can not update cr")
 		}
@@ -101,7 +101,7 @@ func TestScaleHpaCRDHelper(t *testing.T) {
 	defer func() {
 		podsets.WaitForScalingToComplete = origFunc
 	}()
-	podsets.WaitForScalingToComplete = func(ns, name string, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
+	podsets.WaitForScalingToComplete = func(_ context.Context, ns, name string, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
 		return true
 	}
 
@@ -134,7 +134,7 @@ func TestScaleHpaCRDHelper(t *testing.T) {
 
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		result := scaleHpaCRDHelper(client.AutoscalingV1().HorizontalPodAutoscalers("ns1"), "hpaName", "cr1", "ns1", 1, 3, 10*time.Second, gr, log.GetLogger())
+		result := scaleHpaCRDHelper(context.Background(), client.AutoscalingV1().HorizontalPodAutoscalers("ns1"), "hpaName", "cr1", "ns1", 1, 3, 10*time.Second, gr, log.GetLogger())
 		assert.Equal(t, tc.expectedOutput, result)
 	}
 }
@@ -171,7 +171,7 @@ func TestScaleCrHelper(t *testing.T) {
 	defer func() {
 		podsets.WaitForScalingToComplete = origFunc
 	}()
-	podsets.WaitForScalingToComplete = func(ns, name string, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
+	podsets.WaitForScalingToComplete = func(_ context.Context, ns, name string, timeout time.Duration, groupResourceSchema schema.GroupResource, logger *log.Logger) bool {
 		return true
 	}
 
@@ -211,7 +211,7 @@ func TestScaleCrHelper(t *testing.T) {
 
 			var logArchive strings.Builder
 			log.SetupLogger(&logArchive, "INFO")
-			result := scaleCrHelper(fakeGetter, gr, crScale, 2, true, 10*time.Second, log.GetLogger())
+			result := scaleCrHelper(context.Background(), fakeGetter, gr, crScale, 2, true, 10*time.Second, log.GetLogger())
 			assert.Equal(t, tc.expectedOutput, result)
 		})
 	}
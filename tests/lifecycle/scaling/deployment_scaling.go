@@ -38,7 +38,7 @@ import (
 	hps "k8s.io/client-go/kubernetes/typed/autoscaling/v1"
 )
 
-func TestScaleDeployment(deployment *appsv1.Deployment, timeout time.Duration, logger *log.Logger) bool {
+func TestScaleDeployment(ctx context.Context, deployment *appsv1.Deployment, timeout time.Duration, logger *log.Logger) bool {
 	clients := clientsholder.GetClientsHolder()
 	logger.Info("Deployment not using HPA: %s:%s", deployment.Namespace, deployment.Name)
 	var replicas int32
@@ -48,36 +48,25 @@ func TestScaleDeployment(deployment *appsv1.Deployment, timeout time.Duration, l
 		replicas = 1
 	}
 
-	if replicas <= 1 {
-		// scale up
-		replicas++
-		if !scaleDeploymentHelper(clients.K8sClient.AppsV1(), deployment, replicas, timeout, true, logger) {
-			logger.Error("Cannot scale Deployment %s:%s", deployment.Namespace, deployment.Name)
-			return false
-		}
-		// scale down
-		replicas--
-		if !scaleDeploymentHelper(clients.K8sClient.AppsV1(), deployment, replicas, timeout, false, logger) {
-			logger.Error("Cannot scale Deployment %s:%s", deployment.Namespace, deployment.Name)
-			return false
-		}
-	} else {
-		// scale down
-		replicas--
-		if !scaleDeploymentHelper(clients.K8sClient.AppsV1(), deployment, replicas, timeout, false, logger) {
-			logger.Error("Cannot scale Deployment %s:%s", deployment.Namespace, deployment.Name)
-			return false
-		} // scale up
-		replicas++
-		if !scaleDeploymentHelper(clients.K8sClient.AppsV1(), deployment, replicas, timeout, true, logger) {
-			logger.Error("Cannot scale Deployment %s:%s", deployment.Namespace, deployment.Name)
-			return false
-		}
+	// scale up, or down when there are several replicas
+	scaleUp := replicas <= 1
+	scaledReplicas := replicas + 1
+	if !scaleUp {
+		scaledReplicas = replicas - 1
+	}
+	scaled := scaleDeploymentHelper(ctx, clients.K8sClient.AppsV1(), deployment, scaledReplicas, timeout, scaleUp, logger)
+
+	// scale back, even if the check timed out or was aborted
+	restoreCtx, cancel := newRestoreContext(ctx, timeout)
+	defer cancel()
+	if !scaleDeploymentHelper(restoreCtx, clients.K8sClient.AppsV1(), deployment, replicas, timeout, !scaleUp, logger) || !scaled {
+		logger.Error("Cannot scale Deployment %s:%s", deployment.Namespace, deployment.Name)
+		return false
 	}
 	return true
 }
 
-func scaleDeploymentHelper(client typedappsv1.AppsV1Interface, deployment *appsv1.Deployment, replicas int32, timeout time.Duration, up bool, logger *log.Logger) bool {
+func scaleDeploymentHelper(ctx context.Context, client typedappsv1.AppsV1Interface, deployment *appsv1.Deployment, replicas int32, timeout time.Duration, up bool, logger *log.Logger) bool {
 	if up {
 		logger.Info("Scale UP deployment to %d replicas", replicas)
 	} else {
@@ -87,16 +76,16 @@ func scaleDeploymentHelper(client typedappsv1.AppsV1Interface, deployment *appsv
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Retrieve the latest version of Deployment before attempting update
 		// RetryOnConflict uses exponential backoff to avoid exhausting the apiserver
-		dp, err := client.Deployments(deployment.Namespace).Get(context.TODO(), deployment.Name, v1machinery.GetOptions{})
+		dp, err := client.Deployments(deployment.Namespace).Get(ctx, deployment.Name, v1machinery.GetOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to get deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
 		}
 		dp.Spec.Replicas = &replicas
-		_, err = client.Deployments(deployment.Namespace).Update(context.TODO(), dp, v1machinery.UpdateOptions{})
+		_, err = client.Deployments(deployment.Namespace).Update(ctx, dp, v1machinery.UpdateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to update deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
 		}
-		if !podsets.WaitForDeploymentSetReady(deployment.Namespace, deployment.Name, timeout, logger) {
+		if !podsets.WaitForDeploymentSetReady(ctx, deployment.Namespace, deployment.Name, timeout, logger) {
 			logger.Error("Cannot update Deployment %s:%s", deployment.Namespace, deployment.Name)
 			return errors.New("can not update deployment")
 		}
@@ -109,7 +98,7 @@ func scaleDeploymentHelper(client typedappsv1.AppsV1Interface, deployment *appsv
 	return true
 }
 
-func TestScaleHpaDeployment(deployment *provider.Deployment, hpa *v1autoscaling.HorizontalPodAutoscaler, timeout time.Duration, logger *log.Logger) bool {
+func TestScaleHpaDeployment(ctx context.Context, deployment *provider.Deployment, hpa *v1autoscaling.HorizontalPodAutoscaler, timeout time.Duration, logger *log.Logger) bool {
 	clients := clientsholder.GetClientsHolder()
 	hpscaler := clients.K8sClient.AutoscalingV1().HorizontalPodAutoscalers(deployment.Namespace)
 	var min int32
@@ -123,55 +112,39 @@ func TestScaleHpaDeployment(deployment *provider.Deployment, hpa *v1autoscaling.
 		replicas = *deployment.Spec.Replicas
 	}
 	max := hpa.Spec.MaxReplicas
-	if replicas <= 1 {
-		// scale up
-		replicas++
-		logger.Debug("Scale UP HPA %s:%s to min=%d max=%d", deployment.Namespace, hpa.Name, replicas, replicas)
-		pass := scaleHpaDeploymentHelper(hpscaler, hpa.Name, deployment.Name, deployment.Namespace, replicas, replicas, timeout, logger)
-		if !pass {
-			return false
-		}
-		// scale down
-		replicas--
-		logger.Debug("Scale DOWN HPA %s:%s to min=%d max=%d", deployment.Namespace, hpa.Name, replicas, replicas)
-		pass = scaleHpaDeploymentHelper(hpscaler, hpa.Name, deployment.Name, deployment.Namespace, min, max, timeout, logger)
-		if !pass {
-			return false
-		}
-	} else {
-		// scale down
-		replicas--
-		logger.Debug("Scale DOWN HPA %s:%s to min=%d max=%d", deployment.Namespace, hpa.Name, replicas, replicas)
-		pass := scaleHpaDeploymentHelper(hpscaler, hpa.Name, deployment.Name, deployment.Namespace, replicas, replicas, timeout, logger)
-		if !pass {
-			return false
-		}
-		// scale up
-		replicas++
-		logger.Debug("Scale UP HPA %s:%s to min=%d max=%d", deployment.Namespace, hpa.Name, replicas, replicas)
-		pass = scaleHpaDeploymentHelper(hpscaler, hpa.Name, deployment.Name, deployment.Namespace, replicas, replicas, timeout, logger)
-		if !pass {
-			return false
-		}
+	// scale up, or down when there are several replicas
+	scaledReplicas := replicas + 1
+	if replicas > 1 {
+		scaledReplicas = replicas - 1
+	}
+	logger.Debug("Scale HPA %s:%s to min=%d max=%d", deployment.Namespace, hpa.Name, scaledReplicas, scaledReplicas)
+	pass := scaleHpaDeploymentHelper(ctx, hpscaler, hpa.Name, deployment.Name, deployment.Namespace, scaledReplicas, scaledReplicas, timeout, logger)
+
+	// scale back and restore the hpa, even if the check timed out or was aborted
+	restoreCtx, cancel := newRestoreContext(ctx, 2*timeout)
+	defer cancel()
+	if pass {
+		logger.Debug("Scale HPA %s:%s back to min=%d max=%d", deployment.Namespace, hpa.Name, replicas, replicas)
+		pass = scaleHpaDeploymentHelper(restoreCtx, hpscaler, hpa.Name, deployment.Name, deployment.Namespace, replicas, replicas, timeout, logger)
 	}
 	// back the min and the max value of the hpa
 	logger.Debug("Back HPA %s:%s to min=%d max=%d", deployment.Namespace, hpa.Name, min, max)
-	return scaleHpaDeploymentHelper(hpscaler, hpa.Name, deployment.Name, deployment.Namespace, min, max, timeout, logger)
+	return scaleHpaDeploymentHelper(restoreCtx, hpscaler, hpa.Name, deployment.Name, deployment.Namespace, min, max, timeout, logger) && pass
 }
 
-func scaleHpaDeploymentHelper(hpscaler hps.HorizontalPodAutoscalerInterface, hpaName, deploymentName, namespace string, min, max int32, timeout time.Duration, logger *log.Logger) bool {
+func scaleHpaDeploymentHelper(ctx context.Context, hpscaler hps.HorizontalPodAutoscalerInterface, hpaName, deploymentName, namespace string, min, max int32, timeout time.Duration, logger *log.Logger) bool {
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		hpa, err := hpscaler.Get(context.TODO(), hpaName, v1machinery.GetOptions{})
+		hpa, err := hpscaler.Get(ctx, hpaName, v1machinery.GetOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to get HPA %s in namespace %s: %w", hpaName, namespace, err)
 		}
 		hpa.Spec.MinReplicas = &min
 		hpa.Spec.MaxReplicas = max
-		_, err = hpscaler.Update(context.TODO(), hpa, v1machinery.UpdateOptions{})
+		_, err = hpscaler.Update(ctx, hpa, v1machinery.UpdateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to update HPA %s in namespace %s: %w", hpaName, namespace, err)
 		}
-		if !podsets.WaitForDeploymentSetReady(namespace, deploymentName, timeout, logger) {
+		if !podsets.WaitForDeploymentSetReady(ctx, namespace, deploymentName, timeout, logger) {
 			logger.Error("Deployment not ready after scale operation %s:%s", namespace, deploymentName)
 		}
 		return nil
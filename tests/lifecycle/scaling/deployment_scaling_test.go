@@ -68,7 +68,7 @@ func TestScaleDeploymentFunc(t *testing.T) {
 	defer func() {
 		podsets.WaitForDeploymentSetReady = origFunc
 	}()
-	podsets.WaitForDeploymentSetReady = func(ns, name string, timeout time.Duration, logger *log.Logger) bool {
+	podsets.WaitForDeploymentSetReady = func(_ context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
 		return true
 	}
 
@@ -83,7 +83,7 @@ func TestScaleDeploymentFunc(t *testing.T) {
 		// Run the function
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		TestScaleDeployment(tempDP, 10*time.Second, log.GetLogger())
+		TestScaleDeployment(context.Background(), tempDP, 10*time.Second, log.GetLogger())
 
 		// Get the deployment from the fake API
 		dp, err := c.K8sClient.AppsV1().Deployments("namespace1").Get(context.TODO(), tc.deploymentName, metav1.GetOptions{})
@@ -133,7 +133,7 @@ func TestScaleHpaDeploymentFunc(t *testing.T) {
 	defer func() {
 		podsets.WaitForDeploymentSetReady = origFunc
 	}()
-	podsets.WaitForDeploymentSetReady = func(ns, name string, timeout time.Duration, logger *log.Logger) bool {
+	podsets.WaitForDeploymentSetReady = func(_ context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
 		return true
 	}
 
@@ -179,7 +179,7 @@ func TestScaleHpaDeploymentFunc(t *testing.T) {
 		// Run the function
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		TestScaleHpaDeployment(dp, hpatest, 10*time.Second, log.GetLogger())
+		TestScaleHpaDeployment(context.Background(), dp, hpatest, 10*time.Second, log.GetLogger())
 
 		// Get the deployment from the fake API
 		hpa, err := c.AutoscalingV1().HorizontalPodAutoscalers("namespace1").Get(context.TODO(), "hpaName", metav1.GetOptions{})
@@ -248,7 +248,7 @@ func TestScaleHpaDeploymentHelper(t *testing.T) {
 
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		result := scaleHpaDeploymentHelper(client.AutoscalingV1().HorizontalPodAutoscalers("ns1"), "hpaName", "dp1", "ns1", 1, 3, 10*time.Second, log.GetLogger())
+		result := scaleHpaDeploymentHelper(context.Background(), client.AutoscalingV1().HorizontalPodAutoscalers("ns1"), "hpaName", "dp1", "ns1", 1, 3, 10*time.Second, log.GetLogger())
 		assert.Equal(t, tc.expectedOutput, result)
 	}
 }
@@ -318,7 +318,71 @@ func TestScaleDeploymentHelper(t *testing.T) {
 
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		result := scaleDeploymentHelper(client.AppsV1(), dep, 1, 10*time.Second, true, log.GetLogger())
+		result := scaleDeploymentHelper(context.Background(), client.AppsV1(), dep, 1, 10*time.Second, true, log.GetLogger())
 		assert.Equal(t, tc.expectedOutput, result)
 	}
 }
+
+func TestScaleDeploymentRestoresAfterCancel(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+
+	// The check context is cancelled once the first scale is done, the waits fail on cancelled contexts.
+	origFunc := podsets.WaitForDeploymentSetReady
+	defer func() {
+		podsets.WaitForDeploymentSetReady = origFunc
+	}()
+	var cancelCheck context.CancelFunc
+	waits := 0
+	podsets.WaitForDeploymentSetReady = func(ctx context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
+		waits++
+		if waits == 1 {
+			cancelCheck()
+			return true
+		}
+		return ctx.Err() == nil
+	}
+	defer clientsholder.ClearTestClientsHolder()
+
+	for _, replicas := range []int32{1, 3} {
+		waits = 0
+		dep := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "dp1", Namespace: "namespace1"},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(replicas)},
+		}
+		c := clientsholder.GetTestClientsHolder([]runtime.Object{dep})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cancelCheck = cancel
+		assert.True(t, TestScaleDeployment(ctx, dep, 10*time.Second, log.GetLogger()))
+
+		dp, err := c.K8sClient.AppsV1().Deployments("namespace1").Get(context.TODO(), "dp1", metav1.GetOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, replicas, *dp.Spec.Replicas)
+		assert.Equal(t, 2, waits)
+	}
+
+	for _, replicas := range []int32{1, 3} {
+		waits = 0
+		dep := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "dp1", Namespace: "namespace1"},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(replicas)},
+		}
+		hpatest := &v1autoscaling.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "hpaName", Namespace: "namespace1"},
+			Spec:       v1autoscaling.HorizontalPodAutoscalerSpec{MinReplicas: int32Ptr(1), MaxReplicas: 5},
+		}
+		c := clientsholder.GetTestClientsHolder([]runtime.Object{dep, hpatest})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cancelCheck = cancel
+		assert.True(t, TestScaleHpaDeployment(ctx, &provider.Deployment{Deployment: dep}, hpatest, 10*time.Second, log.GetLogger()))
+
+		hpa, err := c.K8sClient.AutoscalingV1().HorizontalPodAutoscalers("namespace1").Get(context.TODO(), "hpaName", metav1.GetOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		assert.Equal(t, 3, waits)
+	}
+}
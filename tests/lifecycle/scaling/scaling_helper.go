@@ -1,7 +1,9 @@
 package scaling
 
 import (
+	"context"
 	"strings"
+	"time"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -10,6 +12,17 @@ import (
 	scalingv1 "k8s.io/api/autoscaling/v1"
 )
 
+// restoreTimeoutMargin is added to the time to wait for a workload to be ready to bound the requests
+// putting it back after scaling it.
+const restoreTimeoutMargin = time.Minute
+
+// newRestoreContext returns the context to put a scaled workload back to its replicas and its HPA back
+// to its min and max. It is not cancelled with the check context, so that a check timing out or aborted
+// between two scales does not leave the workload scaled on the cluster.
+func newRestoreContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), timeout+restoreTimeoutMargin)
+}
+
 func GetResourceHPA(hpaList []*scalingv1.HorizontalPodAutoscaler, name, namespace, kind string) *scalingv1.HorizontalPodAutoscaler {
 	for _, hpa := range hpaList {
 		if hpa.Spec.ScaleTargetRef.Kind == kind && hpa.Spec.ScaleTargetRef.Name == name && hpa.Namespace == namespace {
@@ -37,7 +37,7 @@ import (
 	hps "k8s.io/client-go/kubernetes/typed/autoscaling/v1"
 )
 
-func TestScaleStatefulSet(statefulset *appsv1.StatefulSet, timeout time.Duration, logger *log.Logger) bool {
+func TestScaleStatefulSet(ctx context.Context, statefulset *appsv1.StatefulSet, timeout time.Duration, logger *log.Logger) bool {
 	clients := clientsholder.GetClientsHolder()
 	name, namespace := statefulset.Name, statefulset.Namespace
 	ssClients := clients.K8sClient.AppsV1().StatefulSets(namespace)
@@ -47,56 +47,42 @@ func TestScaleStatefulSet(statefulset *appsv1.StatefulSet, timeout time.Duration
 		replicas = *statefulset.Spec.Replicas
 	}
 
-	if replicas <= 1 {
-		// scale up
-		replicas++
-		logger.Debug("Scale UP statefulset to %d replicas", replicas)
-		if !scaleStatefulsetHelper(clients, ssClients, statefulset, replicas, timeout, logger) {
-			logger.Error("Cannot scale statefulset = %s:%s", namespace, name)
-			return false
-		}
-		// scale down
-		replicas--
-		logger.Debug("Scale DOWN statefulset to %d replicas", replicas)
-		if !scaleStatefulsetHelper(clients, ssClients, statefulset, replicas, timeout, logger) {
-			logger.Error("Cannot scale statefulset = %s:%s", namespace, name)
-			return false
-		}
-	} else {
-		// scale down
-		replicas--
-		logger.Debug("Scale DOWN statefulset to %d replicas", replicas)
-		if !scaleStatefulsetHelper(clients, ssClients, statefulset, replicas, timeout, logger) {
-			logger.Error("Cannot scale statefulset = %s:%s", namespace, name)
-			return false
-		} // scale up
-		replicas++
-		logger.Debug("Scale UP statefulset to %d replicas", replicas)
-		if !scaleStatefulsetHelper(clients, ssClients, statefulset, replicas, timeout, logger) {
-			logger.Error("Cannot scale statefulset = %s:%s", namespace, name)
-			return false
-		}
+	// scale up, or down when there are several replicas
+	scaledReplicas := replicas + 1
+	if replicas > 1 {
+		scaledReplicas = replicas - 1
+	}
+	logger.Debug("Scale statefulset to %d replicas", scaledReplicas)
+	scaled := scaleStatefulsetHelper(ctx, clients, ssClients, statefulset, scaledReplicas, timeout, logger)
+
+	// scale back, even if the check timed out or was aborted
+	restoreCtx, cancel := newRestoreContext(ctx, timeout)
+	defer cancel()
+	logger.Debug("Scale statefulset back to %d replicas", replicas)
+	if !scaleStatefulsetHelper(restoreCtx, clients, ssClients, statefulset, replicas, timeout, logger) || !scaled {
+		logger.Error("Cannot scale statefulset = %s:%s", namespace, name)
+		return false
 	}
 	return true
 }
 
-func scaleStatefulsetHelper(clients *clientsholder.ClientsHolder, ssClient v1.StatefulSetInterface, statefulset *appsv1.StatefulSet, replicas int32, timeout time.Duration, logger *log.Logger) bool {
+func scaleStatefulsetHelper(ctx context.Context, clients *clientsholder.ClientsHolder, ssClient v1.StatefulSetInterface, statefulset *appsv1.StatefulSet, replicas int32, timeout time.Duration, logger *log.Logger) bool {
 	name := statefulset.Name
 	namespace := statefulset.Namespace
 
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Retrieve the latest version of statefulset before attempting update
 		// RetryOnConflict uses exponential backoff to avoid exhausting the apiserver
-		ss, err := ssClient.Get(context.TODO(), name, v1machinery.GetOptions{})
+		ss, err := ssClient.Get(ctx, name, v1machinery.GetOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to get statefulset %s/%s: %w", namespace, name, err)
 		}
 		ss.Spec.Replicas = &replicas
-		_, err = clients.K8sClient.AppsV1().StatefulSets(namespace).Update(context.TODO(), ss, v1machinery.UpdateOptions{})
+		_, err = clients.K8sClient.AppsV1().StatefulSets(namespace).Update(ctx, ss, v1machinery.UpdateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to update statefulset %s/%s: %w", namespace, name, err)
 		}
-		if !podsets.WaitForStatefulSetReady(namespace, name, timeout, logger) {
+		if !podsets.WaitForStatefulSetReady(ctx, namespace, name, timeout, logger) {
 			logger.Error("Cannot update statefulset %s:%s", namespace, name)
 			return errors.New("can not update statefulset")
 		}
@@ -109,7 +95,7 @@ func scaleStatefulsetHelper(clients *clientsholder.ClientsHolder, ssClient v1.St
 	return true
 }
 
-func TestScaleHpaStatefulSet(statefulset *appsv1.StatefulSet, hpa *v1autoscaling.HorizontalPodAutoscaler, timeout time.Duration, logger *log.Logger) bool {
+func TestScaleHpaStatefulSet(ctx context.Context, statefulset *appsv1.StatefulSet, hpa *v1autoscaling.HorizontalPodAutoscaler, timeout time.Duration, logger *log.Logger) bool {
 	clients := clientsholder.GetClientsHolder()
 	hpaName := hpa.Name
 	name, namespace := statefulset.Name, statefulset.Namespace
@@ -123,56 +109,39 @@ func TestScaleHpaStatefulSet(statefulset *appsv1.StatefulSet, hpa *v1autoscaling
 		replicas = *statefulset.Spec.Replicas
 	}
 	max := hpa.Spec.MaxReplicas
-	if replicas <= 1 {
-		// scale up
-		replicas++
-		logger.Debug("Scale UP HPA %s:%s to min=%d max=%d", namespace, hpaName, replicas, replicas)
-		pass := scaleHpaStatefulSetHelper(hpscaler, hpaName, name, namespace, replicas, replicas, timeout, logger)
-		if !pass {
-			return false
-		}
-		// scale down
-		replicas--
-		logger.Debug("Scale DOWN HPA %s:%s to min=%d max=%d", namespace, hpaName, replicas, replicas)
-		pass = scaleHpaStatefulSetHelper(hpscaler, hpaName, name, namespace, replicas, replicas, timeout, logger)
-		if !pass {
-			return false
-		}
-	} else {
-		// scale down
-		replicas--
-		logger.Debug("Scale DOWN HPA %s:%s to min=%d max=%d", namespace, hpaName, replicas, replicas)
-		pass := scaleHpaStatefulSetHelper(hpscaler, hpaName, name, namespace, replicas, replicas, timeout, logger)
-		if !pass {
-			return false
-		}
-		// scale up
-		replicas++
-		logger.Debug("Scale UP HPA %s:%s to min=%d max=%d", namespace, hpaName, min, max)
-		pass = scaleHpaStatefulSetHelper(hpscaler, hpaName, name, namespace, replicas, replicas, timeout, logger)
-		if !pass {
-			return false
-		}
+	// scale up, or down when there are several replicas
+	scaledReplicas := replicas + 1
+	if replicas > 1 {
+		scaledReplicas = replicas - 1
+	}
+	logger.Debug("Scale HPA %s:%s to min=%d max=%d", namespace, hpaName, scaledReplicas, scaledReplicas)
+	pass := scaleHpaStatefulSetHelper(ctx, hpscaler, hpaName, name, namespace, scaledReplicas, scaledReplicas, timeout, logger)
+
+	// scale back and restore the hpa, even if the check timed out or was aborted
+	restoreCtx, cancel := newRestoreContext(ctx, 2*timeout)
+	defer cancel()
+	if pass {
+		logger.Debug("Scale HPA %s:%s back to min=%d max=%d", namespace, hpaName, replicas, replicas)
+		pass = scaleHpaStatefulSetHelper(restoreCtx, hpscaler, hpaName, name, namespace, replicas, replicas, timeout, logger)
 	}
 	// back the min and the max value of the hpa
 	logger.Debug("Back HPA %s:%s to min=%d max=%d", namespace, hpaName, min, max)
-	pass := scaleHpaStatefulSetHelper(hpscaler, hpaName, name, namespace, min, max, timeout, logger)
-	return pass
+	return scaleHpaStatefulSetHelper(restoreCtx, hpscaler, hpaName, name, namespace, min, max, timeout, logger) && pass
 }
 
-func scaleHpaStatefulSetHelper(hpscaler hps.HorizontalPodAutoscalerInterface, hpaName, statefulsetName, namespace string, min, max int32, timeout time.Duration, logger *log.Logger) bool {
+func scaleHpaStatefulSetHelper(ctx context.Context, hpscaler hps.HorizontalPodAutoscalerInterface, hpaName, statefulsetName, namespace string, min, max int32, timeout time.Duration, logger *log.Logger) bool {
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		hpa, err := hpscaler.Get(context.TODO(), hpaName, v1machinery.GetOptions{})
+		hpa, err := hpscaler.Get(ctx, hpaName, v1machinery.GetOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to get HPA %s in namespace %s: %w", hpaName, namespace, err)
 		}
 		hpa.Spec.MinReplicas = &min
 		hpa.Spec.MaxReplicas = max
-		_, err = hpscaler.Update(context.TODO(), hpa, v1machinery.UpdateOptions{})
+		_, err = hpscaler.Update(ctx, hpa, v1machinery.UpdateOptions{})
 		if err != nil {
 			return fmt.Errorf("failed to update HPA %s in namespace %s: %w", hpaName, namespace, err)
 		}
-		if !podsets.WaitForStatefulSetReady(namespace, statefulsetName, timeout, logger) {
+		if !podsets.WaitForStatefulSetReady(ctx, namespace, statefulsetName, timeout, logger) {
 			logger.Error("StatefulSet not ready after scale operation %s:%s", namespace, statefulsetName)
 		}
 		return nil
@@ -67,7 +67,7 @@ func TestScaleStatefulSetFunc(t *testing.T) {
 	defer func() {
 		podsets.WaitForStatefulSetReady = origFunc
 	}()
-	podsets.WaitForStatefulSetReady = func(ns, name string, timeout time.Duration, logger *log.Logger) bool {
+	podsets.WaitForStatefulSetReady = func(_ context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
 		return true
 	}
 
@@ -81,7 +81,7 @@ func TestScaleStatefulSetFunc(t *testing.T) {
 
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		TestScaleStatefulSet(tempSS, 10*time.Second, log.GetLogger())
+		TestScaleStatefulSet(context.Background(), tempSS, 10*time.Second, log.GetLogger())
 
 		ss, err := c.K8sClient.AppsV1().StatefulSets("namespace1").Get(context.TODO(), tc.statefulSetName, metav1.GetOptions{})
 		assert.Nil(t, err)
@@ -118,7 +118,7 @@ func TestScaleHpaStatefulSetFunc(t *testing.T) {
 	defer func() {
 		podsets.WaitForStatefulSetReady = origFunc
 	}()
-	podsets.WaitForStatefulSetReady = func(ns, name string, timeout time.Duration, logger *log.Logger) bool {
+	podsets.WaitForStatefulSetReady = func(_ context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
 		return true
 	}
 
@@ -155,7 +155,7 @@ func TestScaleHpaStatefulSetFunc(t *testing.T) {
 
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		TestScaleHpaStatefulSet(tempSS, hpatest, 10*time.Second, log.GetLogger())
+		TestScaleHpaStatefulSet(context.Background(), tempSS, hpatest, 10*time.Second, log.GetLogger())
 
 		hpa, err := c.AutoscalingV1().HorizontalPodAutoscalers("namespace1").Get(context.TODO(), "hpaName", metav1.GetOptions{})
 		assert.Nil(t, err)
@@ -193,7 +193,7 @@ func TestScaleStatefulsetHelper(t *testing.T) {
 	defer func() {
 		podsets.WaitForStatefulSetReady = origFunc
 	}()
-	podsets.WaitForStatefulSetReady = func(ns, name string, timeout time.Duration, logger *log.Logger) bool {
+	podsets.WaitForStatefulSetReady = func(_ context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
 		return true
 	}
 
@@ -228,7 +228,7 @@ func TestScaleStatefulsetHelper(t *testing.T) {
 
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		result := scaleStatefulsetHelper(clients, fakeClient.AppsV1().StatefulSets("ns1"), ss, 1, 10*time.Second, log.GetLogger())
+		result := scaleStatefulsetHelper(context.Background(), clients, fakeClient.AppsV1().StatefulSets("ns1"), ss, 1, 10*time.Second, log.GetLogger())
 		assert.Equal(t, tc.expectedOutput, result)
 	}
 }
@@ -263,7 +263,7 @@ func TestScaleHpaStatefulSetHelper(t *testing.T) {
 	defer func() {
 		podsets.WaitForStatefulSetReady = origFunc
 	}()
-	podsets.WaitForStatefulSetReady = func(ns, name string, timeout time.Duration, logger *log.Logger) bool {
+	podsets.WaitForStatefulSetReady = func(_ context.Context, ns, name string, timeout time.Duration, logger *log.Logger) bool {
 		return true
 	}
 
@@ -295,7 +295,7 @@ func TestScaleHpaStatefulSetHelper(t *testing.T) {
 
 		var logArchive strings.Builder
 		log.SetupLogger(&logArchive, "INFO")
-		result := scaleHpaStatefulSetHelper(client.AutoscalingV1().HorizontalPodAutoscalers("ns1"), "hpaName", "ss1", "ns1", 1, 3, 10*time.Second, log.GetLogger())
+		result := scaleHpaStatefulSetHelper(context.Background(), client.AutoscalingV1().HorizontalPodAutoscalers("ns1"), "hpaName", "ss1", "ns1", 1, 3, 10*time.Second, log.GetLogger())
 		assert.Equal(t, tc.expectedOutput, result)
 	}
 }
@@ -17,6 +17,7 @@
 package lifecycle
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -43,6 +44,10 @@ const (
 	timeoutPodSetReady         = 7 * time.Minute
 	minWorkerNodesForLifecycle = 2
 	statefulSet                = "StatefulSet"
+
+	// Scaling checks may fail when the cluster is slow to schedule the new pods.
+	scalingRetries = 1
+	scalingBackoff = 30 * time.Second
 )
 
 var (
@@ -77,6 +82,8 @@ func LoadChecks() {
 		WithSkipCheckFn(
 			testhelper.GetNoCrdsUnderTestSkipFn(&env),
 			testhelper.GetNotIntrusiveSkipFn(&env)).
+		WithIntrusive().
+		WithRetries(scalingRetries, scalingBackoff).
 		WithCheckFn(func(c *checksdb.Check) error {
 			// Note: We skip this test because 'testHighAvailability' in the lifecycle suite is already
 			// testing the replicas and antiaffinity rules that should already be in place for crd.
@@ -157,6 +164,7 @@ func LoadChecks() {
 			testhelper.GetNotEnoughWorkersSkipFn(&env, minWorkerNodesForLifecycle),
 			testhelper.GetNotIntrusiveSkipFn(&env)).
 		WithSkipCheckFn(skipIfNoPodSetsetsUnderTest).
+		WithIntrusive().
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPodsRecreation(c, &env)
 			return nil
@@ -168,6 +176,8 @@ func LoadChecks() {
 			testhelper.GetNotIntrusiveSkipFn(&env),
 			testhelper.GetNotEnoughWorkersSkipFn(&env, minWorkerNodesForLifecycle)).
 		WithSkipCheckFn(skipIfNoPodSetsetsUnderTest).
+		WithIntrusive().
+		WithRetries(scalingRetries, scalingBackoff).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testDeploymentScaling(&env, timeout, c)
 			return nil
@@ -179,6 +189,8 @@ func LoadChecks() {
 			testhelper.GetNotIntrusiveSkipFn(&env),
 			testhelper.GetNotEnoughWorkersSkipFn(&env, minWorkerNodesForLifecycle)).
 		WithSkipCheckFn(skipIfNoPodSetsetsUnderTest).
+		WithIntrusive().
+		WithRetries(scalingRetries, scalingBackoff).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testStatefulSetScaling(&env, timeout, c)
 			return nil
@@ -438,7 +450,7 @@ func testDeploymentScaling(env *provider.TestEnvironment, timeout time.Duration,
 			// if the deployment is controller by
 			// horizontal scaler, then test that scaler
 			// can scale the deployment
-			if !scaling.TestScaleHpaDeployment(deployment, hpa, timeout, check.GetLogger()) {
+			if !scaling.TestScaleHpaDeployment(check.Context(), deployment, hpa, timeout, check.GetLogger()) {
 				check.LogError("Deployment %q has failed the HPA scale test", deployment.ToString())
 				nonCompliantObjects = append(nonCompliantObjects, testhelper.NewDeploymentReportObject(deployment.Namespace, deployment.Name, "Deployment has failed the HPA scale test", false))
 			}
@@ -446,7 +458,7 @@ func testDeploymentScaling(env *provider.TestEnvironment, timeout time.Duration,
 		}
 		// if the deployment is not controller by HPA
 		// scale it directly
-		if !scaling.TestScaleDeployment(deployment.Deployment, timeout, check.GetLogger()) {
+		if !scaling.TestScaleDeployment(check.Context(), deployment.Deployment, timeout, check.GetLogger()) {
 			check.LogError("Deployment %q has failed the non-HPA scale test", deployment.ToString())
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewDeploymentReportObject(deployment.Namespace, deployment.Name, "Deployment has failed the non-HPA scale test", false))
 		} else {
@@ -466,13 +478,13 @@ func testScaleCrd(env *provider.TestEnvironment, timeout time.Duration, check *c
 		groupResourceSchema := env.ScaleCrUnderTest[i].GroupResourceSchema
 		scaleCr := env.ScaleCrUnderTest[i].Scale
 		if hpa := scaling.GetResourceHPA(env.HorizontalScaler, scaleCr.Name, scaleCr.Namespace, scaleCr.Kind); hpa != nil {
-			if !scaling.TestScaleHPACrd(&scaleCr, hpa, groupResourceSchema, timeout, check.GetLogger()) {
+			if !scaling.TestScaleHPACrd(check.Context(), &scaleCr, hpa, groupResourceSchema, timeout, check.GetLogger()) {
 				check.LogError("CR has failed the scaling test: %s", scaleCr.GetName())
 				nonCompliantObjects = append(nonCompliantObjects, testhelper.NewCrdReportObject(scaleCr.Namespace, scaleCr.Name, "cr has failed the HPA scaling test", false))
 			}
 			continue
 		}
-		if !scaling.TestScaleCrd(&scaleCr, groupResourceSchema, timeout, check.GetLogger()) {
+		if !scaling.TestScaleCrd(check.Context(), &scaleCr, groupResourceSchema, timeout, check.GetLogger()) {
 			check.LogError("CR has failed the non-HPA scale test: %s", scaleCr.GetName())
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewCrdReportObject(scaleCr.Namespace, scaleCr.Name, "CR has failed the non-HPA scale test", false))
 		} else {
@@ -516,7 +528,7 @@ func testStatefulSetScaling(env *provider.TestEnvironment, timeout time.Duration
 			// if the statefulset is controller by
 			// horizontal scaler, then test that scaler
 			// can scale the statefulset
-			if !scaling.TestScaleHpaStatefulSet(statefulSet.StatefulSet, hpa, timeout, check.GetLogger()) {
+			if !scaling.TestScaleHpaStatefulSet(check.Context(), statefulSet.StatefulSet, hpa, timeout, check.GetLogger()) {
 				check.LogError("StatefulSet has failed the scaling test: %q", statefulSet.ToString())
 				nonCompliantObjects = append(nonCompliantObjects, testhelper.NewStatefulSetReportObject(statefulSet.Namespace, statefulSet.Name, "StatefulSet has failed the HPA scaling test", false))
 			}
@@ -524,7 +536,7 @@ func testStatefulSetScaling(env *provider.TestEnvironment, timeout time.Duration
 		}
 		// if the statefulset is not controller by HPA
 		// scale it directly
-		if !scaling.TestScaleStatefulSet(statefulSet.StatefulSet, timeout, check.GetLogger()) {
+		if !scaling.TestScaleStatefulSet(check.Context(), statefulSet.StatefulSet, timeout, check.GetLogger()) {
 			check.LogError("StatefulSet has failed the scaling test: %s", statefulSet.ToString())
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewStatefulSetReportObject(statefulSet.Namespace, statefulSet.Name, "StatefulSet has failed the non-HPA scale test", false))
 		} else {
@@ -609,7 +621,7 @@ func testPodsRecreation(check *checksdb.Check, env *provider.TestEnvironment) {
 	// Before draining any node, wait until all podsets are ready. The timeout depends on the number of podsets to check.
 	// timeout = k-mins + (1min * (num-deployments + num-statefulsets))
 	allPodsetsReadyTimeout := timeoutPodSetReady + time.Minute*time.Duration(len(env.Deployments)+len(env.StatefulSets))
-	notReadyDeployments, notReadyStatefulSets := podsets.WaitForAllPodSetsReady(env, allPodsetsReadyTimeout, check.GetLogger())
+	notReadyDeployments, notReadyStatefulSets := podsets.WaitForAllPodSetsReady(check.Context(), env, allPodsetsReadyTimeout, check.GetLogger())
 	if len(notReadyDeployments) > 0 || len(notReadyStatefulSets) > 0 {
 		for _, dep := range notReadyDeployments {
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewDeploymentReportObject(dep.Namespace, dep.Name, "Deployment was not ready before draining any node.", false))
@@ -643,14 +655,14 @@ func testPodsRecreation(check *checksdb.Check, env *provider.TestEnvironment) {
 
 	for nodeName := range podsets.GetAllNodesForAllPodSets(env.Pods) {
 		defer podrecreation.CordonCleanup(nodeName, check) //nolint:gocritic // The defer in loop is intentional, calling the cleanup function once per node
-		err := podrecreation.CordonHelper(nodeName, podrecreation.Cordon)
+		err := podrecreation.CordonHelper(check.Context(), nodeName, podrecreation.Cordon)
 		if err != nil {
 			check.LogError("Error cordoning the node: %s", nodeName)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(nodeName, "Node cordoning failed", false))
 			return
 		}
 		check.LogInfo("Draining and Cordoning node %s: ", nodeName)
-		count, err := podrecreation.CountPodsWithDelete(env.Pods, nodeName, podrecreation.NoDelete)
+		count, err := podrecreation.CountPodsWithDelete(check.Context(), env.Pods, nodeName, podrecreation.NoDelete)
 		if err != nil {
 			check.LogError("Getting pods list to drain failed, err=%v", err)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(nodeName, "Getting pods list to drain failed", false))
@@ -658,14 +670,14 @@ func testPodsRecreation(check *checksdb.Check, env *provider.TestEnvironment) {
 		}
 		nodeTimeout := timeoutPodSetReady + timeoutPodRecreationPerPod*time.Duration(count)
 		check.LogDebug("Draining node: %s with timeout: %s", nodeName, nodeTimeout)
-		_, err = podrecreation.CountPodsWithDelete(env.Pods, nodeName, podrecreation.DeleteForeground)
+		_, err = podrecreation.CountPodsWithDelete(check.Context(), env.Pods, nodeName, podrecreation.DeleteForeground)
 		if err != nil {
 			check.LogError("Draining node %q failed, err=%v", nodeName, err)
 			nonCompliantObjects = append(nonCompliantObjects, testhelper.NewNodeReportObject(nodeName, "Draining node failed", false))
 			return
 		}
 
-		notReadyDeployments, notReadyStatefulSets := podsets.WaitForAllPodSetsReady(env, nodeTimeout, check.GetLogger())
+		notReadyDeployments, notReadyStatefulSets := podsets.WaitForAllPodSetsReady(check.Context(), env, nodeTimeout, check.GetLogger())
 		if len(notReadyDeployments) > 0 || len(notReadyStatefulSets) > 0 {
 			for _, dep := range notReadyDeployments {
 				check.LogError("Deployment %q not ready after draining node %q", dep.ToString(), nodeName)
@@ -678,7 +690,8 @@ func testPodsRecreation(check *checksdb.Check, env *provider.TestEnvironment) {
 			return
 		}
 
-		err = podrecreation.CordonHelper(nodeName, podrecreation.Uncordon)
+		// Uncordoned even if the check was cancelled meanwhile.
+		err = podrecreation.CordonHelper(context.Background(), nodeName, podrecreation.Uncordon)
 		if err != nil {
 			check.LogFatal("Error uncordoning the node: %s", nodeName)
 		}
@@ -17,6 +17,7 @@
 package icmp
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -129,6 +130,7 @@ func processContainerIpsPerNet(containerID *provider.Container,
 // runNetworkingTests takes a map netcommons.NetTestContext, e.g. one context per network attachment
 // and runs pings test with it. Returns a network name to a slice of bad target IPs map.
 func RunNetworkingTests( //nolint:funlen
+	ctx context.Context,
 	netsUnderTest map[string]netcommons.NetTestContext,
 	count int,
 	aIPVersion netcommons.IPVersion,
@@ -160,7 +162,7 @@ func RunNetworkingTests( //nolint:funlen
 				aIPVersion, netName,
 				netUnderTest.TesterSource.ContainerIdentifier, netUnderTest.TesterSource.IP,
 				aDestIP.ContainerIdentifier, aDestIP.IP)
-			result, err := TestPing(netUnderTest.TesterSource.ContainerIdentifier, aDestIP, count)
+			result, err := TestPing(ctx, netUnderTest.TesterSource.ContainerIdentifier, aDestIP, count)
 			logger.Debug("Ping results: %q", result)
 			logger.Info("%q ping test on network %q from ( %q  srcip: %q ) to ( %q dstip: %q ) result: %q",
 				aIPVersion, netName,
@@ -227,14 +229,14 @@ func RunNetworkingTests( //nolint:funlen
 }
 
 // TestPing Initiates a ping test between a source container and network (1 ip) and a destination container and network (1 ip)
-var TestPing = func(sourceContainerID *provider.Container, targetContainerIP netcommons.ContainerIP, count int) (results PingResults, err error) {
+var TestPing = func(ctx context.Context, sourceContainerID *provider.Container, targetContainerIP netcommons.ContainerIP, count int) (results PingResults, err error) {
 	// Specify the interface to use for the ping test (if any)
 	interfaceFlag := fmt.Sprintf("-I %s", targetContainerIP.InterfaceName)
 	if targetContainerIP.InterfaceName == "" {
 		interfaceFlag = ""
 	}
 	command := fmt.Sprintf("ping %s -c %d %s", interfaceFlag, count, targetContainerIP.IP)
-	stdout, stderr, err := crclient.ExecCommandContainerNSEnter(command, sourceContainerID)
+	stdout, stderr, err := crclient.ExecCommandContainerNSEnter(ctx, command, sourceContainerID)
 	if err != nil || stderr != "" {
 		results.outcome = testhelper.ERROR
 		return results, fmt.Errorf("ping failed with stderr:%s err:%v", stderr, err)
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/crclient"
@@ -41,6 +42,10 @@ import (
 const (
 	defaultNumPings = 5
 	nodePort        = "NodePort"
+
+	// Connectivity checks may fail because of transient network issues.
+	networkChecksRetries = 2
+	networkChecksBackoff = 10 * time.Second
 )
 
 type Port []struct {
@@ -61,6 +66,8 @@ func LoadChecks() {
 	// Default interface ICMP IPv4 test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestICMPv4ConnectivityIdentifier)).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
+		WithRetries(networkChecksRetries, networkChecksBackoff).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkConnectivity(&env, netcommons.IPv4, netcommons.DEFAULT, c)
 			return nil
@@ -69,6 +76,8 @@ func LoadChecks() {
 	// Multus interfaces ICMP IPv4 test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestICMPv4ConnectivityMultusIdentifier)).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
+		WithRetries(networkChecksRetries, networkChecksBackoff).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkConnectivity(&env, netcommons.IPv4, netcommons.MULTUS, c)
 			return nil
@@ -77,6 +86,8 @@ func LoadChecks() {
 	// Default interface ICMP IPv6 test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestICMPv6ConnectivityIdentifier)).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
+		WithRetries(networkChecksRetries, networkChecksBackoff).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkConnectivity(&env, netcommons.IPv6, netcommons.DEFAULT, c)
 			return nil
@@ -85,6 +96,8 @@ func LoadChecks() {
 	// Multus interfaces ICMP IPv6 test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestICMPv6ConnectivityMultusIdentifier)).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
+		WithRetries(networkChecksRetries, networkChecksBackoff).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testNetworkConnectivity(&env, netcommons.IPv6, netcommons.MULTUS, c)
 			return nil
@@ -93,6 +106,7 @@ func LoadChecks() {
 	// Undeclared container ports usage test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestUndeclaredContainerPortsUsage)).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testUndeclaredContainerPortsUsage(c, &env)
 			return nil
@@ -101,6 +115,7 @@ func LoadChecks() {
 	// OCP reserved ports usage test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOCPReservedPortsUsage)).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env), testhelper.GetNoPodsUnderTestSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOCPReservedPortsUsage(c, &env)
 			return nil
@@ -125,6 +140,7 @@ func LoadChecks() {
 	// Extended partner ports test case
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestReservedExtendedPartnerPorts)).
 		WithSkipCheckFn(testhelper.GetNoPodsUnderTestSkipFn(&env), testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testPartnerSpecificTCPPorts(c, &env)
 			return nil
@@ -162,6 +178,8 @@ func LoadChecks() {
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env),
 			testhelper.GetOCPVersionBelowSkipFn(&env, tlsversion.OCPTLSProfileEnforcementVersion),
 		).
+		WithRequirements(checksdb.RequireProbe, checksdb.RequireExec).
+		WithRetries(networkChecksRetries, networkChecksBackoff).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testTLSMinimumVersion(c, &env)
 			return nil
@@ -174,6 +192,7 @@ func LoadChecks() {
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env),
 			testhelper.GetNoPodsUnderTestSkipFn(&env),
 		).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testUnsecuredContainerPorts(c, &env)
 			return nil
@@ -199,7 +218,7 @@ func testUndeclaredContainerPortsUsage(check *checksdb.Check, env *provider.Test
 		}
 
 		firstPodContainer := put.Containers[0]
-		listeningPorts, err := netutil.GetListeningPorts(firstPodContainer)
+		listeningPorts, err := netutil.GetListeningPorts(check.Context(), firstPodContainer)
 		if err != nil {
 			check.LogError("Failed to get container %q listening ports, err: %v", firstPodContainer, err)
 			result.AddNonCompliantObject(
@@ -327,7 +346,7 @@ func checkPodPortTLS(check *checksdb.Check, put *provider.Pod, ch clientsholder.
 		return
 	}
 
-	listeningPorts, err := getListeningPorts(put.Containers[0])
+	listeningPorts, err := getListeningPorts(check.Context(), put.Containers[0])
 	if err != nil {
 		check.LogError("Failed to get pod %q listening ports, err: %v", put, err)
 		result.AddNonCompliantObject(
@@ -357,7 +376,7 @@ func checkPodPortTLS(check *checksdb.Check, put *provider.Pod, ch clientsholder.
 			continue
 		}
 
-		isTLS, reachable, reason := tlsversion.IsPortTLS(ch, probeCtx, podIP, port.PortNumber)
+		isTLS, reachable, reason := tlsversion.IsPortTLS(check.Context(), ch, probeCtx, podIP, port.PortNumber)
 		check.LogInfo("TLS probe %s:%d: isTLS=%v reachable=%v reason=%q",
 			podIP, port.PortNumber, isTLS, reachable, reason)
 		addPortTLSResult(check, put, result, port, isTLS, reachable, reason)
@@ -388,7 +407,7 @@ func testUnsecuredContainerPorts(check *checksdb.Check, env *provider.TestEnviro
 // testDefaultNetworkConnectivity test the connectivity between the default interfaces of containers under test
 func testNetworkConnectivity(env *provider.TestEnvironment, aIPVersion netcommons.IPVersion, aType netcommons.IFType, check *checksdb.Check) {
 	netsUnderTest := icmp.BuildNetTestContext(env.Pods, aIPVersion, aType, check.GetLogger())
-	report, skip := icmp.RunNetworkingTests(netsUnderTest, defaultNumPings, aIPVersion, check.GetLogger())
+	report, skip := icmp.RunNetworkingTests(check.Context(), netsUnderTest, defaultNumPings, aIPVersion, check.GetLogger())
 	if skip {
 		check.SetResultSkipped(fmt.Sprintf("There are no %s %s networks to test with at least 2 pods. Ensure pods under test have the required network configuration.", aIPVersion, aType))
 		return
@@ -454,7 +473,7 @@ func testReservedPortsUsageParallel(check *checksdb.Check, env *provider.TestEnv
 		}
 
 		firstContainer := put.Containers[0]
-		listeningPorts, err := netutil.GetListeningPorts(firstContainer)
+		listeningPorts, err := netutil.GetListeningPorts(check.Context(), firstContainer)
 		if err != nil {
 			check.LogError("Failed to get the listening ports on %q, err: %v", firstContainer, err)
 			result.AddNonCompliantObject(
@@ -649,7 +668,7 @@ func testNetworkAttachmentDefinitionSRIOVUsingMTU(check *checksdb.Check, sriovPo
 
 func testTLSMinimumVersion(check *checksdb.Check, env *provider.TestEnvironment) {
 	oc := clientsholder.GetClientsHolder()
-	policy := tlsversion.GetClusterTLSPolicy(oc.OcpClient, provider.IsOCPCluster())
+	policy := tlsversion.GetClusterTLSPolicy(check.Context(), oc.OcpClient, provider.IsOCPCluster())
 	check.LogInfo("Using TLS profile %q (min version: %s)", policy.ProfileType, tlsversion.TLSVersionString(policy.MinTLSVersion))
 	compliant, nonCompliant := tlsversion.CheckServiceTLSCompliance(check, env, policy)
 	check.SetResult(compliant, nonCompliant)
@@ -17,6 +17,7 @@
 package networking
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -362,7 +363,7 @@ func TestCheckPodPortTLS(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			getListeningPorts = func(*provider.Container) (map[netutil.PortInfo]bool, error) {
+			getListeningPorts = func(context.Context, *provider.Container) (map[netutil.PortInfo]bool, error) {
 				return tt.ports, tt.portsErr
 			}
 			mock := &clientsholder.MockCommand{
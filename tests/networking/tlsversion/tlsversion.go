@@ -163,12 +163,12 @@ func DefaultTLSPolicy() TLSPolicy {
 // The caller's skip function guarantees the OCP version is at or above
 // OCPTLSProfileEnforcementVersion before this function is reached.
 // See https://docs.openshift.com/container-platform/latest/security/tls-security-profiles.html
-func GetClusterTLSPolicy(ocpClient clientconfigv1.ConfigV1Interface, isOCP bool) TLSPolicy {
+func GetClusterTLSPolicy(ctx context.Context, ocpClient clientconfigv1.ConfigV1Interface, isOCP bool) TLSPolicy {
 	if !isOCP {
 		return DefaultTLSPolicy()
 	}
 
-	apiServer, err := ocpClient.APIServers().Get(context.TODO(), "cluster", metav1.GetOptions{})
+	apiServer, err := ocpClient.APIServers().Get(ctx, "cluster", metav1.GetOptions{})
 	if err != nil {
 		return DefaultTLSPolicy()
 	}
@@ -231,31 +231,31 @@ func ResolveTLSProfile(profile *configv1.TLSSecurityProfile) TLSPolicy {
 //     accept both TLS 1.2 and TLS 1.3).
 //  4. Attempt a TLS 1.2 handshake using only disallowed cipher suites to confirm the
 //     server rejects them (skipped when minimum is TLS 1.3).
-func ProbeServicePortViaExec(ch clientsholder.Command, ctx clientsholder.Context, address string, port int32, policy TLSPolicy) TLSProbeResult {
+func ProbeServicePortViaExec(goCtx context.Context, ch clientsholder.Command, ctx clientsholder.Context, address string, port int32, policy TLSPolicy) TLSProbeResult {
 	endpoint := net.JoinHostPort(address, strconv.Itoa(int(port)))
 
 	// Step 1: Confirm the server accepts the policy's minimum TLS version.
-	if result := probeExecMinVersion(ch, ctx, endpoint, policy); result != nil {
+	if result := probeExecMinVersion(goCtx, ch, ctx, endpoint, policy); result != nil {
 		return *result
 	}
 
 	// Step 2: Confirm the server rejects versions below the minimum.
 	belowVer := versionBelow(policy.MinTLSVersion)
 	if belowVer > 0 {
-		if result := probeExecVersion(ch, ctx, endpoint, belowVer, false, policy); !result.Compliant {
+		if result := probeExecVersion(goCtx, ch, ctx, endpoint, belowVer, false, policy); !result.Compliant {
 			return result
 		}
 	}
 
 	// Step 3: Confirm the server accepts all versions above the minimum up to TLS 1.3.
 	for _, aboveVer := range versionsAbove(policy.MinTLSVersion) {
-		if result := probeExecVersion(ch, ctx, endpoint, aboveVer, true, policy); !result.Compliant {
+		if result := probeExecVersion(goCtx, ch, ctx, endpoint, aboveVer, true, policy); !result.Compliant {
 			return result
 		}
 	}
 
 	// Step 4: Confirm the server rejects disallowed cipher suites.
-	if result := probeExecCipherCompliance(ch, ctx, endpoint, policy); result != nil {
+	if result := probeExecCipherCompliance(goCtx, ch, ctx, endpoint, policy); result != nil {
 		return *result
 	}
 
@@ -335,14 +335,14 @@ func isOpensslTimeout(err error) bool {
 
 // IsPortTLS probes a single TCP port via openssl to determine whether it speaks TLS.
 // It does not validate TLS version or cipher compliance — only whether TLS is present.
-func IsPortTLS(ch clientsholder.Command, ctx clientsholder.Context, address string, port int32) (isTLS, reachable bool, reason string) {
+func IsPortTLS(goCtx context.Context, ch clientsholder.Command, ctx clientsholder.Context, address string, port int32) (isTLS, reachable bool, reason string) {
 	endpoint := net.JoinHostPort(address, strconv.Itoa(int(port)))
 	cmd := fmt.Sprintf("echo | timeout 5 openssl s_client -connect %s 2>&1", endpoint)
 
 	var stdout string
 	var err error
 	for range opensslTLSProbeAttempts {
-		stdout, _, err = ch.ExecCommandContainer(ctx, cmd)
+		stdout, _, err = ch.ExecCommandContainer(goCtx, ctx, cmd)
 		if err == nil || hasOpensslOutput(stdout) || !isOpensslTimeout(err) {
 			break
 		}
@@ -474,10 +474,10 @@ func opensslHandshakeRejected(output string) bool {
 // probeExecMinVersion connects at the policy's minimum TLS version and checks
 // whether the server accepts it. Returns nil on success (server accepted the
 // minimum version), or a TLSProbeResult describing the failure.
-func probeExecMinVersion(ch clientsholder.Command, ctx clientsholder.Context, endpoint string, policy TLSPolicy) *TLSProbeResult {
+func probeExecMinVersion(goCtx context.Context, ch clientsholder.Command, ctx clientsholder.Context, endpoint string, policy TLSPolicy) *TLSProbeResult {
 	flag := opensslVersionFlag(policy.MinTLSVersion)
 	cmd := fmt.Sprintf("echo | timeout 5 openssl s_client -connect %s %s 2>&1", endpoint, flag)
-	stdout, _, err := ch.ExecCommandContainer(ctx, cmd)
+	stdout, _, err := ch.ExecCommandContainer(goCtx, ctx, cmd)
 
 	// openssl exits non-zero on handshake failure, but stdout still contains
 	// parseable output. Only treat as unreachable if both the error is set AND
@@ -579,10 +579,10 @@ func classifyExecNoMinVersion(stdout string, policy TLSPolicy) TLSProbeResult {
 // the server accepted or rejected it. When expectAccept is true (above-minimum check),
 // rejection means non-compliant. When expectAccept is false (below-minimum check),
 // acceptance means non-compliant.
-func probeExecVersion(ch clientsholder.Command, ctx clientsholder.Context, endpoint string, ver uint16, expectAccept bool, policy TLSPolicy) TLSProbeResult {
+func probeExecVersion(goCtx context.Context, ch clientsholder.Command, ctx clientsholder.Context, endpoint string, ver uint16, expectAccept bool, policy TLSPolicy) TLSProbeResult {
 	flag := opensslVersionFlag(ver)
 	cmd := fmt.Sprintf("echo | timeout 5 openssl s_client -connect %s %s 2>&1", endpoint, flag)
-	stdout, _, _ := ch.ExecCommandContainer(ctx, cmd)
+	stdout, _, _ := ch.ExecCommandContainer(goCtx, ctx, cmd)
 
 	rejected := opensslHandshakeRejected(stdout)
 	accepted := !rejected && opensslVersionNegotiated(stdout, ver)
@@ -627,7 +627,7 @@ func probeExecVersion(ch clientsholder.Command, ctx clientsholder.Context, endpo
 //
 // Returns nil if the server is compliant (rejected all disallowed ciphers),
 // or a non-compliant TLSProbeResult identifying the accepted cipher.
-func probeExecCipherCompliance(ch clientsholder.Command, ctx clientsholder.Context, endpoint string, policy TLSPolicy) *TLSProbeResult {
+func probeExecCipherCompliance(goCtx context.Context, ch clientsholder.Command, ctx clientsholder.Context, endpoint string, policy TLSPolicy) *TLSProbeResult {
 	// TLS 1.3 ciphers are mandatory and not configurable — nothing to check.
 	if policy.MinTLSVersion > tls.VersionTLS12 {
 		return nil
@@ -644,7 +644,7 @@ func probeExecCipherCompliance(ch clientsholder.Command, ctx clientsholder.Conte
 	// of the offered ciphers are in its allowed set.
 	cipherStr := strings.Join(disallowedNames, ":")
 	cmd := fmt.Sprintf("echo | timeout 5 openssl s_client -connect %s -cipher %s %s 2>&1", endpoint, cipherStr, opensslFlagTLS12)
-	stdout, _, _ := ch.ExecCommandContainer(ctx, cmd)
+	stdout, _, _ := ch.ExecCommandContainer(goCtx, ctx, cmd)
 
 	// Server rejected the disallowed ciphers — this is the expected (compliant) outcome.
 	rejected := opensslHandshakeRejected(stdout) ||
@@ -752,6 +752,7 @@ func probeServicePortViaExecPod(check *checksdb.Check, env *provider.TestEnviron
 		}
 		check.LogInfo("Using probe pod on node %q for exec probe to %s:%d", nodeName, address, port)
 		result := ProbeServicePortViaExec(
+			check.Context(),
 			clientsholder.GetClientsHolder(),
 			clientsholder.NewContext(probePod.Namespace, probePod.Name, probePod.Spec.Containers[0].Name),
 			address, port, policy,
@@ -17,6 +17,7 @@
 package tlsversion
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"strings"
@@ -73,7 +74,7 @@ func TestProbeServicePortViaExec_TLS13Enforced_ModernProfile(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 443, modernPolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 443, modernPolicy())
 	assert.True(t, result.Compliant, "expected compliant, got: %s", result.Reason)
 	assert.Equal(t, "TLS 1.3", result.NegotiatedVer)
 }
@@ -89,7 +90,7 @@ func TestProbeServicePortViaExec_AcceptsTLS12_ModernProfile(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 443, modernPolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 443, modernPolicy())
 	assert.False(t, result.Compliant, "expected non-compliant with Modern profile")
 	assert.Equal(t, "TLS 1.2", result.NegotiatedVer)
 }
@@ -112,7 +113,7 @@ func TestProbeServicePortViaExec_AcceptsTLS12_IntermediateProfile(t *testing.T)
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 443, intermediatePolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 443, intermediatePolicy())
 	assert.True(t, result.Compliant, "expected compliant with Intermediate profile, got: %s", result.Reason)
 }
 
@@ -160,7 +161,7 @@ SSL-Session:
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.217.4.83", 443, modernPolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.217.4.83", 443, modernPolicy())
 	assert.True(t, result.Compliant, "expected compliant (TLS 1.2 rejected), got: %s", result.Reason)
 	assert.Equal(t, "TLS 1.3", result.NegotiatedVer)
 }
@@ -217,7 +218,7 @@ SSL-Session:
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.217.4.83", 443, intermediatePolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.217.4.83", 443, intermediatePolicy())
 	assert.False(t, result.Compliant, "expected non-compliant: TLS 1.3-only server does not support TLS 1.2 required by Intermediate")
 	assert.True(t, result.IsTLS, "expected IsTLS=true")
 }
@@ -274,7 +275,7 @@ SSL-Session:
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.217.4.83", 443, intermediatePolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.217.4.83", 443, intermediatePolicy())
 	assert.False(t, result.Compliant, "expected non-compliant: TLS 1.3-only server does not support TLS 1.2 required by Intermediate")
 	assert.True(t, result.IsTLS, "expected IsTLS=true")
 }
@@ -290,7 +291,7 @@ func TestProbeServicePortViaExec_ExecErrorNoOutput(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 443, intermediatePolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 443, intermediatePolicy())
 	assert.True(t, result.Compliant, "expected compliant (unreachable), got: %s", result.Reason)
 	assert.False(t, result.Reachable, "expected Reachable=false")
 }
@@ -303,7 +304,7 @@ func TestProbeServicePortViaExec_ConnectionRefused(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 443, intermediatePolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 443, intermediatePolicy())
 	assert.True(t, result.Compliant, "expected compliant (unreachable), got: %s", result.Reason)
 	assert.False(t, result.Reachable, "expected Reachable=false")
 }
@@ -316,7 +317,7 @@ func TestProbeServicePortViaExec_NonTLS(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 8080, intermediatePolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 8080, intermediatePolicy())
 	assert.True(t, result.Compliant, "expected compliant (non-TLS informational), got: %s", result.Reason)
 	assert.False(t, result.IsTLS, "expected IsTLS=false for non-TLS service")
 }
@@ -369,7 +370,7 @@ SSL-Session:
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.96.200.200", 8080, intermediatePolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.96.200.200", 8080, intermediatePolicy())
 	assert.True(t, result.Compliant, "expected compliant (non-TLS service), got: %s", result.Reason)
 	assert.False(t, result.IsTLS, "expected IsTLS=false for plain HTTP service")
 	assert.True(t, result.Reachable, "expected Reachable=true (connection established)")
@@ -474,7 +475,7 @@ func TestDefaultTLSPolicy(t *testing.T) {
 }
 
 func TestGetClusterTLSPolicy_NonOCP(t *testing.T) {
-	policy := GetClusterTLSPolicy(nil, false)
+	policy := GetClusterTLSPolicy(context.Background(), nil, false)
 	assert.Equal(t, "Intermediate", policy.ProfileType)
 	assert.Equal(t, uint16(tls.VersionTLS12), policy.MinTLSVersion)
 }
@@ -486,7 +487,7 @@ func TestIsPortTLS_TLSServer(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.True(t, isTLS, "expected TLS, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "TLS negotiated")
@@ -499,7 +500,7 @@ func TestIsPortTLS_OpenSSL3TLS13Format(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 8443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 8443)
 	assert.True(t, isTLS, "expected TLS from OpenSSL 3 TLS 1.3 output, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "TLS negotiated")
@@ -514,7 +515,7 @@ func TestIsPortTLS_PlaintextService(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 8080)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 8080)
 	assert.False(t, isTLS, "expected plaintext, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "plaintext")
@@ -528,7 +529,7 @@ func TestIsPortTLS_ConnectionRefused(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 9999)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 9999)
 	assert.False(t, isTLS)
 	assert.False(t, reachable)
 	assert.Contains(t, reason, "unreachable")
@@ -542,7 +543,7 @@ func TestIsPortTLS_TLSHandshakeAlert(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.True(t, isTLS, "TLS alert means genuine TLS server, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "TLS server detected")
@@ -556,7 +557,7 @@ func TestIsPortTLS_ExecFailed(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.False(t, isTLS)
 	assert.False(t, reachable)
 	assert.Contains(t, reason, "exec probe failed")
@@ -570,7 +571,7 @@ func TestIsPortTLS_AlertProtocolVersion(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.True(t, isTLS, "protocol version alert means TLS server, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "TLS server detected")
@@ -584,7 +585,7 @@ func TestIsPortTLS_HandshakeFailureWithoutAlertPrefix(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.True(t, isTLS, "handshake failure means TLS server, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "TLS server detected")
@@ -597,7 +598,7 @@ func TestIsPortTLS_CipherIs0000(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.False(t, isTLS, "cipher 0000 means rejected, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "plaintext")
@@ -610,7 +611,7 @@ func TestIsPortTLS_CipherNONEViaExtraction(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.False(t, isTLS, "cipher (NONE) via extraction means no TLS, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "plaintext")
@@ -623,7 +624,7 @@ func TestIsPortTLS_NoRecognizableOutput(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.False(t, isTLS)
 	assert.False(t, reachable)
 	assert.Contains(t, reason, "no recognizable openssl output")
@@ -691,7 +692,7 @@ func TestProbeServicePortViaExec_IntermediateRejectsTLS13Only(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 443, intermediatePolicy())
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 443, intermediatePolicy())
 	assert.False(t, result.Compliant, "expected non-compliant: TLS 1.2-only server doesn't support TLS 1.3 required by Intermediate")
 	assert.True(t, result.IsTLS)
 	assert.Contains(t, result.Reason, "rejected TLS 1.3")
@@ -719,7 +720,7 @@ func TestProbeServicePortViaExec_OldProfileAllVersions(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 443, oldPolicy)
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 443, oldPolicy)
 	assert.True(t, result.Compliant, "expected compliant: server accepts all TLS versions, got: %s", result.Reason)
 }
 
@@ -736,7 +737,7 @@ func TestProbeServicePortViaExec_OldProfileRejectsTLS11(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := ProbeServicePortViaExec(mock, ctx, "10.0.0.1", 443, oldPolicy)
+	result := ProbeServicePortViaExec(context.Background(), mock, ctx, "10.0.0.1", 443, oldPolicy)
 	assert.False(t, result.Compliant, "expected non-compliant: server rejects TLS 1.1 under Old profile")
 	assert.Contains(t, result.Reason, "rejected TLS 1.1")
 }
@@ -749,7 +750,7 @@ func TestProbeExecVersion_BelowMinimum_ServerRejects(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := probeExecVersion(mock, ctx, "10.0.0.1:443", tls.VersionTLS11, false, intermediatePolicy())
+	result := probeExecVersion(context.Background(), mock, ctx, "10.0.0.1:443", tls.VersionTLS11, false, intermediatePolicy())
 	assert.True(t, result.Compliant, "expected compliant: server correctly rejected below-minimum version")
 }
 
@@ -761,7 +762,7 @@ func TestProbeExecVersion_BelowMinimum_ServerAccepts(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := probeExecVersion(mock, ctx, "10.0.0.1:443", tls.VersionTLS11, false, intermediatePolicy())
+	result := probeExecVersion(context.Background(), mock, ctx, "10.0.0.1:443", tls.VersionTLS11, false, intermediatePolicy())
 	assert.False(t, result.Compliant, "expected non-compliant: server accepted below-minimum TLS 1.1")
 	assert.Contains(t, result.Reason, "accepts TLS 1.1")
 }
@@ -774,7 +775,7 @@ func TestProbeExecVersion_AboveMinimum_ServerAccepts(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := probeExecVersion(mock, ctx, "10.0.0.1:443", tls.VersionTLS13, true, intermediatePolicy())
+	result := probeExecVersion(context.Background(), mock, ctx, "10.0.0.1:443", tls.VersionTLS13, true, intermediatePolicy())
 	assert.True(t, result.Compliant, "expected compliant: server accepts TLS 1.3")
 }
 
@@ -786,7 +787,7 @@ func TestProbeExecVersion_AboveMinimum_ServerRejects(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := probeExecVersion(mock, ctx, "10.0.0.1:443", tls.VersionTLS13, true, intermediatePolicy())
+	result := probeExecVersion(context.Background(), mock, ctx, "10.0.0.1:443", tls.VersionTLS13, true, intermediatePolicy())
 	assert.False(t, result.Compliant, "expected non-compliant: server rejected TLS 1.3 required by profile")
 	assert.Contains(t, result.Reason, "rejected TLS 1.3")
 }
@@ -1022,7 +1023,7 @@ func TestIsPortTLS_ConnectedNoCipherLine(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 	assert.False(t, isTLS, "unknown cipher should fall through to plaintext, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "plaintext")
@@ -1037,7 +1038,7 @@ func TestIsPortTLS_ExecErrorWithSSLInStdout(t *testing.T) {
 			},
 		)
 		ctx := clientsholder.NewContext("ns", "pod", "container")
-		isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+		isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 		assert.True(t, isTLS, "SSL handshake alert is a TLS server even without CONNECTED, reason: %s", reason)
 		assert.True(t, reachable)
 		assert.NotContains(t, reason, "exec probe failed")
@@ -1052,7 +1053,7 @@ func TestIsPortTLS_ExecErrorWithSSLInStdout(t *testing.T) {
 			},
 		)
 		ctx := clientsholder.NewContext("ns", "pod", "container")
-		isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 443)
+		isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 443)
 		assert.True(t, isTLS, "expected TLS despite exec error, reason: %s", reason)
 		assert.True(t, reachable)
 		assert.Contains(t, reason, "TLS negotiated")
@@ -1066,7 +1067,7 @@ func TestIsPortTLS_IPv6Address(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "2001:db8::1", 443)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "2001:db8::1", 443)
 	assert.True(t, isTLS, "expected TLS over IPv6, reason: %s", reason)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "TLS negotiated")
@@ -1117,7 +1118,7 @@ func TestIsPortTLS_TimeoutThenPlaintext(t *testing.T) {
 	}
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 8080)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 8080)
 	assert.False(t, isTLS)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "plaintext")
@@ -1132,7 +1133,7 @@ func TestIsPortTLS_TimeoutExhausted(t *testing.T) {
 	}
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 8080)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 8080)
 	assert.False(t, isTLS)
 	assert.False(t, reachable)
 	assert.Contains(t, reason, "exec probe failed")
@@ -1147,7 +1148,7 @@ func TestIsPortTLS_TimeoutNotRetriedWhenConnected(t *testing.T) {
 	}
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 8080)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 8080)
 	assert.False(t, isTLS)
 	assert.True(t, reachable)
 	assert.Contains(t, reason, "plaintext")
@@ -1162,7 +1163,7 @@ func TestIsPortTLS_NonTimeoutExecNotRetried(t *testing.T) {
 	}
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	isTLS, reachable, reason := IsPortTLS(mock, ctx, "10.0.0.1", 8080)
+	isTLS, reachable, reason := IsPortTLS(context.Background(), mock, ctx, "10.0.0.1", 8080)
 	assert.False(t, isTLS)
 	assert.False(t, reachable)
 	assert.Contains(t, reason, "exec probe failed")
@@ -1260,7 +1261,7 @@ func TestProbeExecCipherCompliance_ServerAcceptsDisallowed(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock := newMockCommand(mockPattern{key: "-cipher", stdout: tt.stdout})
 			ctx := clientsholder.NewContext("ns", "pod", "container")
-			result := probeExecCipherCompliance(mock, ctx, "10.0.0.1:443", intermediatePolicy())
+			result := probeExecCipherCompliance(context.Background(), mock, ctx, "10.0.0.1:443", intermediatePolicy())
 			assert.NotNil(t, result, "expected non-nil result for accepted disallowed cipher")
 			assert.False(t, result.Compliant)
 			assert.Contains(t, result.Reason, "DES-CBC3-SHA")
@@ -1271,7 +1272,7 @@ func TestProbeExecCipherCompliance_ServerAcceptsDisallowed(t *testing.T) {
 func TestProbeExecCipherCompliance_TLS13SkipsCipherCheck(t *testing.T) {
 	mock := newMockCommand()
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := probeExecCipherCompliance(mock, ctx, "10.0.0.1:443", modernPolicy())
+	result := probeExecCipherCompliance(context.Background(), mock, ctx, "10.0.0.1:443", modernPolicy())
 	assert.Nil(t, result, "Modern profile (TLS 1.3) should skip cipher check")
 	assert.Equal(t, 0, mock.CallCount())
 }
@@ -1280,7 +1281,7 @@ func TestProbeExecCipherCompliance_OldProfileSkips(t *testing.T) {
 	mock := newMockCommand()
 	ctx := clientsholder.NewContext("ns", "pod", "container")
 	oldPolicy := ResolveTLSProfile(&configv1.TLSSecurityProfile{Type: configv1.TLSProfileOldType})
-	result := probeExecCipherCompliance(mock, ctx, "10.0.0.1:443", oldPolicy)
+	result := probeExecCipherCompliance(context.Background(), mock, ctx, "10.0.0.1:443", oldPolicy)
 	assert.Nil(t, result, "Old profile has no disallowed ciphers to probe")
 	assert.Equal(t, 0, mock.CallCount())
 }
@@ -1292,7 +1293,7 @@ func TestProbeExecCipherCompliance_NoNegotiatedCipher(t *testing.T) {
 		},
 	)
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := probeExecCipherCompliance(mock, ctx, "10.0.0.1:443", intermediatePolicy())
+	result := probeExecCipherCompliance(context.Background(), mock, ctx, "10.0.0.1:443", intermediatePolicy())
 	assert.Nil(t, result, "expected nil when handshake was not rejected and no cipher was negotiated")
 	assert.Equal(t, 1, mock.CallCount())
 }
@@ -1305,7 +1306,7 @@ func TestProbeExecCipherCompliance_ServerRejectsDisallowed(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := probeExecCipherCompliance(mock, ctx, "10.0.0.1:443", intermediatePolicy())
+	result := probeExecCipherCompliance(context.Background(), mock, ctx, "10.0.0.1:443", intermediatePolicy())
 	assert.Nil(t, result, "expected nil (compliant) when server rejects disallowed ciphers")
 }
 
@@ -1318,7 +1319,7 @@ func TestProbeExecMinVersion_ServerNegotiatesTLS13WhenMinIs12(t *testing.T) {
 	)
 
 	ctx := clientsholder.NewContext("ns", "pod", "container")
-	result := probeExecMinVersion(mock, ctx, "10.0.0.1:443", intermediatePolicy())
+	result := probeExecMinVersion(context.Background(), mock, ctx, "10.0.0.1:443", intermediatePolicy())
 	assert.Nil(t, result, "expected nil (success) when server negotiates TLS 1.3 for Intermediate profile")
 }
 
@@ -0,0 +1,284 @@
+// Copyright (C) 2026 Red Hat, Inc.
+
+// Package apiusage profiles the Kubernetes API usage of the workload service accounts, from the
+// APIRequestCount objects of OpenShift and from their RBAC permissions, to find the controllers
+// likely to overload the API server.
+package apiusage
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	apiserv1 "github.com/openshift/api/apiserver/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	// MaxRequestsPerHour is the request rate of a service account over which it's flagged,
+	// 10 requests per second.
+	MaxRequestsPerHour = 36000
+	// MaxResourceVerbRequestsPerHour is the request rate of a service account to a verb of a
+	// resource over which it's flagged, 5 requests per second.
+	MaxResourceVerbRequestsPerHour = 18000
+	// MinPollingListsPerHour is the rate of the LIST requests to a resource that is never watched
+	// from which the service account is flagged as polling it, a request every 10 seconds.
+	MinPollingListsPerHour = 360
+	// MaxWritesPerHour is the rate of the update and patch requests to a resource over which the
+	// service account is flagged as having an update loop, a request per second.
+	MaxWritesPerHour = 3600
+
+	serviceAccountUserPrefix = "system:serviceaccount:"
+)
+
+// Reasons of the findings.
+const (
+	ReasonHighRequestRate         = "high request rate"
+	ReasonHighResourceRequestRate = "high request rate to a resource"
+	ReasonListPolling             = "LIST requests without WATCH"
+	ReasonClusterWideWatch        = "cluster-wide watch of a large resource"
+	ReasonUpdateLoop              = "write-heavy update loop"
+)
+
+// LargeResources are the core resources that usually have many objects in a cluster, so watching
+// or listing them in every namespace is expensive for the API server.
+var LargeResources = []string{"pods", "secrets", "configmaps", "events"}
+
+// ServiceAccountUserName returns the API user name of the service account.
+func ServiceAccountUserName(namespace, name string) string {
+	return serviceAccountUserPrefix + namespace + ":" + name
+}
+
+// resourceName returns the resource of an APIRequestCount name, that is the resource, version and
+// group, e.g. deployments.v1.apps.
+func resourceName(apiRequestCountName string) string {
+	resource, versionAndGroup, _ := strings.Cut(apiRequestCountName, ".")
+	_, group, found := strings.Cut(versionAndGroup, ".")
+	if !found {
+		return resource
+	}
+
+	return resource + "." + group
+}
+
+// Profile is the API usage of a service account.
+type Profile struct {
+	// ServiceAccount is the namespace and name of the service account.
+	ServiceAccount string
+	// Hours is the number of hours of the last 24 hours in which the service account sent requests.
+	Hours int
+	// Requests is the number of requests by resource and verb.
+	Requests map[string]map[string]int64
+	// ClusterWideWatches are the large resources the service account can watch in every namespace.
+	ClusterWideWatches []string
+	// HasRequestData is whether the requests were counted, false when the cluster has no
+	// APIRequestCounts, e.g. on vanilla Kubernetes.
+	HasRequestData bool
+
+	hours map[int]struct{}
+}
+
+// NewProfile returns the profile of the service account with the given user name, without requests.
+func NewProfile(serviceAccountUserName string) *Profile {
+	return &Profile{
+		ServiceAccount: strings.Replace(strings.TrimPrefix(serviceAccountUserName, serviceAccountUserPrefix), ":", "/", 1),
+		Requests:       map[string]map[string]int64{},
+		hours:          map[int]struct{}{},
+	}
+}
+
+// Finding is a usage of the API of a service account likely to overload the API server.
+type Finding struct {
+	ServiceAccount string
+	Resource       string
+	Verb           string
+	Reason         string
+	// RequestsPerHour is the request rate of the finding, zero if it's found from the RBAC
+	// permissions.
+	RequestsPerHour float64
+}
+
+func (f *Finding) String() string {
+	target := f.Resource
+	if f.Verb != "" {
+		target = f.Verb + " " + f.Resource
+	}
+	if target == "" {
+		return fmt.Sprintf("%s: %.0f requests per hour", f.Reason, f.RequestsPerHour)
+	}
+	if f.RequestsPerHour == 0 {
+		return fmt.Sprintf("%s: %s", f.Reason, target)
+	}
+
+	return fmt.Sprintf("%s: %s, %.0f requests per hour", f.Reason, target, f.RequestsPerHour)
+}
+
+// NewProfiles returns the API usage profiles of the service accounts with the given user names,
+// from the requests of the last 24 hours in the APIRequestCount objects.
+func NewProfiles(apiRequestCounts []apiserv1.APIRequestCount, serviceAccountUserNames []string) map[string]*Profile {
+	profiles := map[string]*Profile{}
+	for _, userName := range serviceAccountUserNames {
+		profiles[userName] = NewProfile(userName)
+		profiles[userName].HasRequestData = true
+	}
+
+	for i := range apiRequestCounts {
+		resource := resourceName(apiRequestCounts[i].Name)
+		for hour, last24h := range apiRequestCounts[i].Status.Last24h {
+			for _, byNode := range last24h.ByNode {
+				for _, byUser := range byNode.ByUser {
+					profile, found := profiles[byUser.UserName]
+					if !found || byUser.RequestCount == 0 {
+						continue
+					}
+
+					profile.hours[hour] = struct{}{}
+					if profile.Requests[resource] == nil {
+						profile.Requests[resource] = map[string]int64{}
+					}
+					for _, byVerb := range byUser.ByVerb {
+						profile.Requests[resource][byVerb.Verb] += byVerb.RequestCount
+					}
+				}
+			}
+		}
+	}
+
+	for _, profile := range profiles {
+		profile.Hours = len(profile.hours)
+	}
+
+	return profiles
+}
+
+// perHour returns the rate of the requests over the hours the service account sent requests, so
+// that workloads deployed less than 24 hours ago are not underestimated.
+func (p *Profile) perHour(requests int64) float64 {
+	if p.Hours == 0 {
+		return 0
+	}
+
+	return float64(requests) / float64(p.Hours)
+}
+
+// RequestsPerHour returns the request rate of the service account.
+func (p *Profile) RequestsPerHour() float64 {
+	total := int64(0)
+	for _, verbs := range p.Requests {
+		for _, requests := range verbs {
+			total += requests
+		}
+	}
+
+	return p.perHour(total)
+}
+
+// Rates returns the request rates to every resource and verb, e.g. "list pods: 12/h", sorted.
+func (p *Profile) Rates() []string {
+	rates := []string{}
+	for resource, verbs := range p.Requests {
+		for verb, requests := range verbs {
+			rates = append(rates, fmt.Sprintf("%s %s: %.0f/h", verb, resource, p.perHour(requests)))
+		}
+	}
+
+	sort.Strings(rates)
+	return rates
+}
+
+// Findings returns the usages of the API of the service account likely to overload the API server:
+// high request rates, resources that are listed periodically instead of watched, large resources
+// that are watched in every namespace, and resources that are updated in a loop, e.g. the status
+// of custom resources updated on every reconciliation.
+func (p *Profile) Findings() []Finding {
+	findings := []Finding{}
+
+	if rate := p.RequestsPerHour(); rate > MaxRequestsPerHour {
+		findings = append(findings, Finding{ServiceAccount: p.ServiceAccount, Reason: ReasonHighRequestRate, RequestsPerHour: rate})
+	}
+
+	resources := make([]string, 0, len(p.Requests))
+	for resource := range p.Requests {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	for _, resource := range resources {
+		verbs := p.Requests[resource]
+
+		verbNames := make([]string, 0, len(verbs))
+		for verb := range verbs {
+			verbNames = append(verbNames, verb)
+		}
+		sort.Strings(verbNames)
+		for _, verb := range verbNames {
+			if rate := p.perHour(verbs[verb]); rate > MaxResourceVerbRequestsPerHour {
+				findings = append(findings, Finding{ServiceAccount: p.ServiceAccount, Resource: resource, Verb: verb, Reason: ReasonHighResourceRequestRate, RequestsPerHour: rate})
+			}
+		}
+
+		// The APIRequestCounts have no request parameters, so the LIST requests can't be told
+		// apart by their limit: the ones of a resource that is never watched are polling it.
+		if rate := p.perHour(verbs["list"]); verbs["watch"] == 0 && rate >= MinPollingListsPerHour {
+			findings = append(findings, Finding{ServiceAccount: p.ServiceAccount, Resource: resource, Verb: "list", Reason: ReasonListPolling, RequestsPerHour: rate})
+		}
+
+		if rate := p.perHour(verbs["update"] + verbs["patch"]); rate > MaxWritesPerHour {
+			findings = append(findings, Finding{ServiceAccount: p.ServiceAccount, Resource: resource, Verb: "update", Reason: ReasonUpdateLoop, RequestsPerHour: rate})
+		}
+	}
+
+	// The APIRequestCounts don't tell the namespace of the requests either, so the watches of large
+	// resources the service account can watch in every namespace are flagged. Without request data,
+	// all those permissions are, as the informers of the controllers usually use them.
+	for _, resource := range p.ClusterWideWatches {
+		if p.HasRequestData && p.Requests[resource]["watch"] == 0 {
+			continue
+		}
+		findings = append(findings, Finding{ServiceAccount: p.ServiceAccount, Resource: resource, Verb: "watch", Reason: ReasonClusterWideWatch, RequestsPerHour: p.perHour(p.Requests[resource]["watch"])})
+	}
+
+	return findings
+}
+
+func ruleAllowsWatch(rule *rbacv1.PolicyRule, resource string) bool {
+	return (slices.Contains(rule.APIGroups, "") || slices.Contains(rule.APIGroups, rbacv1.APIGroupAll)) &&
+		(slices.Contains(rule.Resources, resource) || slices.Contains(rule.Resources, rbacv1.ResourceAll)) &&
+		(slices.Contains(rule.Verbs, "watch") || slices.Contains(rule.Verbs, rbacv1.VerbAll)) &&
+		len(rule.ResourceNames) == 0
+}
+
+// ClusterWideWatches returns the large resources the service account can watch in every
+// namespace, granted by the cluster roles bound to it with cluster role bindings.
+func ClusterWideWatches(namespace, name string, clusterRoleBindings []rbacv1.ClusterRoleBinding, clusterRoles map[string]*rbacv1.ClusterRole) []string {
+	resources := []string{}
+	for i := range clusterRoleBindings {
+		binding := &clusterRoleBindings[i]
+		if !slices.ContainsFunc(binding.Subjects, func(s rbacv1.Subject) bool {
+			return s.Kind == rbacv1.ServiceAccountKind && s.Namespace == namespace && s.Name == name
+		}) {
+			continue
+		}
+
+		role, found := clusterRoles[binding.RoleRef.Name]
+		if binding.RoleRef.Kind != "ClusterRole" || !found {
+			continue
+		}
+
+		for _, resource := range LargeResources {
+			if slices.Contains(resources, resource) {
+				continue
+			}
+			for j := range role.Rules {
+				if ruleAllowsWatch(&role.Rules[j], resource) {
+					resources = append(resources, resource)
+					break
+				}
+			}
+		}
+	}
+
+	sort.Strings(resources)
+	return resources
+}
@@ -0,0 +1,121 @@
+package apiusage
+
+import (
+	"testing"
+
+	apiserv1 "github.com/openshift/api/apiserver/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testUserName = "system:serviceaccount:tnf:controller"
+
+// newTestAPIRequestCount returns the APIRequestCount of the resource with the requests of the user
+// by verb in each hour, the same number every hour.
+func newTestAPIRequestCount(name, userName string, hours int, requestsByVerb map[string]int64) apiserv1.APIRequestCount {
+	byVerb := []apiserv1.PerVerbAPIRequestCount{}
+	total := int64(0)
+	for verb, requests := range requestsByVerb {
+		byVerb = append(byVerb, apiserv1.PerVerbAPIRequestCount{Verb: verb, RequestCount: requests})
+		total += requests
+	}
+
+	apiRequestCount := apiserv1.APIRequestCount{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for range hours {
+		apiRequestCount.Status.Last24h = append(apiRequestCount.Status.Last24h, apiserv1.PerResourceAPIRequestLog{
+			ByNode: []apiserv1.PerNodeAPIRequestLog{{
+				NodeName: "master-0",
+				ByUser:   []apiserv1.PerUserAPIRequestCount{{UserName: userName, RequestCount: total, ByVerb: byVerb}},
+			}},
+		})
+	}
+
+	return apiRequestCount
+}
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "pods", resourceName("pods.v1"))
+	assert.Equal(t, "deployments.apps", resourceName("deployments.v1.apps"))
+	assert.Equal(t, "routes.route.openshift.io", resourceName("routes.v1.route.openshift.io"))
+}
+
+func TestNewProfiles(t *testing.T) {
+	apiRequestCounts := []apiserv1.APIRequestCount{
+		newTestAPIRequestCount("pods.v1", testUserName, 2, map[string]int64{"list": 2, "watch": 4}),
+		newTestAPIRequestCount("deployments.v1.apps", testUserName, 1, map[string]int64{"get": 10}),
+		newTestAPIRequestCount("pods.v1", "system:serviceaccount:other:controller", 24, map[string]int64{"list": 1000}),
+	}
+
+	profiles := NewProfiles(apiRequestCounts, []string{testUserName, "system:serviceaccount:tnf:idle"})
+	require.Len(t, profiles, 2)
+
+	profile := profiles[testUserName]
+	assert.Equal(t, "tnf/controller", profile.ServiceAccount)
+	assert.True(t, profile.HasRequestData)
+	assert.Equal(t, 2, profile.Hours)
+	assert.Equal(t, map[string]map[string]int64{"pods": {"list": 4, "watch": 8}, "deployments.apps": {"get": 10}}, profile.Requests)
+	assert.InDelta(t, 11, profile.RequestsPerHour(), 0.01)
+	assert.Equal(t, []string{"get deployments.apps: 5/h", "list pods: 2/h", "watch pods: 4/h"}, profile.Rates())
+	assert.Empty(t, profile.Findings())
+
+	idle := profiles["system:serviceaccount:tnf:idle"]
+	assert.Equal(t, 0, idle.Hours)
+	assert.Zero(t, idle.RequestsPerHour())
+}
+
+func TestProfileFindings(t *testing.T) {
+	apiRequestCounts := []apiserv1.APIRequestCount{
+		// Polling the config maps every 5 seconds.
+		newTestAPIRequestCount("configmaps.v1", testUserName, 4, map[string]int64{"list": 720}),
+		// Updating the status of its custom resources 2 times per second.
+		newTestAPIRequestCount("widgets.v1.example.com", testUserName, 4, map[string]int64{"get": 3600, "update": 7200}),
+		// Watching the pods, that it can watch in every namespace.
+		newTestAPIRequestCount("pods.v1", testUserName, 4, map[string]int64{"list": 1, "watch": 30}),
+		// Getting the secrets 20 times per second.
+		newTestAPIRequestCount("secrets.v1", testUserName, 4, map[string]int64{"get": 72000}),
+	}
+
+	profile := NewProfiles(apiRequestCounts, []string{testUserName})[testUserName]
+	profile.ClusterWideWatches = []string{"pods", "secrets"}
+
+	findings := profile.Findings()
+	assert.Equal(t, []Finding{
+		{ServiceAccount: "tnf/controller", Reason: ReasonHighRequestRate, RequestsPerHour: 83551},
+		{ServiceAccount: "tnf/controller", Resource: "configmaps", Verb: "list", Reason: ReasonListPolling, RequestsPerHour: 720},
+		{ServiceAccount: "tnf/controller", Resource: "secrets", Verb: "get", Reason: ReasonHighResourceRequestRate, RequestsPerHour: 72000},
+		{ServiceAccount: "tnf/controller", Resource: "widgets.example.com", Verb: "update", Reason: ReasonUpdateLoop, RequestsPerHour: 7200},
+		// The secrets are not watched.
+		{ServiceAccount: "tnf/controller", Resource: "pods", Verb: "watch", Reason: ReasonClusterWideWatch, RequestsPerHour: 30},
+	}, findings)
+	assert.Equal(t, "LIST requests without WATCH: list configmaps, 720 requests per hour", findings[1].String())
+
+	// Without request data, the cluster-wide watch permissions are flagged.
+	profile = NewProfile(testUserName)
+	profile.ClusterWideWatches = []string{"secrets"}
+	findings = profile.Findings()
+	assert.Equal(t, []Finding{{ServiceAccount: "tnf/controller", Resource: "secrets", Verb: "watch", Reason: ReasonClusterWideWatch}}, findings)
+	assert.Equal(t, "cluster-wide watch of a large resource: watch secrets", findings[0].String())
+}
+
+func TestClusterWideWatches(t *testing.T) {
+	clusterRoles := map[string]*rbacv1.ClusterRole{
+		"pod-reader": {Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list", "watch"}}}},
+		"admin":      {Rules: []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}}},
+		"secret-getter": {Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+			{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"watch"}, ResourceNames: []string{"settings"}},
+		}},
+	}
+	subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "tnf", Name: "controller"}
+	clusterRoleBindings := []rbacv1.ClusterRoleBinding{
+		{Subjects: []rbacv1.Subject{subject}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "pod-reader"}},
+		{Subjects: []rbacv1.Subject{subject}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "secret-getter"}},
+		{Subjects: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Namespace: "other", Name: "controller"}}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"}},
+	}
+
+	assert.Equal(t, []string{"pods"}, ClusterWideWatches("tnf", "controller", clusterRoleBindings, clusterRoles))
+	assert.Equal(t, []string{"configmaps", "events", "pods", "secrets"}, ClusterWideWatches("other", "controller", clusterRoleBindings, clusterRoles))
+	assert.Empty(t, ClusterWideWatches("tnf", "idle", clusterRoleBindings, clusterRoles))
+}
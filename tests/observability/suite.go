@@ -26,6 +26,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/common"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
+	"github.com/redhat-best-practices-for-k8s/certsuite/tests/observability/apiusage"
 	pdbv1 "github.com/redhat-best-practices-for-k8s/certsuite/tests/observability/pdb"
 
 	apiserv1 "github.com/openshift/api/apiserver/v1"
@@ -35,6 +36,7 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/testhelper"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -81,6 +83,12 @@ func LoadChecks() {
 			testAPICompatibilityWithNextOCPRelease(c, &env)
 			return nil
 		}))
+
+	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestAPIUsageFootprintIdentifier)).
+		WithSkipCheckFn(testhelper.GetOfflineClusterSkipFn(), testhelper.GetNoServiceAccountsUnderTestSkipFn(&env)).
+		WithCheckFn(func(c *checksdb.Check) error {
+			return testAPIUsageFootprint(c, &env)
+		}))
 }
 
 // containerHasLoggingOutput helper function to get the last line of logging output from
@@ -436,3 +444,83 @@ func testAPICompatibilityWithNextOCPRelease(check *checksdb.Check, env *provider
 	// Add test results
 	check.SetResult(compliantObjects, nonCompliantObjects)
 }
+
+// getServiceAccountsAPIUsage returns the API usage profiles of the service accounts under test, by
+// their user name, from the APIRequestCounts on OpenShift, and without requests elsewhere.
+func getServiceAccountsAPIUsage(check *checksdb.Check, env *provider.TestEnvironment) (map[string]*apiusage.Profile, error) {
+	userNames := []string{}
+	for _, sa := range env.ServiceAccounts {
+		userNames = append(userNames, apiusage.ServiceAccountUserName(sa.Namespace, sa.Name))
+	}
+
+	if !provider.IsOCPCluster() {
+		check.LogInfo("The Kubernetes distribution is not OpenShift, it has no APIRequestCounts. Only the service accounts permissions are checked.")
+		profiles := map[string]*apiusage.Profile{}
+		for _, userName := range userNames {
+			profiles[userName] = apiusage.NewProfile(userName)
+		}
+		return profiles, nil
+	}
+
+	oc := clientsholder.GetClientsHolder()
+	apiRequestCounts, err := oc.ApiserverClient.ApiserverV1().APIRequestCounts().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the APIRequestCount objects: %w", err)
+	}
+
+	return apiusage.NewProfiles(apiRequestCounts.Items, userNames), nil
+}
+
+// testAPIUsageFootprint profiles the API usage of the service accounts under test, flagging the
+// ones likely to overload the API server.
+func testAPIUsageFootprint(check *checksdb.Check, env *provider.TestEnvironment) error {
+	profiles, err := getServiceAccountsAPIUsage(check, env)
+	if err != nil {
+		return fmt.Errorf("could not get the API usage of the service accounts: %w", err)
+	}
+
+	oc := clientsholder.GetClientsHolder()
+	clusterRoles := map[string]*rbacv1.ClusterRole{}
+	clusterRoleList, err := oc.K8sClient.RbacV1().ClusterRoles().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("could not list the cluster roles: %w", err)
+	}
+	for i := range clusterRoleList.Items {
+		clusterRoles[clusterRoleList.Items[i].Name] = &clusterRoleList.Items[i]
+	}
+
+	var compliantObjects []*testhelper.ReportObject
+	var nonCompliantObjects []*testhelper.ReportObject
+	for _, sa := range env.ServiceAccounts {
+		profile := profiles[apiusage.ServiceAccountUserName(sa.Namespace, sa.Name)]
+		profile.ClusterWideWatches = apiusage.ClusterWideWatches(sa.Namespace, sa.Name, env.ClusterRoleBindings, clusterRoles)
+		if profile.HasRequestData {
+			check.LogInfo("Service account %s sent %.0f requests per hour in %d hours: %s", profile.ServiceAccount, profile.RequestsPerHour(), profile.Hours, strings.Join(profile.Rates(), ", "))
+		}
+
+		findings := profile.Findings()
+		if len(findings) == 0 {
+			check.LogInfo("Service account %s API usage is not likely to overload the API server", profile.ServiceAccount)
+			compliantObjects = append(compliantObjects,
+				testhelper.NewReportObject("Service account API usage is not likely to overload the API server", "ServiceAccount", true).
+					AddField(testhelper.Namespace, sa.Namespace).
+					AddField(testhelper.ServiceAccountName, sa.Name).
+					AddField("RequestsPerHour", fmt.Sprintf("%.0f", profile.RequestsPerHour())))
+			continue
+		}
+
+		for i := range findings {
+			check.LogError("Service account %s: %s", profile.ServiceAccount, findings[i].String())
+			nonCompliantObjects = append(nonCompliantObjects,
+				testhelper.NewReportObject("Service account API usage is likely to overload the API server: "+findings[i].Reason, "ServiceAccount", false).
+					AddField(testhelper.Namespace, sa.Namespace).
+					AddField(testhelper.ServiceAccountName, sa.Name).
+					AddField("Resource", findings[i].Resource).
+					AddField("Verb", findings[i].Verb).
+					AddField("RequestsPerHour", fmt.Sprintf("%.0f", findings[i].RequestsPerHour)))
+		}
+	}
+
+	check.SetResult(compliantObjects, nonCompliantObjects)
+	return nil
+}
@@ -77,6 +77,7 @@ func LoadChecks() {
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorCrdVersioningIdentifier)).
 		WithSkipCheckFn(testhelper.GetNoOperatorCrdsSkipFn(&env)).
+		WithDependsOn(identifiers.TestOperatorInstallStatusSucceededIdentifier.Id).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorCrdVersioning(c, &env)
 			return nil
@@ -84,6 +85,7 @@ func LoadChecks() {
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorCrdSchemaIdentifier)).
 		WithSkipCheckFn(testhelper.GetNoOperatorCrdsSkipFn(&env)).
+		WithDependsOn(identifiers.TestOperatorInstallStatusSucceededIdentifier.Id).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorCrdOpenAPISpec(c, &env)
 			return nil
@@ -117,8 +119,10 @@ func LoadChecks() {
 			return nil
 		}))
 
+	// The bundles are counted from the probe pods on OCP 4.12 and older.
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestOperatorCatalogSourceBundleCountIdentifier)).
 		WithSkipCheckFn(testhelper.GetNoCatalogSourcesSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testOperatorCatalogSourceBundleCount(c, &env)
 			return nil
@@ -92,6 +92,7 @@ func LoadChecks() { //nolint:funlen
 		WithSkipCheckFn(
 			skipIfNoGuaranteedPodContainersWithExclusiveCPUs,
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testRtAppsNoExecProbes(c, &env)
 			return nil
@@ -101,6 +102,7 @@ func LoadChecks() { //nolint:funlen
 		WithSkipCheckFn(
 			skipIfNoNonGuaranteedPodContainersWithoutHostPID,
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSchedulingPolicyInCPUPool(c, &env, env.GetNonGuaranteedPodContainersWithoutHostPID(), scheduling.SharedCPUScheduling)
 			return nil
@@ -110,6 +112,7 @@ func LoadChecks() { //nolint:funlen
 		WithSkipCheckFn(
 			skipIfNoGuaranteedPodContainersWithExclusiveCPUsWithoutHostPID,
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSchedulingPolicyInCPUPool(c, &env, env.GetGuaranteedPodContainersWithExclusiveCPUsWithoutHostPID(), scheduling.ExclusiveCPUScheduling)
 			return nil
@@ -119,6 +122,7 @@ func LoadChecks() { //nolint:funlen
 		WithSkipCheckFn(
 			skipIfNoGuaranteedPodContainersWithIsolatedCPUsWithoutHostPID,
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSchedulingPolicyInCPUPool(c, &env, env.GetGuaranteedPodContainersWithIsolatedCPUsWithoutHostPID(), scheduling.ExclusiveCPUScheduling)
 			return nil
@@ -265,7 +269,7 @@ func testSchedulingPolicyInCPUPool(check *checksdb.Check, env *provider.TestEnvi
 			defer nodeMutex.Unlock()
 		}
 
-		pidNamespace, err := crclient.GetContainerPidNamespace(cut, env)
+		pidNamespace, err := crclient.GetContainerPidNamespace(check.Context(), cut, env)
 		if err != nil {
 			check.LogError("Unable to get pid namespace for Container %q, err: %v", cut, err)
 			result.AddNonCompliantObject(
@@ -274,7 +278,7 @@ func testSchedulingPolicyInCPUPool(check *checksdb.Check, env *provider.TestEnvi
 		}
 		check.LogDebug("PID namespace for Container %q is %q", cut, pidNamespace)
 
-		processes, err := crclient.GetPidsFromPidNamespace(pidNamespace, cut)
+		processes, err := crclient.GetPidsFromPidNamespace(check.Context(), pidNamespace, cut)
 		if err != nil {
 			check.LogError("Unable to get PIDs from PID namespace %q for Container %q, err: %v", pidNamespace, cut, err)
 			result.AddNonCompliantObject(
@@ -330,7 +334,7 @@ func testRtAppsNoExecProbes(check *checksdb.Check, env *provider.TestEnvironment
 			defer nodeMutex.Unlock()
 		}
 
-		processes, err := crclient.GetContainerProcesses(cut, env)
+		processes, err := crclient.GetContainerProcesses(check.Context(), cut, env)
 		if err != nil {
 			check.LogError("Could not determine the processes pids for container %q, err: %v", cut, err)
 			result.AddNonCompliantObject(testhelper.NewContainerReportObject(cut.Namespace, cut.Podname, cut.Name, "Could not determine the processes pids for container", false))
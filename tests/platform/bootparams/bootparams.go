@@ -17,6 +17,7 @@
 package bootparams
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -88,7 +89,7 @@ func GetMcKernelArguments(env *provider.TestEnvironment, nodeName string) (map[s
 func getGrubKernelArgs(env *provider.TestEnvironment, nodeName string) (aMap map[string]string, err error) {
 	o := clientsholder.GetClientsHolder()
 	ctx := clientsholder.NewContext(env.ProbePods[nodeName].Namespace, env.ProbePods[nodeName].Name, env.ProbePods[nodeName].Spec.Containers[0].Name)
-	bootConfig, errStr, err := o.ExecCommandContainer(ctx, grubKernelArgsCommand)
+	bootConfig, errStr, err := o.ExecCommandContainer(context.TODO(), ctx, grubKernelArgsCommand)
 	if err != nil || errStr != "" {
 		return aMap, fmt.Errorf("cannot execute %s on probe pod %s, err=%v, stderr=%s", grubKernelArgsCommand, env.ProbePods[nodeName], err, errStr)
 	}
@@ -109,7 +110,7 @@ func getGrubKernelArgs(env *provider.TestEnvironment, nodeName string) (aMap map
 func getCurrentKernelCmdlineArgs(env *provider.TestEnvironment, nodeName string) (aMap map[string]string, err error) {
 	o := clientsholder.GetClientsHolder()
 	ctx := clientsholder.NewContext(env.ProbePods[nodeName].Namespace, env.ProbePods[nodeName].Name, env.ProbePods[nodeName].Spec.Containers[0].Name)
-	currentKernelCmdlineArgs, errStr, err := o.ExecCommandContainer(ctx, kernelArgscommand)
+	currentKernelCmdlineArgs, errStr, err := o.ExecCommandContainer(context.TODO(), ctx, kernelArgscommand)
 	if err != nil || errStr != "" {
 		return aMap, fmt.Errorf("cannot execute %s on probe pod container %s, err=%v, stderr=%s", grubKernelArgsCommand, env.ProbePods[nodeName].Name, err, errStr)
 	}
@@ -145,7 +145,7 @@ func (f *FsDiff) runPodmanDiff(containerUID string) (string, error) {
 		podmanPath = fmt.Sprintf("%s/podman", tmpMountDestFolder)
 	}
 
-	output, outerr, err := f.clientHolder.ExecCommandContainer(f.ctxt, fmt.Sprintf("chroot /host %s diff --format json %s", podmanPath, containerUID))
+	output, outerr, err := f.clientHolder.ExecCommandContainer(f.check.Context(), f.ctxt, fmt.Sprintf("chroot /host %s diff --format json %s", podmanPath, containerUID))
 	if err != nil {
 		return "", fmt.Errorf("can not execute command on container: %w", err)
 	}
@@ -220,7 +220,7 @@ func (f *FsDiff) GetResults() int {
 // container under test. Whatever output in stdout or stderr is considered a failure, so it will
 // return the concatenation of the given errorStr with those stdout, stderr and the error string.
 func (f *FsDiff) execCommandContainer(cmd, errorStr string) error {
-	output, outerr, err := f.clientHolder.ExecCommandContainer(f.ctxt, cmd)
+	output, outerr, err := f.clientHolder.ExecCommandContainer(f.check.Context(), f.ctxt, cmd)
 	if err != nil || output != "" || outerr != "" {
 		return fmt.Errorf("%s Stderr: %s, Stdout: %s, Err: %v", errorStr, output, outerr, err)
 	}
@@ -17,6 +17,7 @@
 package cnffsdiff
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -35,7 +36,7 @@ type ClientHoldersMock struct {
 	err    error
 }
 
-func (o ClientHoldersMock) ExecCommandContainer(_ clientsholder.Context, cmd string) (stdout, stderr string, err error) {
+func (o ClientHoldersMock) ExecCommandContainer(_ context.Context, _ clientsholder.Context, cmd string) (stdout, stderr string, err error) {
 	// Filter out mkdir/rmdir and mount/umount commands.
 	if !strings.Contains(cmd, "podman diff") {
 		return "", "", nil
@@ -132,7 +133,7 @@ type ClientHoldersRetryMock struct {
 	output    string
 }
 
-func (m *ClientHoldersRetryMock) ExecCommandContainer(_ clientsholder.Context, cmd string) (stdout, stderr string, err error) {
+func (m *ClientHoldersRetryMock) ExecCommandContainer(_ context.Context, _ clientsholder.Context, cmd string) (stdout, stderr string, err error) {
 	if !strings.Contains(cmd, "podman diff") {
 		return "", "", nil
 	}
@@ -181,7 +182,7 @@ type ClientHoldersMountCustomPodmanMock struct {
 	MountPhaseReached bool
 }
 
-func (o *ClientHoldersMountCustomPodmanMock) ExecCommandContainer(_ clientsholder.Context, _ string) (stdout, stderr string, err error) {
+func (o *ClientHoldersMountCustomPodmanMock) ExecCommandContainer(_ context.Context, _ clientsholder.Context, _ string) (stdout, stderr string, err error) {
 	if o.MountPhaseReached {
 		if o.mountFolderStdout != "" || o.mountFolderStderr != "" || o.mountFolderErr != nil {
 			return o.mountFolderStdout, o.mountFolderStderr, o.mountFolderErr
@@ -261,7 +262,7 @@ type ClientHoldersUnmountCustomPodmanMock struct {
 	DeletePhaseReached bool
 }
 
-func (o *ClientHoldersUnmountCustomPodmanMock) ExecCommandContainer(_ clientsholder.Context, cmd string) (stdout, stderr string, err error) {
+func (o *ClientHoldersUnmountCustomPodmanMock) ExecCommandContainer(_ context.Context, _ clientsholder.Context, cmd string) (stdout, stderr string, err error) {
 	// To reach the unmount/delete folder at the end, we need to make the mount operation and the podman diff to return no errors.
 	if strings.Contains(cmd, "mount --bind") || strings.Contains(cmd, "mkdir") {
 		return "", "", nil
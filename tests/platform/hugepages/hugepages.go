@@ -2,6 +2,7 @@
 package hugepages
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
@@ -259,7 +260,7 @@ func (tester *Tester) TestNodeHugepagesWithKernelArgs() (bool, error) {
 // getNodeNumaHugePages gets the actual node's hugepages config based on /sys/devices/system/node/nodeX files.
 func (tester *Tester) getNodeNumaHugePages() (hugepages hugepagesByNuma, err error) {
 	// This command must run inside the node, so we'll need the node's context to run commands inside the probe daemonset pod.
-	stdout, stderr, err := tester.commander.ExecCommandContainer(tester.context, cmd)
+	stdout, stderr, err := tester.commander.ExecCommandContainer(context.TODO(), tester.context, cmd)
 	log.Debug("getNodeNumaHugePages stdout: %s, stderr: %s", stdout, stderr)
 	if err != nil {
 		return hugepagesByNuma{}, err
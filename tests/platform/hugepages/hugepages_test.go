@@ -2,6 +2,7 @@
 package hugepages
 
 import (
+	"context"
 	"testing"
 
 	mcv1 "github.com/openshift/api/machineconfiguration/v1"
@@ -173,7 +174,7 @@ type fakeK8sClient struct {
 	execCommandFunctionMocker func() (stdout string, stderr string, err error)
 }
 
-func (client *fakeK8sClient) ExecCommandContainer(_ clientsholder.Context, _ string) (stdout, stderr string, err error) {
+func (client *fakeK8sClient) ExecCommandContainer(_ context.Context, _ clientsholder.Context, _ string) (stdout, stderr string, err error) {
 	return client.execCommandFunctionMocker()
 }
 
@@ -17,6 +17,7 @@
 package isredhat
 
 import (
+	"context"
 	"errors"
 	"regexp"
 
@@ -68,7 +69,7 @@ func IsRHEL(output string) bool {
 }
 
 func (b *BaseImageInfo) runCommand(cmd string) (string, error) {
-	output, outerr, err := b.ClientHolder.ExecCommandContainer(b.OCPContext, cmd)
+	output, outerr, err := b.ClientHolder.ExecCommandContainer(context.TODO(), b.OCPContext, cmd)
 	if err != nil {
 		log.Error("can not execute command on container, err: %v", err)
 		return "", err
@@ -17,6 +17,7 @@
 package nodetainted
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
@@ -35,7 +36,7 @@ type NodeTainted struct {
 
 var runCommand = func(ctx *clientsholder.Context, cmd string) (string, error) {
 	ch := clientsholder.GetClientsHolder()
-	output, outerr, err := ch.ExecCommandContainer(*ctx, cmd)
+	output, outerr, err := ch.ExecCommandContainer(context.TODO(), *ctx, cmd)
 	if err != nil {
 		log.Error("can not execute command on container, err=%v", err)
 		return "", err
@@ -55,6 +55,7 @@ func LoadChecks() {
 		WithSkipCheckFn(
 			testhelper.GetNoBareMetalNodesSkipFn(&env),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testHyperThreadingEnabled(c, &env)
 			return nil
@@ -65,6 +66,7 @@ func LoadChecks() {
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env),
 			testhelper.GetNoContainersUnderTestSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testContainersFsDiff(c, &env)
 			return nil
@@ -72,6 +74,7 @@ func LoadChecks() {
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestNonTaintedNodeKernelsIdentifier)).
 		WithSkipCheckFn(testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testTainted(c, &env)
 			return nil
@@ -79,6 +82,7 @@ func LoadChecks() {
 
 	checksGroup.Add(checksdb.NewCheck(identifiers.GetTestIDAndLabels(identifiers.TestIsRedHatReleaseIdentifier)).
 		WithSkipCheckFn(testhelper.GetNoContainersUnderTestSkipFn(&env)).
+		WithRequirements(checksdb.RequireExec).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testIsRedHatRelease(c, &env)
 			return nil
@@ -88,6 +92,7 @@ func LoadChecks() {
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testIsSELinuxEnforcing(c, &env)
 			return nil
@@ -97,6 +102,7 @@ func LoadChecks() {
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testHugepages(c, &env)
 			return nil
@@ -106,6 +112,7 @@ func LoadChecks() {
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testUnalteredBootParams(c, &env)
 			return nil
@@ -115,6 +122,7 @@ func LoadChecks() {
 		WithSkipCheckFn(
 			testhelper.GetNonOCPClusterSkipFn(),
 			testhelper.GetDaemonSetFailedToSpawnSkipFn(&env)).
+		WithRequirements(checksdb.RequireProbe).
 		WithCheckFn(func(c *checksdb.Check) error {
 			testSysctlConfigs(c, &env)
 			return nil
@@ -390,7 +398,7 @@ func testIsSELinuxEnforcing(check *checksdb.Check, env *provider.TestEnvironment
 	checksdb.ForEachParallel(check, probePods, 0, func(check *checksdb.Check, probePod *corev1.Pod, result *checksdb.ParallelResult) {
 		o := clientsholder.GetClientsHolder()
 		ctx := clientsholder.NewContext(probePod.Namespace, probePod.Name, probePod.Spec.Containers[0].Name)
-		outStr, errStr, err := o.ExecCommandContainer(ctx, getenforceCommand)
+		outStr, errStr, err := o.ExecCommandContainer(check.Context(), ctx, getenforceCommand)
 		if err != nil || errStr != "" {
 			check.LogError("Could not execute command %q in Probe Pod %q, errStr: %q, err: %v", getenforceCommand, probePod, errStr, err)
 			result.AddNonCompliantObject(testhelper.NewPodReportObject(probePod.Namespace, probePod.Name, "Failed to execute command", false))
@@ -17,6 +17,7 @@
 package sysctlconfig
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -54,7 +55,7 @@ func GetSysctlSettings(env *provider.TestEnvironment, nodeName string) (map[stri
 	o := clientsholder.GetClientsHolder()
 	ctx := clientsholder.NewContext(env.ProbePods[nodeName].Namespace, env.ProbePods[nodeName].Name, env.ProbePods[nodeName].Spec.Containers[0].Name)
 
-	outStr, errStr, err := o.ExecCommandContainer(ctx, sysctlCommand)
+	outStr, errStr, err := o.ExecCommandContainer(context.TODO(), ctx, sysctlCommand)
 	if err != nil || errStr != "" {
 		return nil, fmt.Errorf("failed to execute command %s in probe pod %s, err=%v, stderr=%s", sysctlCommand,
 			env.ProbePods[nodeName], err, errStr)
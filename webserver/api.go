@@ -0,0 +1,260 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package webserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/labels"
+	yaml "gopkg.in/yaml.v3"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// APIPrefix is the path prefix of the REST API endpoints. It's versioned, so that breaking
+	// changes go to a new prefix.
+	APIPrefix = "/api/v1"
+
+	// Limit request body size to 32 MB to prevent memory exhaustion (gosec G120)
+	maxRequestBodySize = 32 << 20
+
+	claimFileName = "claim.json"
+
+	// Fields of the job submission form.
+	kubeconfigFormField   = "kubeconfig"
+	configFormField       = "config"
+	labelFilterFormField  = "labelFilter"
+	artifactsContentType  = "application/gzip"
+	claimFileContentType  = "application/json"
+	contentTypeHeaderName = "Content-Type"
+)
+
+// apiError is the body of the API error responses.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// jobsAPI serves the REST API of the job queue.
+type jobsAPI struct {
+	jobs *JobQueue
+	// Configuration file of the jobs submitted without one. It's only read.
+	defaultConfigFile string
+}
+
+func writeJSONResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set(contentTypeHeaderName, "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Error("Failed to write the API response: %v", err)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeJSONResponse(w, status, apiError{Error: err.Error()})
+}
+
+// jobErrorStatus returns the HTTP status of the job queue errors.
+func jobErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrJobFinished):
+		return http.StatusConflict
+	case errors.Is(err, ErrJobQueueFull):
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusInternalServerError
+}
+
+func (api *jobsAPI) install(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+APIPrefix+"/jobs", api.submitJob)
+	mux.HandleFunc("GET "+APIPrefix+"/jobs", api.listJobs)
+	mux.HandleFunc("GET "+APIPrefix+"/jobs/{id}", api.getJob)
+	mux.HandleFunc("POST "+APIPrefix+"/jobs/{id}/cancel", api.cancelJob)
+	mux.HandleFunc("GET "+APIPrefix+"/jobs/{id}/claim", api.getJobClaim)
+	mux.HandleFunc("GET "+APIPrefix+"/jobs/{id}/artifacts", api.getJobArtifacts)
+	mux.HandleFunc("GET "+APIPrefix+"/jobs/{id}/events", api.streamJobEvents)
+}
+
+func readFormFile(r *http.Request, field string) ([]byte, error) {
+	file, _, err := r.FormFile(field)
+	if err != nil {
+		return nil, err
+	}
+	defer func(file multipart.File) { _ = file.Close() }(file)
+
+	return io.ReadAll(file)
+}
+
+// validateKubeconfig returns an error if the kubeconfig has no cluster to connect to, so that the
+// jobs with invalid kubeconfig files are not queued.
+func validateKubeconfig(kubeconfig []byte) error {
+	if _, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig); err != nil {
+		return fmt.Errorf("invalid %s file: %w", kubeconfigFormField, err)
+	}
+
+	return nil
+}
+
+// submitJob queues a job from a multipart form with the kubeconfig file, the optional certsuite
+// configuration file, and the labels filter.
+func (api *jobsAPI) submitJob(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+
+	labelsFilter := r.FormValue(labelFilterFormField)
+	if labelsFilter == "" {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("the %s field is required", labelFilterFormField))
+		return
+	}
+	if _, err := labels.NewLabelsExprEvaluator(labelsFilter); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid %s: %w", labelFilterFormField, err))
+		return
+	}
+
+	kubeconfig, err := readFormFile(r, kubeconfigFormField)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("the %s file is required: %w", kubeconfigFormField, err))
+		return
+	}
+	if err := validateKubeconfig(kubeconfig); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	config, err := readFormFile(r, configFormField)
+	switch {
+	case errors.Is(err, http.ErrMissingFile):
+		config, err = os.ReadFile(api.defaultConfigFile)
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, fmt.Errorf("failed to read the default configuration file: %w", err))
+			return
+		}
+	case err != nil:
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("failed to read the %s file: %w", configFormField, err))
+		return
+	default:
+		if err := yaml.Unmarshal(config, &configuration.TestConfiguration{}); err != nil {
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid %s file: %w", configFormField, err))
+			return
+		}
+	}
+
+	job, err := api.jobs.Submit(getUser(r), kubeconfig, config, labelsFilter)
+	if err != nil {
+		writeAPIError(w, jobErrorStatus(err), err)
+		return
+	}
+
+	log.Info("Job %s submitted, labels filter: %s", job.ID, job.LabelsFilter)
+	w.Header().Set("Location", APIPrefix+"/jobs/"+job.ID)
+	writeJSONResponse(w, http.StatusAccepted, job)
+}
+
+// getUserJob returns the job of the request path, if its user can access it. The jobs of other
+// users are not found, so their IDs are not disclosed.
+func (api *jobsAPI) getUserJob(r *http.Request) (Job, error) {
+	job, err := api.jobs.Get(r.PathValue("id"))
+	if err != nil {
+		return Job{}, err
+	}
+
+	if job.Owner != getUser(r) {
+		return Job{}, ErrJobNotFound
+	}
+
+	return job, nil
+}
+
+func (api *jobsAPI) listJobs(w http.ResponseWriter, r *http.Request) {
+	jobs := []Job{}
+	for _, job := range api.jobs.List() {
+		if job.Owner == getUser(r) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	writeJSONResponse(w, http.StatusOK, jobs)
+}
+
+func (api *jobsAPI) getJob(w http.ResponseWriter, r *http.Request) {
+	job, err := api.getUserJob(r)
+	if err != nil {
+		writeAPIError(w, jobErrorStatus(err), err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, job)
+}
+
+func (api *jobsAPI) cancelJob(w http.ResponseWriter, r *http.Request) {
+	job, err := api.getUserJob(r)
+	if err == nil {
+		job, err = api.jobs.Cancel(job.ID)
+	}
+	if err != nil {
+		writeAPIError(w, jobErrorStatus(err), err)
+		return
+	}
+
+	log.Info("Job %s cancellation requested", job.ID)
+	writeJSONResponse(w, http.StatusAccepted, job)
+}
+
+// getFinishedJob returns the job, writing the error response if it's not found or not finished.
+func (api *jobsAPI) getFinishedJob(w http.ResponseWriter, r *http.Request) (Job, bool) {
+	job, err := api.getUserJob(r)
+	if err != nil {
+		writeAPIError(w, jobErrorStatus(err), err)
+		return job, false
+	}
+
+	if job.FinishedAt == nil {
+		writeAPIError(w, http.StatusConflict, fmt.Errorf("job %s is %s", job.ID, job.State))
+		return job, false
+	}
+
+	return job, true
+}
+
+func serveJobFile(w http.ResponseWriter, r *http.Request, filePath, contentType string) {
+	if _, err := os.Stat(filePath); err != nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("%s not found", filepath.Base(filePath)))
+		return
+	}
+
+	w.Header().Set(contentTypeHeaderName, contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filepath.Base(filePath)))
+	http.ServeFile(w, r, filePath)
+}
+
+func (api *jobsAPI) getJobClaim(w http.ResponseWriter, r *http.Request) {
+	job, ok := api.getFinishedJob(w, r)
+	if !ok {
+		return
+	}
+
+	serveJobFile(w, r, filepath.Join(job.OutputDir, claimFileName), claimFileContentType)
+}
+
+func (api *jobsAPI) getJobArtifacts(w http.ResponseWriter, r *http.Request) {
+	job, ok := api.getFinishedJob(w, r)
+	if !ok {
+		return
+	}
+
+	if job.ArtifactsFile == "" {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("job %s has no results artifacts file", job.ID))
+		return
+	}
+
+	serveJobFile(w, r, filepath.Join(job.OutputDir, job.ArtifactsFile), artifactsContentType)
+}
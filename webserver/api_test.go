@@ -0,0 +1,216 @@
+package webserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+  - name: test
+    cluster:
+      server: https://api.test.example.com:6443
+contexts:
+  - name: test
+    context:
+      cluster: test
+      user: test
+current-context: test
+users:
+  - name: test
+    user:
+      token: token
+`
+
+func newTestJobsAPI(t *testing.T) (*httptest.Server, *fakeRunner) {
+	t.Helper()
+
+	runner := newFakeRunner()
+	q, err := NewJobQueue(t.TempDir(), runner, nil)
+	require.NoError(t, err)
+
+	defaultConfigFile := filepath.Join(t.TempDir(), "certsuite_config.yml")
+	require.NoError(t, os.WriteFile(defaultConfigFile, []byte("targetNameSpaces:\n  - name: default\n"), jobInputFilePerms))
+
+	mux := http.NewServeMux()
+	api := jobsAPI{jobs: q, defaultConfigFile: defaultConfigFile}
+	api.install(mux)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, runner
+}
+
+func submitTestJob(t *testing.T, url string, fields, files map[string]string) *http.Response {
+	t.Helper()
+
+	body := bytes.Buffer{}
+	form := multipart.NewWriter(&body)
+	for field, value := range fields {
+		require.NoError(t, form.WriteField(field, value))
+	}
+	for field, content := range files {
+		file, err := form.CreateFormFile(field, field)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, form.Close())
+
+	resp, err := http.Post(url+APIPrefix+"/jobs", form.FormDataContentType(), &body)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+func decodeTestResponse(t *testing.T, resp *http.Response, body any) {
+	t.Helper()
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(body))
+}
+
+func TestJobsAPI(t *testing.T) {
+	server, runner := newTestJobsAPI(t)
+
+	resp := submitTestJob(t, server.URL, map[string]string{labelFilterFormField: "observability"}, map[string]string{kubeconfigFormField: testKubeconfig})
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	job := Job{}
+	decodeTestResponse(t, resp, &job)
+	assert.Equal(t, APIPrefix+"/jobs/"+job.ID, resp.Header.Get("Location"))
+	assert.Equal(t, JobStateQueued, job.State)
+
+	// The job runs with the default configuration file.
+	running := <-runner.started
+	config, err := os.ReadFile(running.ConfigFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(config), "targetNameSpaces")
+
+	// The results can't be downloaded until the job finishes.
+	resp, err = http.Get(server.URL + APIPrefix + "/jobs/" + job.ID + "/claim")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	runner.release <- nil
+	<-running.done
+
+	resp, err = http.Get(server.URL + APIPrefix + "/jobs/" + job.ID)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	decodeTestResponse(t, resp, &job)
+	assert.Equal(t, JobStateCompleted, job.State)
+
+	resp, err = http.Get(server.URL + APIPrefix + "/jobs")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	jobs := []Job{}
+	decodeTestResponse(t, resp, &jobs)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, job.ID, jobs[0].ID)
+
+	resp, err = http.Get(server.URL + APIPrefix + "/jobs/" + job.ID + "/claim")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, claimFileContentType, resp.Header.Get(contentTypeHeaderName))
+
+	resp, err = http.Get(server.URL + APIPrefix + "/jobs/" + job.ID + "/artifacts")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `attachment; filename="20261017-cnf-test-results.tar.gz"`, resp.Header.Get("Content-Disposition"))
+
+	resp, err = http.Post(server.URL+APIPrefix+"/jobs/"+job.ID+"/cancel", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + APIPrefix + "/jobs/unknown")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestJobsAPICancel(t *testing.T) {
+	server, runner := newTestJobsAPI(t)
+
+	resp := submitTestJob(t, server.URL, map[string]string{labelFilterFormField: "all"}, map[string]string{kubeconfigFormField: testKubeconfig})
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	job := Job{}
+	decodeTestResponse(t, resp, &job)
+	running := <-runner.started
+
+	resp, err := http.Post(server.URL+APIPrefix+"/jobs/"+job.ID+"/cancel", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	<-running.done
+	resp, err = http.Get(server.URL + APIPrefix + "/jobs/" + job.ID)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	decodeTestResponse(t, resp, &job)
+	assert.Equal(t, JobStateCanceled, job.State)
+}
+
+func TestJobsAPISubmitErrors(t *testing.T) {
+	server, _ := newTestJobsAPI(t)
+
+	testCases := []struct {
+		name          string
+		fields        map[string]string
+		files         map[string]string
+		expectedError string
+	}{
+		{
+			name:          "missing labels filter",
+			files:         map[string]string{kubeconfigFormField: testKubeconfig},
+			expectedError: "the labelFilter field is required",
+		},
+		{
+			name:          "invalid labels filter",
+			fields:        map[string]string{labelFilterFormField: "observability &&"},
+			files:         map[string]string{kubeconfigFormField: testKubeconfig},
+			expectedError: "invalid labelFilter",
+		},
+		{
+			name:          "missing kubeconfig",
+			fields:        map[string]string{labelFilterFormField: "all"},
+			expectedError: "the kubeconfig file is required",
+		},
+		{
+			name:          "invalid kubeconfig",
+			fields:        map[string]string{labelFilterFormField: "all"},
+			files:         map[string]string{kubeconfigFormField: "kubeconfig"},
+			expectedError: "invalid kubeconfig file",
+		},
+		{
+			name:          "invalid configuration file",
+			fields:        map[string]string{labelFilterFormField: "all"},
+			files:         map[string]string{kubeconfigFormField: testKubeconfig, configFormField: "targetNameSpaces: name"},
+			expectedError: "invalid config file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := submitTestJob(t, server.URL, tc.fields, tc.files)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			apiErr := apiError{}
+			decodeTestResponse(t, resp, &apiErr)
+			assert.Contains(t, apiErr.Error, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package webserver
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" //nolint:gosec // Only to check the {SHA} passwords of htpasswd files.
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrNoCredentials is returned by the authenticators when the request has no credentials of
+	// the kind they check.
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials is returned by the authenticators when the credentials are wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	userCtxKey webServerContextKey = "user"
+)
+
+// Prefixes of the users of each authenticator, so that the users of different authenticators with
+// the same name, e.g. an OIDC subject and an htpasswd user, don't own each other's jobs and runs.
+const (
+	tokenUserPrefix    = "token:"
+	htpasswdUserPrefix = "htpasswd:"
+	oidcUserPrefix     = "oidc:"
+)
+
+// Authenticator authenticates the user of the web server requests.
+type Authenticator interface {
+	// Authenticate returns the user of the request, prefixed with the authenticator kind,
+	// ErrNoCredentials if the request has no credentials of the kind it checks, or the reason they
+	// are not valid.
+	Authenticate(r *http.Request) (user string, err error)
+	// Challenge returns the WWW-Authenticate header value of the unauthenticated responses.
+	Challenge() string
+}
+
+// getUser returns the authenticated user of the request, empty when the server has no authentication.
+func getUser(r *http.Request) string {
+	user, _ := r.Context().Value(userCtxKey).(string)
+	return user
+}
+
+func getBearerToken(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
+
+// isSameOrigin returns whether the request was sent by a page of this server, or not by a browser.
+func isSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	originURL, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(originURL.Host, r.Host)
+}
+
+// authenticate returns the handler that only serves the requests authenticated by any of the
+// authenticators, with the user in their context. The browsers send the basic auth credentials on
+// their own, so the requests changing the state from other sites are rejected.
+func authenticate(authenticators []Authenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, authenticator := range authenticators {
+			user, err := authenticator.Authenticate(r)
+			if errors.Is(err, ErrNoCredentials) {
+				continue
+			}
+			if err != nil {
+				log.Warn("Authentication failed for %s %s from %s: %v", r.Method, r.URL.Path, r.RemoteAddr, err)
+				break
+			}
+
+			if r.Method != http.MethodGet && r.Method != http.MethodHead && !isSameOrigin(r) {
+				http.Error(w, "Cross-origin request rejected", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userCtxKey, user)))
+			return
+		}
+
+		challenges := []string{}
+		for _, authenticator := range authenticators {
+			if !slices.Contains(challenges, authenticator.Challenge()) {
+				challenges = append(challenges, authenticator.Challenge())
+				w.Header().Add("WWW-Authenticate", authenticator.Challenge())
+			}
+		}
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	})
+}
+
+// readCredentialsFile returns the non-empty lines of the file that are not comments.
+func readCredentialsFile(fileName string) ([]string, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// tokenAuthenticator authenticates the requests with static bearer tokens.
+type tokenAuthenticator struct {
+	// Users by token.
+	users map[string]string
+}
+
+// NewTokenAuthenticator returns an authenticator of the bearer tokens in the file, which has a
+// token and the user it authenticates per line, separated by spaces.
+func NewTokenAuthenticator(fileName string) (Authenticator, error) {
+	lines, err := readCredentialsFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tokens file %s: %w", fileName, err)
+	}
+
+	users := map[string]string{}
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 { //nolint:mnd // token and user
+			return nil, fmt.Errorf("invalid tokens file %s: entry %d is not a token and a user", fileName, i+1)
+		}
+		users[fields[0]] = fields[1]
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("tokens file %s has no tokens", fileName)
+	}
+
+	return &tokenAuthenticator{users: users}, nil
+}
+
+func (a *tokenAuthenticator) Authenticate(r *http.Request) (string, error) {
+	token, found := getBearerToken(r)
+	if !found {
+		return "", ErrNoCredentials
+	}
+
+	// Every token is compared, so the time it takes tells nothing about them.
+	user := ""
+	for knownToken, knownUser := range a.users {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(knownToken)) == 1 {
+			user = knownUser
+		}
+	}
+	if user == "" {
+		// It may be an OIDC token.
+		return "", ErrNoCredentials
+	}
+
+	return tokenUserPrefix + user, nil
+}
+
+func (a *tokenAuthenticator) Challenge() string {
+	return `Bearer realm="certsuite"`
+}
+
+// htpasswdAuthenticator authenticates the requests with basic auth credentials.
+type htpasswdAuthenticator struct {
+	// Password hashes by user.
+	hashes map[string]string
+	// Hash the passwords of the unknown users are compared with, so the time it takes tells
+	// nothing about the users.
+	dummyHash []byte
+}
+
+// NewHtpasswdAuthenticator returns an authenticator of the users in the htpasswd file, whose
+// passwords must be hashed with bcrypt (htpasswd -B) or SHA-1 (htpasswd -s).
+func NewHtpasswdAuthenticator(fileName string) (Authenticator, error) {
+	lines, err := readCredentialsFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the htpasswd file %s: %w", fileName, err)
+	}
+
+	hashes := map[string]string{}
+	for i, line := range lines {
+		user, hash, found := strings.Cut(line, ":")
+		if !found || user == "" {
+			return nil, fmt.Errorf("invalid htpasswd file %s: entry %d is not a user and a password hash", fileName, i+1)
+		}
+		if !strings.HasPrefix(hash, "$2") && !strings.HasPrefix(hash, "{SHA}") {
+			return nil, fmt.Errorf("invalid htpasswd file %s: unsupported password hash of user %s, use bcrypt (htpasswd -B)", fileName, user)
+		}
+		hashes[user] = hash
+	}
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("htpasswd file %s has no users", fileName)
+	}
+
+	// The dummy hash has the cost of the file ones, for the comparisons to take as long.
+	cost := bcrypt.DefaultCost
+	for _, hash := range hashes {
+		if hashCost, err := bcrypt.Cost([]byte(hash)); err == nil {
+			cost = hashCost
+			break
+		}
+	}
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("dummy"), cost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the dummy password hash: %w", err)
+	}
+
+	return &htpasswdAuthenticator{hashes: hashes, dummyHash: dummyHash}, nil
+}
+
+func (a *htpasswdAuthenticator) Authenticate(r *http.Request) (string, error) {
+	user, password, found := r.BasicAuth()
+	if !found {
+		return "", ErrNoCredentials
+	}
+
+	hash, found := a.hashes[user]
+	if !found {
+		_ = bcrypt.CompareHashAndPassword(a.dummyHash, []byte(password))
+		return "", fmt.Errorf("unknown user %s: %w", user, ErrInvalidCredentials)
+	}
+
+	if sha1Hash, isSHA1 := strings.CutPrefix(hash, "{SHA}"); isSHA1 {
+		sum := sha1.Sum([]byte(password)) //nolint:gosec
+		if subtle.ConstantTimeCompare([]byte(base64.StdEncoding.EncodeToString(sum[:])), []byte(sha1Hash)) != 1 {
+			return "", fmt.Errorf("wrong password of user %s: %w", user, ErrInvalidCredentials)
+		}
+		return htpasswdUserPrefix + user, nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return "", fmt.Errorf("wrong password of user %s: %w", user, ErrInvalidCredentials)
+	}
+
+	return htpasswdUserPrefix + user, nil
+}
+
+func (a *htpasswdAuthenticator) Challenge() string {
+	return `Basic realm="certsuite", charset="UTF-8"`
+}
@@ -0,0 +1,199 @@
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(fileName, []byte(content), jobInputFilePerms))
+	return fileName
+}
+
+func newAuthRequest(t *testing.T, header string) *http.Request {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", http.NoBody)
+	if header != "" {
+		r.Header.Set("Authorization", header)
+	}
+	return r
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	authenticator, err := NewTokenAuthenticator(writeCredentialsFile(t, "# token user\ns3cr3t alice\n\nt0k3n bob\n"))
+	require.NoError(t, err)
+
+	user, err := authenticator.Authenticate(newAuthRequest(t, "Bearer s3cr3t"))
+	require.NoError(t, err)
+	assert.Equal(t, "token:alice", user)
+
+	user, err = authenticator.Authenticate(newAuthRequest(t, "bearer t0k3n"))
+	require.NoError(t, err)
+	assert.Equal(t, "token:bob", user)
+
+	_, err = authenticator.Authenticate(newAuthRequest(t, "Bearer unknown"))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+	_, err = authenticator.Authenticate(newAuthRequest(t, ""))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	_, err = NewTokenAuthenticator(writeCredentialsFile(t, "s3cr3t\n"))
+	assert.ErrorContains(t, err, "entry 1 is not a token and a user")
+	_, err = NewTokenAuthenticator(writeCredentialsFile(t, "# no tokens\n"))
+	assert.ErrorContains(t, err, "has no tokens")
+}
+
+func TestHtpasswdAuthenticator(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("alicepw"), bcrypt.MinCost)
+	require.NoError(t, err)
+	// htpasswd -bns bob bobpw
+	authenticator, err := NewHtpasswdAuthenticator(writeCredentialsFile(t, "alice:"+string(hash)+"\nbob:{SHA}KXV5lfOmXj1HOy0eE1tRGdIyUHw=\n"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		user, password string
+		expectedErr    error
+	}{
+		{user: "alice", password: "alicepw"},
+		{user: "bob", password: "bobpw"},
+		{user: "alice", password: "bobpw", expectedErr: ErrInvalidCredentials},
+		{user: "bob", password: "alicepw", expectedErr: ErrInvalidCredentials},
+		{user: "carol", password: "alicepw", expectedErr: ErrInvalidCredentials},
+	}
+
+	for _, tc := range testCases {
+		r := newAuthRequest(t, "")
+		r.SetBasicAuth(tc.user, tc.password)
+		user, err := authenticator.Authenticate(r)
+		if tc.expectedErr != nil {
+			assert.ErrorIs(t, err, tc.expectedErr)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, "htpasswd:"+tc.user, user)
+	}
+
+	_, err = authenticator.Authenticate(newAuthRequest(t, "Bearer s3cr3t"))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+
+	_, err = NewHtpasswdAuthenticator(writeCredentialsFile(t, "alice:$apr1$Zf3ZsQ7n$GGmP7Nsd1bVXrL4lTOYo/0\n"))
+	assert.ErrorContains(t, err, "unsupported password hash of user alice")
+}
+
+func TestAuthenticate(t *testing.T) {
+	tokens, err := NewTokenAuthenticator(writeCredentialsFile(t, "s3cr3t alice\nt0k3n bob\n"))
+	require.NoError(t, err)
+	hash, err := bcrypt.GenerateFromPassword([]byte("bobpw"), bcrypt.MinCost)
+	require.NoError(t, err)
+	htpasswd, err := NewHtpasswdAuthenticator(writeCredentialsFile(t, "bob:"+string(hash)+"\n"))
+	require.NoError(t, err)
+
+	handler := authenticate([]Authenticator{tokens, htpasswd}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(getUser(r)))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newAuthRequest(t, "Bearer s3cr3t"))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "token:alice", w.Body.String())
+
+	w = httptest.NewRecorder()
+	r := newAuthRequest(t, "")
+	r.SetBasicAuth("bob", "bobpw")
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "htpasswd:bob", w.Body.String())
+
+	// The users of different authenticators with the same name are different users.
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newAuthRequest(t, "Bearer t0k3n"))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "token:bob", w.Body.String())
+
+	for _, header := range []string{"", "Bearer unknown"} {
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, newAuthRequest(t, header))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, []string{`Bearer realm="certsuite"`, `Basic realm="certsuite", charset="UTF-8"`}, w.Header().Values("WWW-Authenticate"))
+	}
+
+	w = httptest.NewRecorder()
+	r = newAuthRequest(t, "")
+	r.SetBasicAuth("bob", "wrong")
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// The browsers send the basic auth credentials on their own to this server from other sites.
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "http://certsuite.example.com/api/v1/jobs", http.NoBody)
+	r.SetBasicAuth("bob", "bobpw")
+	r.Header.Set("Origin", "https://attacker.example.com")
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	r.Header.Set("Origin", "http://certsuite.example.com")
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestJobsAPIUserVisibility(t *testing.T) {
+	runner := newFakeRunner()
+	q, err := NewJobQueue(t.TempDir(), runner, nil)
+	require.NoError(t, err)
+	tokens, err := NewTokenAuthenticator(writeCredentialsFile(t, "alice-token alice\nbob-token bob\n"))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := jobsAPI{jobs: q}
+	api.install(mux)
+	server := httptest.NewServer(authenticate([]Authenticator{tokens}, mux))
+	t.Cleanup(server.Close)
+
+	job, err := q.Submit("token:alice", nil, nil, "all")
+	require.NoError(t, err)
+	running := <-runner.started
+	runner.release <- nil
+	<-running.done
+
+	get := func(token, path string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+APIPrefix+path, http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	for _, path := range []string{"/jobs/" + job.ID, "/jobs/" + job.ID + "/claim", "/jobs/" + job.ID + "/artifacts", "/jobs/" + job.ID + "/events"} {
+		assert.Equal(t, http.StatusOK, get("alice-token", path).StatusCode, path)
+		// Other users' jobs are not found.
+		assert.Equal(t, http.StatusNotFound, get("bob-token", path).StatusCode, path)
+	}
+
+	jobs := []Job{}
+	decodeTestResponse(t, get("alice-token", "/jobs"), &jobs)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "token:alice", jobs[0].Owner)
+	decodeTestResponse(t, get("bob-token", "/jobs"), &jobs)
+	assert.Empty(t, jobs)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+APIPrefix+"/jobs/"+job.ID+"/cancel", http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer bob-token")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package webserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+)
+
+const (
+	// Events file written by the certsuite in the output directory of each job.
+	eventsFileName = "certsuite-events.jsonl"
+
+	eventsPollInterval = 500 * time.Millisecond
+	// Type of the last event of the stream, whose data is the finished job.
+	jobFinishedEventType = "job-finished"
+)
+
+// eventsTail reads the lines appended to the events file since the last read.
+type eventsTail struct {
+	fileName string
+	offset   int64
+	// Number of lines read so far, which is the ID of the last line sent.
+	lines int
+}
+
+func (t *eventsTail) readLines() ([][]byte, error) {
+	f, err := os.Open(t.fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		// The job has not started yet.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// The last line is left for the next read until it's complete.
+	end := bytes.LastIndexByte(data, '\n')
+	if end < 0 {
+		return nil, nil
+	}
+	t.offset += int64(end + 1)
+
+	return bytes.Split(data[:end], []byte("\n")), nil
+}
+
+func writeServerSentEvent(w io.Writer, id, eventType string, data []byte) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, data)
+	return err
+}
+
+// streamJobEvents streams the events of the job as Server-Sent Events, from the first one or the
+// one after the Last-Event-ID header, until the job finishes. The event ID is the line number in
+// the events file, and its type, the run event type.
+func (api *jobsAPI) streamJobEvents(w http.ResponseWriter, r *http.Request) {
+	job, err := api.getUserJob(r)
+	if err != nil {
+		writeAPIError(w, jobErrorStatus(err), err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	// Events already received by a client that reconnects are not sent again.
+	lastEventID, _ := strconv.Atoi(r.Header.Get("Last-Event-ID"))
+
+	w.Header().Set(contentTypeHeaderName, "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	tail := eventsTail{fileName: filepath.Join(job.OutputDir, eventsFileName)}
+	for {
+		// The job is read before the file, so no event written before it finished is missed.
+		job, err = api.jobs.Get(job.ID)
+		if err != nil {
+			return
+		}
+
+		lines, err := tail.readLines()
+		if err != nil {
+			log.Error("Failed to read the events of job %s: %v", job.ID, err)
+			return
+		}
+
+		for _, line := range lines {
+			tail.lines++
+			if tail.lines <= lastEventID {
+				continue
+			}
+
+			event := struct {
+				Type string `json:"type"`
+			}{}
+			if err := json.Unmarshal(line, &event); err != nil {
+				log.Warn("Ignoring invalid event %d of job %s: %v", tail.lines, job.ID, err)
+				continue
+			}
+
+			if err := writeServerSentEvent(w, strconv.Itoa(tail.lines), event.Type, line); err != nil {
+				return
+			}
+		}
+
+		if job.FinishedAt != nil {
+			data, err := json.Marshal(job)
+			if err == nil {
+				_ = writeServerSentEvent(w, "", jobFinishedEventType, data)
+			}
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(eventsPollInterval):
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package webserver
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsTailReadLines(t *testing.T) {
+	tail := eventsTail{fileName: filepath.Join(t.TempDir(), eventsFileName)}
+
+	// No events file until the job starts.
+	lines, err := tail.readLines()
+	require.NoError(t, err)
+	assert.Empty(t, lines)
+
+	require.NoError(t, os.WriteFile(tail.fileName, []byte("{\"seq\":1}\n{\"seq\""), jobInputFilePerms))
+	lines, err = tail.readLines()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(`{"seq":1}`)}, lines)
+
+	// The incomplete line is read once it's complete.
+	require.NoError(t, os.WriteFile(tail.fileName, []byte("{\"seq\":1}\n{\"seq\":2}\n"), jobInputFilePerms))
+	lines, err = tail.readLines()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(`{"seq":2}`)}, lines)
+}
+
+func TestStreamJobEvents(t *testing.T) {
+	server, runner := newTestJobsAPI(t)
+
+	resp := submitTestJob(t, server.URL, map[string]string{labelFilterFormField: "all"}, map[string]string{kubeconfigFormField: testKubeconfig})
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	job := Job{}
+	decodeTestResponse(t, resp, &job)
+	running := <-runner.started
+	runner.release <- nil
+	<-running.done
+
+	resp, err := http.Get(server.URL + APIPrefix + "/jobs/" + job.ID + "/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get(contentTypeHeaderName))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "id: 1\nevent: run-started\ndata: {\"seq\":1,\"type\":\"run-started\"}\n\n")
+	assert.Contains(t, string(body), "id: 2\nevent: check-passed\n")
+	assert.Contains(t, string(body), "event: job-finished\ndata: {\"id\":\""+job.ID+"\",\"state\":\"completed\"")
+
+	// The events received before reconnecting are not sent again.
+	req, err := http.NewRequest(http.MethodGet, server.URL+APIPrefix+"/jobs/"+job.ID+"/events", http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "run-started")
+	assert.Contains(t, string(body), "id: 2\nevent: check-passed\n")
+}
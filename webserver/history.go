@@ -0,0 +1,183 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package webserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/baseline"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/runhistory"
+)
+
+const (
+	// Run history database file in the output folder.
+	historyFileName = "history.db"
+
+	// Query parameters of the runs search and comparison.
+	workloadQueryParam  = "workload"
+	namespaceQueryParam = "namespace"
+	testIDQueryParam    = "testId"
+	testStateQueryParam = "testState"
+	baselineQueryParam  = "baseline"
+)
+
+// historyAPI serves the REST API of the run history.
+type historyAPI struct {
+	history *runhistory.Store
+}
+
+// runsComparison is the comparison of the results of a run with a baseline run.
+type runsComparison struct {
+	Baseline runhistory.Run  `json:"baseline"`
+	Run      runhistory.Run  `json:"run"`
+	Report   baseline.Report `json:"report"`
+}
+
+func runErrorStatus(err error) int {
+	if errors.Is(err, runhistory.ErrRunNotFound) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
+
+func (api *historyAPI) install(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+APIPrefix+"/runs", api.listRuns)
+	mux.HandleFunc("GET "+APIPrefix+"/runs/{id}", api.getRun)
+	mux.HandleFunc("DELETE "+APIPrefix+"/runs/{id}", api.deleteRun)
+	mux.HandleFunc("GET "+APIPrefix+"/runs/{id}/claim", api.getRunClaim)
+	mux.HandleFunc("GET "+APIPrefix+"/runs/{id}/compare", api.compareRuns)
+}
+
+// getUserRun returns the run with the ID, if its user can access it. The runs of other users are
+// not found, as their jobs.
+func (api *historyAPI) getUserRun(r *http.Request, id string) (runhistory.Run, error) {
+	run, err := api.history.Get(id)
+	if err != nil {
+		return runhistory.Run{}, err
+	}
+
+	if run.Owner != getUser(r) {
+		return runhistory.Run{}, runhistory.ErrRunNotFound
+	}
+
+	return run, nil
+}
+
+// listRuns returns the runs of the user, from the latest one, selected by the workload, namespace
+// and test ID (and its result state) query parameters.
+func (api *historyAPI) listRuns(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	runs, err := api.history.List(runhistory.Query{
+		Owner:     getUser(r),
+		Workload:  query.Get(workloadQueryParam),
+		Namespace: query.Get(namespaceQueryParam),
+		TestID:    query.Get(testIDQueryParam),
+		TestState: query.Get(testStateQueryParam),
+	})
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, runs)
+}
+
+func (api *historyAPI) getRun(w http.ResponseWriter, r *http.Request) {
+	run, err := api.getUserRun(r, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, runErrorStatus(err), err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, run)
+}
+
+func (api *historyAPI) deleteRun(w http.ResponseWriter, r *http.Request) {
+	run, err := api.getUserRun(r, r.PathValue("id"))
+	if err == nil {
+		err = api.history.Delete(run.ID)
+	}
+	if err != nil {
+		writeAPIError(w, runErrorStatus(err), err)
+		return
+	}
+
+	log.Info("Run %s removed from the history", run.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (api *historyAPI) getRunClaim(w http.ResponseWriter, r *http.Request) {
+	run, err := api.getUserRun(r, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, runErrorStatus(err), err)
+		return
+	}
+
+	claimData, err := api.history.GetClaim(run.ID)
+	if err != nil {
+		writeAPIError(w, runErrorStatus(err), err)
+		return
+	}
+
+	w.Header().Set(contentTypeHeaderName, claimFileContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", run.ID+"-"+claimFileName))
+	if _, err := w.Write(claimData); err != nil {
+		log.Error("Failed to write the claim file of run %s: %v", run.ID, err)
+	}
+}
+
+// getRunResults returns the run and the test case results of its claim file.
+func (api *historyAPI) getRunResults(r *http.Request, id string) (runhistory.Run, map[string]claim.Result, error) {
+	run, err := api.getUserRun(r, id)
+	if err != nil {
+		return run, nil, err
+	}
+
+	claimData, err := api.history.GetClaim(run.ID)
+	if err != nil {
+		return run, nil, err
+	}
+
+	claimFile := claim.Root{}
+	if err := json.Unmarshal(claimData, &claimFile); err != nil {
+		return run, nil, fmt.Errorf("failed to parse the claim file of run %s: %w", run.ID, err)
+	}
+	if claimFile.Claim == nil {
+		return run, nil, fmt.Errorf("the claim file of run %s has no claim", run.ID)
+	}
+
+	return run, claimFile.Claim.Results, nil
+}
+
+// compareRuns compares the results of the run with the ones of the baseline query parameter run,
+// as the claim compare command does with --baseline.
+func (api *historyAPI) compareRuns(w http.ResponseWriter, r *http.Request) {
+	baselineID := r.URL.Query().Get(baselineQueryParam)
+	if baselineID == "" {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("the %s query parameter is required", baselineQueryParam))
+		return
+	}
+
+	baselineRun, baselineResults, err := api.getRunResults(r, baselineID)
+	if err != nil {
+		writeAPIError(w, runErrorStatus(err), err)
+		return
+	}
+
+	run, results, err := api.getRunResults(r, r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, runErrorStatus(err), err)
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, runsComparison{
+		Baseline: baselineRun,
+		Run:      run,
+		Report:   *baseline.GetReport(baselineResults, results),
+	})
+}
@@ -0,0 +1,133 @@
+const api = '/api/v1/runs';
+
+/** @type {string[]} IDs of the runs selected to compare, the baseline first. */
+let selected = [];
+
+function cell(row, text, className) {
+  const td = row.insertCell();
+  td.textContent = text;
+  if (className) td.className = className;
+  return td;
+}
+
+function summaryText(summary) {
+  return Object.entries(summary || {}).map(([state, count]) => `${count} ${state}`).join(', ');
+}
+
+async function getJSON(url) {
+  const response = await fetch(url);
+  const body = await response.json();
+  if (!response.ok) throw new Error(body.error || response.statusText);
+  return body;
+}
+
+function updateCompareButton() {
+  document.getElementById('compare').disabled = selected.length !== 2;
+}
+
+async function deleteRun(id) {
+  if (!confirm(`Remove run ${id} from the history?`)) return;
+  const response = await fetch(`${api}/${id}`, { method: 'DELETE' });
+  if (!response.ok) {
+    alert(`Failed to remove run ${id}: ${response.statusText}`);
+    return;
+  }
+  await search();
+}
+
+async function search() {
+  const params = new URLSearchParams();
+  for (const [name, value] of new FormData(document.getElementById('search'))) {
+    if (value) params.append(name, value);
+  }
+
+  const tbody = document.getElementById('runs');
+  tbody.replaceChildren();
+  selected = [];
+  updateCompareButton();
+
+  let runs;
+  try {
+    runs = await getJSON(`${api}?${params}`);
+  } catch (error) {
+    cell(tbody.insertRow(), `Failed to get the runs: ${error.message}`).colSpan = 8;
+    return;
+  }
+
+  if (runs.length === 0) {
+    cell(tbody.insertRow(), 'No runs found.').colSpan = 8;
+    return;
+  }
+
+  for (const run of runs) {
+    const row = tbody.insertRow();
+
+    const checkbox = document.createElement('input');
+    checkbox.type = 'checkbox';
+    checkbox.addEventListener('change', () => {
+      selected = checkbox.checked ? [...selected, run.id] : selected.filter(id => id !== run.id);
+      updateCompareButton();
+    });
+    row.insertCell().append(checkbox);
+
+    const link = document.createElement('a');
+    link.href = `${api}/${run.id}/claim`;
+    link.textContent = run.id;
+    link.title = 'Download the claim file';
+    row.insertCell().append(link);
+
+    cell(row, new Date(run.startTime).toLocaleString());
+    cell(row, run.labelFilter);
+    cell(row, run.error ? `${run.state}: ${run.error}` : run.state, run.state);
+    cell(row, summaryText(run.summary));
+    cell(row, (run.workloads || []).map(w => `${w.kind} ${w.namespace}/${w.name}`).join(', '));
+
+    const remove = document.createElement('button');
+    remove.textContent = 'Remove';
+    remove.addEventListener('click', () => deleteRun(run.id));
+    row.insertCell().append(remove);
+  }
+}
+
+async function compare() {
+  // The run selected first is the baseline.
+  const [baseline, run] = selected;
+  const section = document.getElementById('comparison');
+  const tbody = document.getElementById('differences');
+  tbody.replaceChildren();
+  section.hidden = false;
+
+  let comparison;
+  try {
+    comparison = await getJSON(`${api}/${run}/compare?baseline=${baseline}`);
+  } catch (error) {
+    document.getElementById('comparison-title').textContent = `Failed to compare the runs: ${error.message}`;
+    return;
+  }
+
+  document.getElementById('comparison-title').textContent = `Run ${run} against baseline ${baseline}`;
+  document.getElementById('comparison-summary').textContent =
+    `Baseline: ${summaryText(comparison.baseline.summary)}. Run: ${summaryText(comparison.run.summary)}. ` +
+    `Regressions: ${comparison.report.regressions}.`;
+
+  for (const tc of comparison.report.testCases) {
+    const row = tbody.insertRow();
+    cell(row, tc.name);
+    cell(row, tc.classification, tc.regression ? 'regression' : '');
+    cell(row, tc.baselineResult, tc.baselineResult);
+    cell(row, tc.result, tc.result);
+    cell(row, (tc.newNonCompliantObjects || []).join(', '));
+    cell(row, (tc.fixedNonCompliantObjects || []).join(', '));
+  }
+  if (comparison.report.testCases.length === 0) {
+    cell(tbody.insertRow(), 'No differences found.').colSpan = 6;
+  }
+}
+
+document.getElementById('search').addEventListener('submit', event => {
+  event.preventDefault();
+  search();
+});
+document.getElementById('compare').addEventListener('click', compare);
+
+search();
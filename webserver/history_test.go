@@ -0,0 +1,182 @@
+package webserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/runhistory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClaim returns a claim file of the tnf/test deployment with the given test cases results.
+func newTestClaim(crdStatusState, sshDaemonsState string) string {
+	result := func(id, state string) string {
+		return fmt.Sprintf(`{"state":%q,"testID":{"id":%q,"suite":"test","tags":"common"},"capturedTestOutput":"",
+			"catalogInfo":{"bestPracticeReference":"","description":"","exceptionProcess":"","remediation":""},
+			"categoryClassification":{},"checkDetails":"","duration":0,"failureLineContent":"","failureLocation":"",
+			"skipReason":"","startTime":"","endTime":""}`, state, id)
+	}
+
+	return `{"claim":{
+	"configurations":{"testNamespaces":["tnf"],"testDeployments":[{"metadata":{"name":"test","namespace":"tnf"}}]},
+	"metadata":{"startTime":"2026-10-17 00:00:00 +0000 UTC","endTime":"2026-10-17 00:10:00 +0000 UTC"},
+	"nodes":{},
+	"versions":{"certSuite":"v5.6.0","claimFormat":"v0.5.0"},
+	"results":{
+		"observability-crd-status":` + result("observability-crd-status", crdStatusState) + `,
+		"access-control-ssh-daemons":` + result("access-control-ssh-daemons", sshDaemonsState) + `
+	}
+}}`
+}
+
+var (
+	testBaselineClaim = newTestClaim("passed", "failed")
+	testRunClaim      = newTestClaim("failed", "passed")
+)
+
+func openTestHistory(t *testing.T) *runhistory.Store {
+	t.Helper()
+
+	history, err := runhistory.Open(filepath.Join(t.TempDir(), historyFileName), runhistory.RetentionPolicy{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = history.Close() })
+	return history
+}
+
+func addTestHistoryRun(t *testing.T, history *runhistory.Store, id, owner, claimData string, storedAt time.Time) {
+	t.Helper()
+
+	run, err := runhistory.NewRun([]byte(claimData))
+	require.NoError(t, err)
+	run.ID = id
+	run.Owner = owner
+	run.StoredAt = storedAt
+	require.NoError(t, history.Add(run, []byte(claimData)))
+}
+
+func TestJobQueueStoresRuns(t *testing.T) {
+	runner := newFakeRunner()
+	history := openTestHistory(t)
+	q, err := NewJobQueue(t.TempDir(), runner, history)
+	require.NoError(t, err)
+
+	job, err := q.Submit("alice", nil, nil, "observability")
+	require.NoError(t, err)
+	<-runner.started
+	runner.release <- nil
+	_, err = q.Wait(job.ID)
+	require.NoError(t, err)
+
+	run, err := history.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", run.Owner)
+	assert.Equal(t, "observability", run.LabelsFilter)
+	assert.Equal(t, JobStateCompleted, run.State)
+	claimData, err := history.GetClaim(job.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"claim":{}}`, string(claimData))
+}
+
+func TestHistoryAPI(t *testing.T) {
+	history := openTestHistory(t)
+	now := time.Now().UTC()
+	addTestHistoryRun(t, history, "baseline", "", testBaselineClaim, now.Add(-time.Hour))
+	addTestHistoryRun(t, history, "run", "", testRunClaim, now)
+
+	mux := http.NewServeMux()
+	api := historyAPI{history: history}
+	api.install(mux)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	get := func(path string) *http.Response {
+		resp, err := http.Get(server.URL + APIPrefix + path)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	runs := []runhistory.Run{}
+	decodeTestResponse(t, get("/runs?workload=tnf/test"), &runs)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "run", runs[0].ID)
+	decodeTestResponse(t, get("/runs?testId=observability-crd-status&testState=failed"), &runs)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "run", runs[0].ID)
+	decodeTestResponse(t, get("/runs?namespace=default"), &runs)
+	assert.Empty(t, runs)
+
+	resp := get("/runs/baseline/claim")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, claimFileContentType, resp.Header.Get(contentTypeHeaderName))
+	claimData, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, testBaselineClaim, string(claimData))
+
+	comparison := runsComparison{}
+	resp = get("/runs/run/compare?baseline=baseline")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	decodeTestResponse(t, resp, &comparison)
+	assert.Equal(t, "baseline", comparison.Baseline.ID)
+	assert.Equal(t, "run", comparison.Run.ID)
+	assert.Equal(t, 1, comparison.Report.Regressions)
+	require.Len(t, comparison.Report.TestCases, 2)
+	assert.Equal(t, "access-control-ssh-daemons", comparison.Report.TestCases[0].Name)
+	assert.Equal(t, "fixed", comparison.Report.TestCases[0].Classification)
+	assert.Equal(t, "observability-crd-status", comparison.Report.TestCases[1].Name)
+	assert.Equal(t, "new-failure", comparison.Report.TestCases[1].Classification)
+
+	assert.Equal(t, http.StatusBadRequest, get("/runs/run/compare").StatusCode)
+	assert.Equal(t, http.StatusNotFound, get("/runs/run/compare?baseline=unknown").StatusCode)
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+APIPrefix+"/runs/baseline", http.NoBody)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, get("/runs/baseline").StatusCode)
+}
+
+func TestHistoryAPIUserVisibility(t *testing.T) {
+	history := openTestHistory(t)
+	addTestHistoryRun(t, history, "run", "token:alice", testRunClaim, time.Now().UTC())
+	tokens, err := NewTokenAuthenticator(writeCredentialsFile(t, "alice-token alice\nbob-token bob\n"))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := historyAPI{history: history}
+	api.install(mux)
+	server := httptest.NewServer(authenticate([]Authenticator{tokens}, mux))
+	t.Cleanup(server.Close)
+
+	do := func(method, token, path string) *http.Response {
+		req, err := http.NewRequest(method, server.URL+APIPrefix+path, http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	for _, path := range []string{"/runs/run", "/runs/run/claim", "/runs/run/compare?baseline=run"} {
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, "alice-token", path).StatusCode, path)
+		// Other users' runs are not found.
+		assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "bob-token", path).StatusCode, path)
+	}
+
+	runs := []runhistory.Run{}
+	decodeTestResponse(t, do(http.MethodGet, "bob-token", "/runs"), &runs)
+	assert.Empty(t, runs)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "bob-token", "/runs/run").StatusCode)
+
+	decodeTestResponse(t, do(http.MethodGet, "alice-token", "/runs"), &runs)
+	require.Len(t, runs, 1)
+}
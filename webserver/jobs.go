@@ -0,0 +1,381 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package webserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/certsuite"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/runhistory"
+)
+
+// States of the jobs.
+const (
+	JobStateQueued    = "queued"
+	JobStateRunning   = "running"
+	JobStateCompleted = "completed"
+	JobStateFailed    = "failed"
+	JobStateCanceled  = "canceled"
+)
+
+const (
+	jobsDirName       = "jobs"
+	jobIDBytes        = 8
+	jobInputFilePerms = 0o600
+	jobDirPerms       = 0o755
+
+	jobKubeconfigFileName = "kubeconfig"
+	jobConfigFileName     = "certsuite_config.yml"
+
+	// Length of the queue of submitted jobs that are not running yet.
+	maxQueuedJobs = 100
+)
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrJobFinished  = errors.New("job already finished")
+	ErrJobQueueFull = errors.New("too many queued jobs")
+)
+
+// Job is a certsuite run submitted to the web server.
+type Job struct {
+	ID    string `json:"id"`
+	State string `json:"state"`
+	// Owner is the user who submitted the job, the only one who can access it. It's empty when the
+	// web server has no authentication.
+	Owner        string     `json:"owner,omitempty"`
+	LabelsFilter string     `json:"labelFilter"`
+	SubmittedAt  time.Time  `json:"submittedAt"`
+	StartedAt    *time.Time `json:"startedAt,omitempty"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty"`
+	// Error of the run, if it failed, or the verdict of the --fail-on category if it failed.
+	Error string `json:"error,omitempty"`
+	// Results artifacts file name in the output directory, if it was created.
+	ArtifactsFile string `json:"artifactsFile,omitempty"`
+
+	// OutputDir is the directory of the job results, where nothing else is written.
+	OutputDir string `json:"-"`
+	// KubeconfigFile and ConfigFile are the job input files, removed once the job finishes, as they
+	// have credentials.
+	KubeconfigFile string `json:"-"`
+	ConfigFile     string `json:"-"`
+
+	inputDir        string
+	cancelRequested bool
+	done            chan struct{}
+	// Log output of the run, streamed to the web page. It's shared by the copies of the job.
+	logs *jobLogs
+}
+
+// jobLogs is the log output of a job, written by the run and read by the web page streams.
+type jobLogs struct {
+	lock sync.Mutex
+	data []byte
+}
+
+func (l *jobLogs) Write(p []byte) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.data = append(l.data, p...)
+	return len(p), nil
+}
+
+// NewReader returns a reader of the log output from its start, with its own cursor, so every
+// viewer of the job gets all the lines.
+func (l *jobLogs) NewReader() io.Reader {
+	return &jobLogsReader{logs: l}
+}
+
+// jobLogsReader reads the log output of a job from its cursor. It returns io.EOF once it has read
+// all the output written so far, and more output can be read once the run writes it.
+type jobLogsReader struct {
+	logs   *jobLogs
+	cursor int
+}
+
+func (r *jobLogsReader) Read(p []byte) (int, error) {
+	r.logs.lock.Lock()
+	defer r.logs.lock.Unlock()
+
+	if r.cursor >= len(r.logs.data) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.logs.data[r.cursor:])
+	r.cursor += n
+	return n, nil
+}
+
+// JobRunner runs the certsuite with the job's files, returning the results artifacts file path, if
+// any. It must return once CancelRun is called on it.
+type JobRunner interface {
+	Run(job *Job) (artifactsFile string, err error)
+	CancelRun()
+}
+
+// JobQueue runs the submitted jobs one at a time, in the order they were submitted, as the test
+// environment, the checks and their results are global to the certsuite process.
+type JobQueue struct {
+	lock    sync.Mutex
+	jobs    map[string]*Job
+	queue   chan *Job
+	jobsDir string
+	runner  JobRunner
+	// Run history where the finished jobs are stored, if any.
+	history *runhistory.Store
+}
+
+// NewJobQueue returns a job queue whose jobs outputs are saved in the jobs subdirectory of the
+// output folder, and starts running them. The finished jobs with a claim file are stored in the
+// run history, if not nil.
+func NewJobQueue(outputFolder string, runner JobRunner, history *runhistory.Store) (*JobQueue, error) {
+	jobsDir := filepath.Join(outputFolder, jobsDirName)
+	if err := os.MkdirAll(jobsDir, jobDirPerms); err != nil {
+		return nil, fmt.Errorf("failed to create the jobs directory %s: %w", jobsDir, err)
+	}
+
+	q := &JobQueue{
+		jobs:    map[string]*Job{},
+		queue:   make(chan *Job, maxQueuedJobs),
+		jobsDir: jobsDir,
+		runner:  runner,
+		history: history,
+	}
+	go q.runJobs()
+
+	return q, nil
+}
+
+func newJobID() (string, error) {
+	id := make([]byte, jobIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate the job id: %w", err)
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+// Submit queues a job of the owner with the kubeconfig and certsuite configuration file contents
+// and labels filter. The files are saved in a directory of the job, never in the shared
+// configuration file.
+func (q *JobQueue) Submit(owner string, kubeconfig, config []byte, labelsFilter string) (Job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	job := &Job{
+		ID:           id,
+		State:        JobStateQueued,
+		Owner:        owner,
+		LabelsFilter: labelsFilter,
+		SubmittedAt:  time.Now().UTC(),
+		OutputDir:    filepath.Join(q.jobsDir, id),
+		done:         make(chan struct{}),
+		logs:         &jobLogs{},
+	}
+
+	if err := os.MkdirAll(job.OutputDir, jobDirPerms); err != nil {
+		return Job{}, fmt.Errorf("failed to create the job output directory: %w", err)
+	}
+
+	job.inputDir, err = os.MkdirTemp("", "certsuite-job-"+id+"-")
+	if err != nil {
+		return Job{}, fmt.Errorf("failed to create the job input directory: %w", err)
+	}
+	job.KubeconfigFile = filepath.Join(job.inputDir, jobKubeconfigFileName)
+	job.ConfigFile = filepath.Join(job.inputDir, jobConfigFileName)
+	for file, content := range map[string][]byte{job.KubeconfigFile: kubeconfig, job.ConfigFile: config} {
+		if err := os.WriteFile(file, content, jobInputFilePerms); err != nil {
+			_ = os.RemoveAll(job.inputDir)
+			return Job{}, fmt.Errorf("failed to save the job input files: %w", err)
+		}
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	select {
+	case q.queue <- job:
+	default:
+		_ = os.RemoveAll(job.inputDir)
+		return Job{}, ErrJobQueueFull
+	}
+	q.jobs[id] = job
+
+	return *job, nil
+}
+
+// Get returns a copy of the job with the given ID.
+func (q *JobQueue) Get(id string) (Job, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	job, found := q.jobs[id]
+	if !found {
+		return Job{}, ErrJobNotFound
+	}
+
+	return *job, nil
+}
+
+// List returns a copy of the jobs, from the latest submitted one.
+func (q *JobQueue) List() []Job {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	jobs := make([]Job, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].SubmittedAt.After(jobs[j].SubmittedAt)
+	})
+
+	return jobs
+}
+
+// Running returns a copy of the running job, if any.
+func (q *JobQueue) Running() (Job, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, job := range q.jobs {
+		if job.State == JobStateRunning {
+			return *job, true
+		}
+	}
+
+	return Job{}, false
+}
+
+// Cancel cancels the job: a queued job won't run, and a running job is aborted, its claim file
+// having the results of the checks that completed.
+func (q *JobQueue) Cancel(id string) (Job, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	job, found := q.jobs[id]
+	if !found {
+		return Job{}, ErrJobNotFound
+	}
+
+	switch job.State {
+	case JobStateQueued:
+		// It's skipped when it's dequeued.
+		job.cancelRequested = true
+		q.finish(job, JobStateCanceled, "", "")
+	case JobStateRunning:
+		if !job.cancelRequested {
+			job.cancelRequested = true
+			q.runner.CancelRun()
+		}
+	default:
+		return *job, ErrJobFinished
+	}
+
+	return *job, nil
+}
+
+// Wait returns the job once it finishes.
+func (q *JobQueue) Wait(id string) (Job, error) {
+	q.lock.Lock()
+	job, found := q.jobs[id]
+	q.lock.Unlock()
+	if !found {
+		return Job{}, ErrJobNotFound
+	}
+
+	<-job.done
+
+	return q.Get(id)
+}
+
+// finish sets the final state of the job. The lock must be held.
+func (q *JobQueue) finish(job *Job, state, errMsg, artifactsFile string) {
+	now := time.Now().UTC()
+	job.State = state
+	job.Error = errMsg
+	job.ArtifactsFile = artifactsFile
+	job.FinishedAt = &now
+
+	_ = os.RemoveAll(job.inputDir)
+	close(job.done)
+}
+
+func (q *JobQueue) runJobs() {
+	for job := range q.queue {
+		q.lock.Lock()
+		if job.cancelRequested {
+			q.lock.Unlock()
+			continue
+		}
+		now := time.Now().UTC()
+		job.State = JobStateRunning
+		job.StartedAt = &now
+		q.lock.Unlock()
+
+		artifactsFile, err := q.runner.Run(job)
+
+		q.lock.Lock()
+		state, errMsg := JobStateCompleted, ""
+		switch {
+		case job.cancelRequested:
+			state = JobStateCanceled
+		case errors.Is(err, certsuite.ErrMandatoryTestCasesFailed):
+			errMsg = err.Error()
+		case err != nil:
+			state, errMsg = JobStateFailed, err.Error()
+		}
+		if artifactsFile != "" {
+			artifactsFile = filepath.Base(artifactsFile)
+		}
+		q.lock.Unlock()
+
+		// The job is stored before it's finished, so that it's in the history once waited for.
+		if err := q.storeRun(job, state, errMsg); err != nil {
+			log.Error("Failed to store job %s in the run history: %v", job.ID, err)
+		}
+
+		q.lock.Lock()
+		q.finish(job, state, errMsg, artifactsFile)
+		q.lock.Unlock()
+	}
+}
+
+// storeRun stores the job in the run history with its claim file, if the run wrote it.
+func (q *JobQueue) storeRun(job *Job, state, errMsg string) error {
+	if q.history == nil {
+		return nil
+	}
+
+	claimData, err := os.ReadFile(filepath.Join(job.OutputDir, claimFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	run, err := runhistory.NewRun(claimData)
+	if err != nil {
+		return err
+	}
+	run.ID = job.ID
+	run.Owner = job.Owner
+	run.LabelsFilter = job.LabelsFilter
+	run.State = state
+	run.Error = errMsg
+
+	return q.history.Add(run, claimData)
+}
@@ -0,0 +1,174 @@
+package webserver
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/certsuite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEvents = `{"seq":1,"type":"run-started"}
+{"seq":2,"type":"check-passed","checkId":"observability-crd-status"}
+`
+
+// fakeRunner runs the jobs until they're released or canceled.
+type fakeRunner struct {
+	started chan *Job
+	release chan error
+	cancel  chan struct{}
+}
+
+func newFakeRunner() *fakeRunner {
+	return &fakeRunner{
+		started: make(chan *Job, 1),
+		release: make(chan error, 1),
+		cancel:  make(chan struct{}, 1),
+	}
+}
+
+func (r *fakeRunner) Run(job *Job) (string, error) {
+	r.started <- job
+
+	artifactsFile := filepath.Join(job.OutputDir, "20261017-cnf-test-results.tar.gz")
+	if err := os.WriteFile(artifactsFile, []byte("artifacts"), jobInputFilePerms); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(job.OutputDir, claimFileName), []byte(`{"claim":{}}`), jobInputFilePerms); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(job.OutputDir, eventsFileName), []byte(testEvents), jobInputFilePerms); err != nil {
+		return "", err
+	}
+
+	select {
+	case err := <-r.release:
+		return artifactsFile, err
+	case <-r.cancel:
+		return artifactsFile, nil
+	}
+}
+
+func (r *fakeRunner) CancelRun() {
+	r.cancel <- struct{}{}
+}
+
+func TestJobQueueRun(t *testing.T) {
+	runner := newFakeRunner()
+	q, err := NewJobQueue(t.TempDir(), runner, nil)
+	require.NoError(t, err)
+
+	job, err := q.Submit("alice", []byte("kubeconfig"), []byte("config"), "observability")
+	require.NoError(t, err)
+	assert.Equal(t, JobStateQueued, job.State)
+	assert.Equal(t, "observability", job.LabelsFilter)
+
+	running := <-runner.started
+	// The job has its own copy of the input files.
+	config, err := os.ReadFile(running.ConfigFile)
+	require.NoError(t, err)
+	assert.Equal(t, "config", string(config))
+	job, err = q.Get(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStateRunning, job.State)
+	// The copies of the job share its log output, which every reader reads from its own cursor.
+	_, err = running.logs.Write([]byte("line\n"))
+	require.NoError(t, err)
+	reader := job.logs.NewReader()
+	logs, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "line\n", string(logs))
+	logs, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Empty(t, logs)
+	_, err = running.logs.Write([]byte("next\n"))
+	require.NoError(t, err)
+	logs, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "next\n", string(logs))
+	logs, err = io.ReadAll(job.logs.NewReader())
+	require.NoError(t, err)
+	assert.Equal(t, "line\nnext\n", string(logs))
+
+	runner.release <- nil
+	job, err = q.Wait(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStateCompleted, job.State)
+	assert.Empty(t, job.Error)
+	assert.Equal(t, "20261017-cnf-test-results.tar.gz", job.ArtifactsFile)
+	assert.NotNil(t, job.FinishedAt)
+	// The input files are removed once the job finishes.
+	assert.NoFileExists(t, job.KubeconfigFile)
+	assert.NoFileExists(t, job.ConfigFile)
+
+	_, err = q.Cancel(job.ID)
+	assert.ErrorIs(t, err, ErrJobFinished)
+	_, err = q.Get("unknown")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestJobQueueRunErrors(t *testing.T) {
+	runner := newFakeRunner()
+	q, err := NewJobQueue(t.TempDir(), runner, nil)
+	require.NoError(t, err)
+
+	failed, err := q.Submit("", nil, nil, "all")
+	require.NoError(t, err)
+	<-runner.started
+	runner.release <- errors.New("no cluster")
+	failed, err = q.Wait(failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStateFailed, failed.State)
+	assert.Equal(t, "no cluster", failed.Error)
+
+	// Failed mandatory test cases don't fail the job.
+	completed, err := q.Submit("", nil, nil, "all")
+	require.NoError(t, err)
+	<-runner.started
+	runner.release <- certsuite.ErrMandatoryTestCasesFailed
+	completed, err = q.Wait(completed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStateCompleted, completed.State)
+	assert.Equal(t, certsuite.ErrMandatoryTestCasesFailed.Error(), completed.Error)
+
+	jobs := q.List()
+	require.Len(t, jobs, 2)
+	assert.Equal(t, completed.ID, jobs[0].ID)
+	assert.Equal(t, failed.ID, jobs[1].ID)
+}
+
+func TestJobQueueCancel(t *testing.T) {
+	runner := newFakeRunner()
+	q, err := NewJobQueue(t.TempDir(), runner, nil)
+	require.NoError(t, err)
+
+	running, err := q.Submit("", nil, nil, "all")
+	require.NoError(t, err)
+	<-runner.started
+	queued, err := q.Submit("", nil, nil, "all")
+	require.NoError(t, err)
+
+	queued, err = q.Cancel(queued.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStateCanceled, queued.State)
+	assert.NoFileExists(t, queued.ConfigFile)
+
+	_, err = q.Cancel(running.ID)
+	require.NoError(t, err)
+	running, err = q.Wait(running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStateCanceled, running.State)
+	// The results of the checks that completed are kept.
+	assert.NotEmpty(t, running.ArtifactsFile)
+
+	// The canceled queued job is never run.
+	select {
+	case job := <-runner.started:
+		t.Fatalf("unexpected run of job %s", job.ID)
+	default:
+	}
+}
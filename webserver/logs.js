@@ -1,6 +1,8 @@
 import '@rhds/elements/rh-code-block/rh-code-block.js';
 
-const socket = new WebSocket('ws://localhost:8084/logstream');
+const socket = new WebSocket(
+  `${location.protocol === 'https:' ? 'wss' : 'ws'}://${location.host}/logstream`,
+);
 const code = document
   .getElementById('logs')
   .querySelector('rh-code-block');
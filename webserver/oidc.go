@@ -0,0 +1,327 @@
+// Copyright (C) 2026 Red Hat, Inc.
+package webserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	oidcHTTPTimeout   = 10 * time.Second
+	// Tolerance of the clock differences with the issuer when checking the token times.
+	oidcClockSkew = time.Minute
+	// Minimum time between the fetches of the issuer keys, to find the keys of unknown IDs.
+	oidcKeysRefreshInterval = time.Minute
+	// DefaultOIDCUsernameClaim is the ID token claim with the user name by default.
+	DefaultOIDCUsernameClaim = "sub"
+)
+
+var errUnknownKeyID = errors.New("unknown key id")
+
+// oidcAuthenticator authenticates the requests with the OIDC ID tokens of an issuer for a client,
+// sent as bearer tokens.
+type oidcAuthenticator struct {
+	issuerURL     string
+	clientID      string
+	usernameClaim string
+	jwksURL       string
+	client        *http.Client
+
+	lock          sync.Mutex
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	// RSA keys.
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC keys.
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// NewOIDCAuthenticator returns an authenticator of the ID tokens of the issuer for the client, whose
+// user is the value of the username claim. The issuer keys are got from its discovery document.
+func NewOIDCAuthenticator(issuerURL, clientID, usernameClaim string) (Authenticator, error) {
+	a := &oidcAuthenticator{
+		issuerURL:     strings.TrimSuffix(issuerURL, "/"),
+		clientID:      clientID,
+		usernameClaim: usernameClaim,
+		client:        &http.Client{Timeout: oidcHTTPTimeout},
+	}
+
+	discovery := struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}{}
+	if err := a.getJSON(a.issuerURL+oidcDiscoveryPath, &discovery); err != nil {
+		return nil, fmt.Errorf("failed to get the OIDC discovery document of %s: %w", issuerURL, err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != a.issuerURL {
+		return nil, fmt.Errorf("OIDC discovery document of %s is of another issuer: %s", issuerURL, discovery.Issuer)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC discovery document of %s has no jwks_uri", issuerURL)
+	}
+	a.jwksURL = discovery.JWKSURI
+
+	if err := a.fetchKeys(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *oidcAuthenticator) getJSON(url string, v any) error {
+	resp, err := a.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func decodeBase64URLInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (key *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := decodeBase64URLInt(key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URLInt(key.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if key.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", key.Crv)
+		}
+		x, err := decodeBase64URLInt(key.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URLInt(key.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %s", key.Kty)
+}
+
+// fetchKeys gets the signing keys of the issuer. The lock must be held, but when creating it.
+func (a *oidcAuthenticator) fetchKeys() error {
+	jwks := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	if err := a.getJSON(a.jwksURL, &jwks); err != nil {
+		return fmt.Errorf("failed to get the OIDC keys of %s: %w", a.issuerURL, err)
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for i := range jwks.Keys {
+		if jwks.Keys[i].Use != "" && jwks.Keys[i].Use != "sig" {
+			continue
+		}
+		// The keys of unsupported types are ignored, as the tokens aren't signed with them.
+		if key, err := jwks.Keys[i].publicKey(); err == nil {
+			keys[jwks.Keys[i].Kid] = key
+		}
+	}
+
+	a.keys = keys
+	a.keysFetchedAt = time.Now()
+	return nil
+}
+
+// getKey returns the key with the ID, fetching the issuer keys again if not found, as they may
+// have been rotated.
+func (a *oidcAuthenticator) getKey(kid string) (crypto.PublicKey, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if key, found := a.keys[kid]; found {
+		return key, nil
+	}
+
+	if time.Since(a.keysFetchedAt) < oidcKeysRefreshInterval {
+		return nil, fmt.Errorf("%w %q", errUnknownKeyID, kid)
+	}
+	if err := a.fetchKeys(); err != nil {
+		return nil, err
+	}
+
+	if key, found := a.keys[kid]; found {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w %q", errUnknownKeyID, kid)
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signedData, signature []byte) error {
+	digest := sha256.Sum256(signedData)
+
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key is not an RSA key")
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature)
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		const es256SignatureSize = 64
+		if !ok || len(signature) != es256SignatureSize {
+			return fmt.Errorf("key is not an EC key or wrong signature size")
+		}
+		r := new(big.Int).SetBytes(signature[:es256SignatureSize/2])
+		s := new(big.Int).SetBytes(signature[es256SignatureSize/2:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported signing algorithm %q", alg)
+}
+
+// audience is the aud claim, which is a string or an array of them.
+type audience []string
+
+func (aud *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*aud = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*aud = multiple
+	return nil
+}
+
+// verifyIDToken returns the claims of the ID token once its signature, issuer, audience and
+// validity times are checked.
+func (a *oidcAuthenticator) verifyIDToken(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:mnd // header, payload and signature
+		return nil, errors.New("malformed token")
+	}
+
+	header := struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}{}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed token header: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature: %w", err)
+	}
+
+	key, err := a.getKey(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, fmt.Errorf("invalid token signature: %w", err)
+	}
+
+	claims := struct {
+		Issuer    string   `json:"iss"`
+		Audience  audience `json:"aud"`
+		ExpiresAt *int64   `json:"exp"`
+		NotBefore *int64   `json:"nbf"`
+	}{}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed token claims: %w", err)
+	}
+
+	now := time.Now()
+	switch {
+	case strings.TrimSuffix(claims.Issuer, "/") != a.issuerURL:
+		return nil, fmt.Errorf("token of another issuer: %s", claims.Issuer)
+	case !slices.Contains(claims.Audience, a.clientID):
+		return nil, fmt.Errorf("token of another audience: %v", claims.Audience)
+	case claims.ExpiresAt == nil || now.After(time.Unix(*claims.ExpiresAt, 0).Add(oidcClockSkew)):
+		return nil, errors.New("token expired")
+	case claims.NotBefore != nil && now.Add(oidcClockSkew).Before(time.Unix(*claims.NotBefore, 0)):
+		return nil, errors.New("token not valid yet")
+	}
+
+	allClaims := map[string]any{}
+	if err := decodeJWTPart(parts[1], &allClaims); err != nil {
+		return nil, fmt.Errorf("malformed token claims: %w", err)
+	}
+
+	return allClaims, nil
+}
+
+func decodeJWTPart(part string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (a *oidcAuthenticator) Authenticate(r *http.Request) (string, error) {
+	token, found := getBearerToken(r)
+	if !found {
+		return "", ErrNoCredentials
+	}
+
+	claims, err := a.verifyIDToken(token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	user, ok := claims[a.usernameClaim].(string)
+	if !ok || user == "" {
+		return "", fmt.Errorf("%w: token has no %s claim", ErrInvalidCredentials, a.usernameClaim)
+	}
+
+	return oidcUserPrefix + user, nil
+}
+
+func (a *oidcAuthenticator) Challenge() string {
+	return `Bearer realm="certsuite"`
+}
@@ -0,0 +1,164 @@
+package webserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOIDCClientID = "certsuite"
+
+// oidcStub is a local OIDC issuer, serving its discovery document and keys.
+type oidcStub struct {
+	server *httptest.Server
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+}
+
+func newOIDCStub(t *testing.T) *oidcStub {
+	t.Helper()
+
+	stub := &oidcStub{}
+	var err error
+	stub.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	stub.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, map[string]string{"issuer": stub.server.URL, "jwks_uri": stub.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+		writeJSONResponse(w, http.StatusOK, map[string]any{"keys": []jsonWebKey{
+			{Kid: "rsa", Kty: "RSA", Use: "sig", N: encode(stub.rsaKey.N.Bytes()), E: encode(big.NewInt(int64(stub.rsaKey.E)).Bytes())},
+			{Kid: "ec", Kty: "EC", Crv: "P-256", X: encode(stub.ecKey.X.FillBytes(make([]byte, 32))), Y: encode(stub.ecKey.Y.FillBytes(make([]byte, 32)))},
+			{Kid: "enc", Kty: "RSA", Use: "enc", N: encode(stub.rsaKey.N.Bytes()), E: "AQAB"},
+		}})
+	})
+	stub.server = httptest.NewServer(mux)
+	t.Cleanup(stub.server.Close)
+
+	return stub
+}
+
+// idToken returns an ID token with the claims, signed with the key of the ID.
+func (stub *oidcStub) idToken(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+
+	alg := "RS256"
+	if kid == "ec" {
+		alg = "ES256"
+	}
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signedData := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signedData))
+
+	var signature []byte
+	if kid == "ec" {
+		r, s, err := ecdsa.Sign(rand.Reader, stub.ecKey, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	} else {
+		signature, err = rsa.SignPKCS1v15(rand.Reader, stub.rsaKey, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	}
+
+	return signedData + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (stub *oidcStub) claims(overrides map[string]any) map[string]any {
+	claims := map[string]any{
+		"iss":   stub.server.URL,
+		"aud":   testOIDCClientID,
+		"sub":   "1234",
+		"email": "alice@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range overrides {
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	stub := newOIDCStub(t)
+	authenticator, err := NewOIDCAuthenticator(stub.server.URL, testOIDCClientID, "email")
+	require.NoError(t, err)
+
+	for _, kid := range []string{"rsa", "ec"} {
+		user, err := authenticator.Authenticate(newAuthRequest(t, "Bearer "+stub.idToken(t, kid, stub.claims(nil))))
+		require.NoError(t, err, kid)
+		assert.Equal(t, "oidc:alice@example.com", user)
+	}
+
+	user, err := authenticator.Authenticate(newAuthRequest(t, "Bearer "+stub.idToken(t, "rsa", stub.claims(map[string]any{"aud": []string{"other", testOIDCClientID}}))))
+	require.NoError(t, err)
+	assert.Equal(t, "oidc:alice@example.com", user)
+
+	testCases := []struct {
+		name          string
+		token         string
+		expectedError string
+	}{
+		{name: "expired", token: stub.idToken(t, "rsa", stub.claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})), expectedError: "token expired"},
+		{name: "not valid yet", token: stub.idToken(t, "rsa", stub.claims(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()})), expectedError: "token not valid yet"},
+		{name: "other issuer", token: stub.idToken(t, "rsa", stub.claims(map[string]any{"iss": "https://issuer.example.com"})), expectedError: "token of another issuer"},
+		{name: "other audience", token: stub.idToken(t, "rsa", stub.claims(map[string]any{"aud": "other"})), expectedError: "token of another audience"},
+		{name: "no username", token: stub.idToken(t, "rsa", stub.claims(map[string]any{"email": ""})), expectedError: "token has no email claim"},
+		{name: "encryption key", token: stub.idToken(t, "enc", stub.claims(nil)), expectedError: `unknown key id "enc"`},
+		{name: "malformed", token: "not-a-jwt", expectedError: "malformed token"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := authenticator.Authenticate(newAuthRequest(t, "Bearer "+tc.token))
+			assert.ErrorIs(t, err, ErrInvalidCredentials)
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+
+	// The claims of a token with the signature of another one.
+	token := strings.Split(stub.idToken(t, "rsa", stub.claims(map[string]any{"email": "mallory@example.com"})), ".")
+	signedToken := strings.Split(stub.idToken(t, "rsa", stub.claims(nil)), ".")
+	_, err = authenticator.Authenticate(newAuthRequest(t, "Bearer "+strings.Join([]string{token[0], token[1], signedToken[2]}, ".")))
+	assert.ErrorContains(t, err, "invalid token signature")
+
+	_, err = authenticator.Authenticate(newAuthRequest(t, ""))
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestNewOIDCAuthenticatorErrors(t *testing.T) {
+	stub := newOIDCStub(t)
+
+	_, err := NewOIDCAuthenticator(stub.server.URL+"/other", testOIDCClientID, DefaultOIDCUsernameClaim)
+	assert.ErrorContains(t, err, "failed to get the OIDC discovery document")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, map[string]string{"issuer": stub.server.URL, "jwks_uri": stub.server.URL + "/keys"})
+	})
+	impostor := httptest.NewServer(mux)
+	defer impostor.Close()
+	_, err = NewOIDCAuthenticator(impostor.URL, testOIDCClientID, DefaultOIDCUsernameClaim)
+	assert.ErrorContains(t, err, "is of another issuer")
+}
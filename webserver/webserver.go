@@ -8,11 +8,10 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
-	"io"
-	"io/fs"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -21,10 +20,13 @@ import (
 	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/clientsholder"
 	"github.com/redhat-best-practices-for-k8s/certsuite/internal/log"
+	"github.com/redhat-best-practices-for-k8s/certsuite/internal/results"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/arrayhelper"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/certsuite"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/checksdb"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/provider"
+	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/runhistory"
 	"github.com/redhat-best-practices-for-k8s/certsuite/tests/identifiers"
 	"github.com/robert-nix/ansihtml"
 
@@ -35,6 +37,13 @@ type webServerContextKey string
 
 const (
 	logTimeout = 1000
+	// Time between the checks of the running job and its new log lines.
+	logWaitInterval = 500 * time.Millisecond
+	// The log stream fails when the client doesn't answer the pings in logPongWait, or a message
+	// can't be sent in logWriteWait.
+	logPongWait   = 60 * time.Second
+	logPingPeriod = logPongWait * 9 / 10
+	logWriteWait  = 10 * time.Second
 
 	readTimeoutSeconds = 10
 )
@@ -58,7 +67,11 @@ var toast []byte
 //go:embed index.js
 var index []byte
 
-var buf *bytes.Buffer
+//go:embed history.html
+var historyHTML []byte
+
+//go:embed history.js
+var historyJS []byte
 
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
@@ -66,35 +79,106 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
-func logStreamHandler(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Info("WebSocket upgrade error: %v", err)
-		return
+// logStreamHandler returns the handler streaming the log of the running job, if the user owns it.
+func logStreamHandler(jobs *JobQueue) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Info("WebSocket upgrade error: %v", err)
+			return
+		}
+		defer conn.Close()
+		streamLogs(conn, jobs, getUser(r))
 	}
-	defer conn.Close()
-	// Create a scanner to read the log file line by line
+}
+
+func streamLogs(conn *websocket.Conn, jobs *JobQueue, user string) {
+	// The client sends nothing: the connection is only read to find out when it's closed, failed or
+	// no longer answers the pings.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+
+		_ = conn.SetReadDeadline(time.Now().Add(logPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(logPongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				log.Debug("Log stream connection closed: %v", err)
+				return
+			}
+		}
+	}()
+
+	pingTicker := time.NewTicker(logPingPeriod)
+	defer pingTicker.Stop()
+	waitTicker := time.NewTicker(logWaitInterval)
+	defer waitTicker.Stop()
+
+	// Each stream reads the log of the job from its own cursor. The lines of the last job are all
+	// sent before switching to the next one.
+	var (
+		jobID   string
+		reader  *bufio.Reader
+		partial []byte
+	)
 	for {
-		scanner := bufio.NewScanner(buf)
-		for scanner.Scan() {
-			line := scanner.Bytes()
-			fmt.Println(string(line))
-			line = append(ansihtml.ConvertToHTML(line), []byte("<br>")...)
-
-			// Send each log line to the client
-			if err := conn.WriteMessage(websocket.TextMessage, line); err != nil {
-				fmt.Println(err)
+		select {
+		case <-closed:
+			return
+		case <-pingTicker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(logWriteWait)); err != nil {
+				log.Debug("Failed to ping the log stream of job %s: %v", jobID, err)
+				return
+			}
+			continue
+		case <-waitTicker.C:
+		}
+
+		job, running := jobs.Running()
+		if reader == nil {
+			if !running || job.Owner != user {
+				continue
+			}
+			jobID = job.ID
+			reader = bufio.NewReader(job.logs.NewReader())
+			partial = nil
+		}
+		// Once the job is seen finished, its log has no more lines to wait for.
+		jobFinished := !running || job.ID != jobID
+
+		for {
+			line, err := reader.ReadBytes('\n')
+			partial = append(partial, line...)
+			if err != nil && (!jobFinished || len(partial) == 0) {
+				// The rest of the line is not written yet.
+				break
+			}
+
+			msg := append(ansihtml.ConvertToHTML(bytes.TrimSuffix(partial, []byte("\n"))), []byte("<br>")...)
+			partial = nil
+			if err := sendLogLine(conn, msg); err != nil {
+				log.Debug("Failed to send the log of job %s: %v", jobID, err)
 				return
 			}
 			time.Sleep(logTimeout)
 		}
-		if err := scanner.Err(); err != nil {
-			log.Info("Error reading log file: %v", err)
-			return
+
+		if jobFinished {
+			reader = nil
 		}
 	}
 }
 
+func sendLogLine(conn *websocket.Conn, line []byte) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(logWriteWait)); err != nil {
+		return err
+	}
+
+	return conn.WriteMessage(websocket.TextMessage, line)
+}
+
 type RequestedData struct {
 	SelectedOptions                      []string `json:"selectedOptions"`
 	TargetNameSpaces                     []string `json:"targetNameSpaces"`
@@ -127,7 +211,7 @@ type ResponseData struct {
 	Message string `json:"message"`
 }
 
-func installReqHandlers() {
+func installReqHandlers(jobs *JobQueue) {
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		// Set the content type to "text/html".
 		w.Header().Set("Content-Type", "text/html")
@@ -195,8 +279,22 @@ func installReqHandlers() {
 		}
 	})
 
+	http.HandleFunc("/history", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if _, err := w.Write(historyHTML); err != nil {
+			http.Error(w, "Failed to write response", http.StatusInternalServerError)
+		}
+	})
+
+	http.HandleFunc("/history.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/javascript")
+		if _, err := w.Write(historyJS); err != nil {
+			http.Error(w, "Failed to write response", http.StatusInternalServerError)
+		}
+	})
+
 	// Serve the static HTML file
-	http.HandleFunc("/logstream", logStreamHandler)
+	http.HandleFunc("/logstream", logStreamHandler(jobs))
 }
 
 func StartServer(outputFolder string) error {
@@ -210,130 +308,211 @@ func StartServer(outputFolder string) error {
 		},
 	}
 
-	installReqHandlers()
+	params := configuration.GetTestParameters()
+	authenticators, err := newAuthenticators(params)
+	if err != nil {
+		return err
+	}
+	if len(authenticators) > 0 {
+		server.Handler = authenticate(authenticators, http.DefaultServeMux)
+		// The browsers send the basic auth credentials to the web sockets of other sites too.
+		upgrader.CheckOrigin = isSameOrigin
+	} else {
+		log.Warn("The web server has no authentication, anyone who can reach it can run the certsuite and get the results")
+	}
 
-	http.HandleFunc("/runFunction", runHandler)
+	// The runs are kept in the history, as the output folders of the jobs may be removed.
+	history, err := runhistory.Open(filepath.Join(outputFolder, historyFileName), runhistory.RetentionPolicy{
+		MaxRuns: params.ServerHistoryMaxRuns,
+		MaxAge:  params.ServerHistoryMaxAge,
+	})
+	if err != nil {
+		return err
+	}
+	defer history.Close()
 
-	log.Info("Server is running on :8084...")
-	if err := server.ListenAndServe(); err != nil {
-		return fmt.Errorf("server listen error: %w", err)
+	jobs, err := NewJobQueue(outputFolder, certsuiteRunner{}, history)
+	if err != nil {
+		return err
 	}
 
-	return nil
-}
+	installReqHandlers(jobs)
 
-// Define an HTTP handler that triggers CERTSUITE tests
-//
-//nolint:funlen
-func runHandler(w http.ResponseWriter, r *http.Request) {
-	buf = bytes.NewBufferString("")
-	// The log output will be written to the log file and to this buffer buf
-	log.SetLogger(log.GetMultiLogger(buf))
+	api := jobsAPI{jobs: jobs, defaultConfigFile: params.ConfigFile}
+	api.install(http.DefaultServeMux)
+	historyAPI := historyAPI{history: history}
+	historyAPI.install(http.DefaultServeMux)
 
-	// Limit request body size to 32 MB to prevent memory exhaustion (gosec G120)
-	const maxBodySize = 32 << 20 // 32 MB
-	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	http.HandleFunc("/runFunction", runHandler(jobs))
 
-	jsonData := r.FormValue("jsonData") // "jsonData" is the name of the JSON input field
-	var data RequestedData
-	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
-		fmt.Println("Error:", err)
+	if params.ServerTLSCertFile != "" {
+		log.Info("Server is running on :8084 with TLS...")
+		err = server.ListenAndServeTLS(params.ServerTLSCertFile, params.ServerTLSKeyFile)
+	} else {
+		log.Info("Server is running on :8084...")
+		err = server.ListenAndServe()
 	}
-	flattenedOptions := data.SelectedOptions
-
-	// Get the file from the request
-	file, fileHeader, err := r.FormFile("kubeConfigPath") // "fileInput" is the name of the file input field
 	if err != nil {
-		http.Error(w, "Unable to retrieve file from form", http.StatusBadRequest)
-		return
+		return fmt.Errorf("server listen error: %w", err)
 	}
-	defer file.Close()
 
-	log.Info("Kubeconfig file name received: %s", fileHeader.Filename)
-	kubeconfigTempFile, err := os.CreateTemp("", "webserver-kubeconfig-*")
-	if err != nil {
-		http.Error(w, "Failed to create temp file to store the kubeconfig content.", http.StatusBadRequest)
-		return
+	return nil
+}
+
+// newAuthenticators returns the authenticators of the web server parameters, none if it has no
+// authentication.
+func newAuthenticators(params *configuration.TestParameters) ([]Authenticator, error) {
+	authenticators := []Authenticator{}
+
+	if params.ServerTokensFile != "" {
+		authenticator, err := NewTokenAuthenticator(params.ServerTokensFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, authenticator)
 	}
 
-	defer func() {
-		log.Info("Removing temporary kubeconfig file %s", kubeconfigTempFile.Name())
-		err = os.Remove(kubeconfigTempFile.Name())
+	if params.ServerHtpasswdFile != "" {
+		authenticator, err := NewHtpasswdAuthenticator(params.ServerHtpasswdFile)
 		if err != nil {
-			log.Error("Failed to remove temp kubeconfig file %s", kubeconfigTempFile.Name())
+			return nil, err
 		}
-	}()
+		authenticators = append(authenticators, authenticator)
+	}
 
-	_, err = io.Copy(kubeconfigTempFile, file)
-	if err != nil {
-		http.Error(w, "Unable to copy file", http.StatusInternalServerError)
-		return
+	if params.ServerOIDCIssuerURL != "" {
+		authenticator, err := NewOIDCAuthenticator(params.ServerOIDCIssuerURL, params.ServerOIDCClientID, params.ServerOIDCUsernameClaim)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, authenticator)
 	}
 
-	_ = kubeconfigTempFile.Close()
+	return authenticators, nil
+}
 
-	log.Info("Web Server kubeconfig file : %v (copied into %v)", fileHeader.Filename, kubeconfigTempFile.Name())
-	log.Info("Web Server Labels filter   : %v", flattenedOptions)
+// certsuiteRunner runs the jobs with the certsuite, whose configuration, test environment and
+// checks are reset before every job, as they are global to the process.
+type certsuiteRunner struct{}
 
-	tnfConfig, err := os.ReadFile("config/certsuite_config.yml")
-	if err != nil {
-		log.Fatal("Error reading YAML file: %v", err) //nolint:gocritic // exitAfterDefer
-	}
+func (certsuiteRunner) Run(job *Job) (string, error) {
+	// The jobs only change the parameters of the files they have.
+	params := configuration.GetTestParameters()
+	savedParams := *params
+	defer func() { *params = savedParams }()
+	params.ConfigFile = job.ConfigFile
+	params.Kubeconfig = job.KubeconfigFile
+	params.LabelsFilter = job.LabelsFilter
+	params.OutputDir = job.OutputDir
 
-	newData := updateTnf(tnfConfig, &data)
+	configuration.ResetConfiguration()
+	provider.ResetTestEnvironment()
+	checksdb.ResetChecksDB()
 
-	// Write the modified YAML data back to the file
-	var filePerm fs.FileMode = 0o644 // owner can read/write, group and others can only read
-	err = os.WriteFile("config/certsuite_config.yml", newData, filePerm)
-	if err != nil {
-		log.Fatal("Error writing YAML file: %v", err)
+	serverLogger := log.GetLogger()
+	if err := log.CreateGlobalLogFile(job.OutputDir, "debug"); err != nil {
+		return "", fmt.Errorf("could not create the log file: %w", err)
 	}
-	labelsFilter := strings.Join(flattenedOptions, ",")
-
-	_ = clientsholder.GetNewClientsHolder(kubeconfigTempFile.Name())
-	certsuite.LoadChecksDB(labelsFilter)
+	defer func() {
+		if err := log.CloseGlobalLogFile(); err != nil {
+			log.Error("Could not close the log file of job %s: %v", job.ID, err)
+		}
+	}()
 
-	outputFolder := r.Context().Value(outputFolderCtxKey).(string)
+	// The log output is written to the job log file and to the job logs, streamed to the web page,
+	// until the job finishes.
+	log.SetLogger(log.GetMultiLogger(job.logs))
+	defer log.SetLogger(serverLogger)
 
-	if err := checksdb.InitLabelsExprEvaluator(labelsFilter); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to initialize a test case label evaluator, err: %v", err)
-		os.Exit(1)
+	if err := checksdb.InitLabelsExprEvaluator(job.LabelsFilter); err != nil {
+		return "", fmt.Errorf("failed to initialize a test case label evaluator: %w", err)
 	}
 
-	if err := log.CreateGlobalLogFile(outputFolder, "debug"); err != nil {
-		fmt.Fprintf(os.Stderr, "Could not create the log file, err: %v\n", err)
-		os.Exit(1)
+	// The configuration and the clients are loaded before the run, that would exit on errors.
+	if _, err := configuration.LoadConfiguration(job.ConfigFile); err != nil {
+		return "", fmt.Errorf("cannot load configuration file: %w", err)
 	}
-
-	log.Info("Running CNF Cert Suite (web-mode). Labels filter: %s, outputFolder: %s", labelsFilter, outputFolder)
-	err = certsuite.Run(labelsFilter, outputFolder)
-	if err != nil {
-		log.Error("Failed to run CNF Cert Suite: %v", err)
+	if _, err := clientsholder.LoadClientsHolder(job.KubeconfigFile); err != nil {
+		return "", fmt.Errorf("failed to create the k8s clients: %w", err)
 	}
+	certsuite.LoadChecksDB(job.LabelsFilter)
 
-	// Return the result as JSON
-	response := struct {
-		Message string `json:"Message"`
-	}{
-		Message: fmt.Sprintf("Succeeded to run %s", strings.Join(flattenedOptions, " ")),
+	log.Info("Running CNF Cert Suite (web-mode). Job: %s, labels filter: %s, outputFolder: %s", job.ID, job.LabelsFilter, job.OutputDir)
+	runErr := certsuite.Run(job.LabelsFilter, job.OutputDir)
+	if runErr != nil {
+		log.Error("Failed to run CNF Cert Suite: %v", runErr)
 	}
-	// Serialize the response data to JSON
-	jsonResponse, err := json.Marshal(response)
+
+	artifactsFile, err := results.GetResultsArtifactsFile(job.OutputDir)
 	if err != nil {
-		log.Error("Failed to marshal jsonResponse: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		log.Error("%v", err)
 	}
 
-	// Set the Content-Type header to specify that the response is JSON
-	w.Header().Set("Content-Type", "application/json")
-	// Write the JSON response to the client
-	log.Info("Sending web response: %v", response)
-	_, err = w.Write(jsonResponse)
-	if err != nil {
-		log.Error("Failed to write jsonResponse: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	return artifactsFile, runErr
+}
+
+func (certsuiteRunner) CancelRun() {
+	checksdb.CancelRun("job canceled")
+}
+
+// runHandler returns the handler of the web page form, that runs a job with the configuration file
+// updated with the form fields, and responds once it finishes.
+func runHandler(jobs *JobQueue) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+
+		jsonData := r.FormValue("jsonData") // "jsonData" is the name of the JSON input field
+		var data RequestedData
+		if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
+			http.Error(w, "Invalid form data", http.StatusBadRequest)
+			return
+		}
+		flattenedOptions := data.SelectedOptions
+
+		// Get the file from the request
+		kubeconfig, err := readFormFile(r, "kubeConfigPath")
+		if err != nil {
+			http.Error(w, "Unable to retrieve file from form", http.StatusBadRequest)
+			return
+		}
+		if err := validateKubeconfig(kubeconfig); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		log.Info("Web Server Labels filter   : %v", flattenedOptions)
+
+		tnfConfig, err := os.ReadFile(configuration.GetTestParameters().ConfigFile)
+		if err != nil {
+			http.Error(w, "Failed to read the configuration file", http.StatusInternalServerError)
+			return
+		}
+
+		// The job has its own copy of the configuration file, updated with the form fields.
+		newData := updateTnf(tnfConfig, &data)
+		labelsFilter := strings.Join(flattenedOptions, ",")
+
+		job, err := jobs.Submit(getUser(r), kubeconfig, newData, labelsFilter)
+		if err != nil {
+			http.Error(w, err.Error(), jobErrorStatus(err))
+			return
+		}
+
+		job, err = jobs.Wait(job.ID)
+		if err != nil {
+			http.Error(w, err.Error(), jobErrorStatus(err))
+			return
+		}
+
+		message := fmt.Sprintf("Succeeded to run %s", strings.Join(flattenedOptions, " "))
+		if job.State != JobStateCompleted {
+			message = fmt.Sprintf("Job %s %s: %s", job.ID, job.State, job.Error)
+		}
+
+		log.Info("Sending web response: %v", message)
+		writeJSONResponse(w, http.StatusOK, struct {
+			Message string `json:"Message"`
+		}{Message: message})
 	}
 }
 
@@ -2,8 +2,13 @@
 package webserver
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/redhat-best-practices-for-k8s/certsuite-claim/pkg/claim"
 	"github.com/redhat-best-practices-for-k8s/certsuite/pkg/configuration"
 	"github.com/stretchr/testify/assert"
@@ -224,3 +229,88 @@ func TestCreatePrintableCatalogFromIdentifiers(t *testing.T) {
 		})
 	}
 }
+
+// newTestLogStreamServer returns a server streaming the logs of the jobs of the queue, and a channel
+// where every finished stream is signaled.
+func newTestLogStreamServer(t *testing.T, jobs *JobQueue) (*httptest.Server, chan struct{}) {
+	t.Helper()
+
+	streamsDone := make(chan struct{}, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() { streamsDone <- struct{}{} }()
+		logStreamHandler(jobs)(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, streamsDone
+}
+
+func dialTestLogStream(t *testing.T, server *httptest.Server) *websocket.Conn {
+	t.Helper()
+
+	conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func readTestLogLine(t *testing.T, conn *websocket.Conn) string {
+	t.Helper()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, line, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	return string(line)
+}
+
+func TestStreamLogsViewers(t *testing.T) {
+	runner := newFakeRunner()
+	jobs, err := NewJobQueue(t.TempDir(), runner, nil)
+	require.NoError(t, err)
+	server, _ := newTestLogStreamServer(t, jobs)
+
+	_, err = jobs.Submit("", []byte("kubeconfig"), []byte("config"), "observability")
+	require.NoError(t, err)
+	running := <-runner.started
+
+	_, err = running.logs.Write([]byte("first\nsec"))
+	require.NoError(t, err)
+
+	// Both viewers get all the lines, the second one starting after the first was sent.
+	viewer1 := dialTestLogStream(t, server)
+	assert.Equal(t, "first<br>", readTestLogLine(t, viewer1))
+	viewer2 := dialTestLogStream(t, server)
+	assert.Equal(t, "first<br>", readTestLogLine(t, viewer2))
+
+	_, err = running.logs.Write([]byte("ond\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "second<br>", readTestLogLine(t, viewer1))
+	assert.Equal(t, "second<br>", readTestLogLine(t, viewer2))
+
+	// The last line is sent once the job finishes, even without its end of line.
+	_, err = running.logs.Write([]byte("last"))
+	require.NoError(t, err)
+	runner.release <- nil
+	assert.Equal(t, "last<br>", readTestLogLine(t, viewer1))
+	assert.Equal(t, "last<br>", readTestLogLine(t, viewer2))
+}
+
+func TestStreamLogsClientClosed(t *testing.T) {
+	runner := newFakeRunner()
+	jobs, err := NewJobQueue(t.TempDir(), runner, nil)
+	require.NoError(t, err)
+	server, streamsDone := newTestLogStreamServer(t, jobs)
+
+	// The stream of an idle client, with no job running, returns once the client is gone.
+	conn := dialTestLogStream(t, server)
+	conn.Close()
+
+	select {
+	case <-streamsDone:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "the log stream did not return after the client closed the connection")
+	}
+}